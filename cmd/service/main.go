@@ -13,14 +13,19 @@ import (
 	"echo-app/internal/config"
 	"echo-app/internal/db"
 	"echo-app/internal/permify"
+	"echo-app/internal/repositories"
 	"echo-app/internal/server"
 	"echo-app/internal/server/routes"
+	"echo-app/internal/services/outbox"
+	"echo-app/internal/services/schema"
+	"echo-app/internal/services/session"
 	"echo-app/internal/slogx"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 const shutdownTimeout = 20 * time.Second
@@ -55,34 +60,58 @@ func run() error {
 		return fmt.Errorf("parse env: %w", err)
 	}
 
+	providers, err := config.ParseOIDCProviders(cfg.Auth)
+	if err != nil {
+		return fmt.Errorf("parse OIDC providers: %w", err)
+	}
+
+	cfg.Auth.Providers = providers
+
 	docs.SwaggerInfo.Host = fmt.Sprintf("%s:%s", cfg.HTTP.Host, cfg.HTTP.Port)
 
 	if err := slogx.Init(cfg.Logger); err != nil {
 		return fmt.Errorf("init logger: %w", err)
 	}
 
-	err := permify.InitClient()
-
+	// ✅ Setup DB and server after
+	gormDB, err := db.NewGormDB(cfg.DB)
 	if err != nil {
-		return fmt.Errorf("Error initng Permify Module: %v", err)
+		return fmt.Errorf("new db connection: %w", err)
 	}
 
+	authorizer, err := newAuthorizer(context.Background(), cfg.Permify, gormDB)
+	if err != nil {
+		return fmt.Errorf("Error initng Permify Module: %w", err)
+	}
 
+	relationshipOutbox := outbox.NewService(
+		repositories.NewOutboxRepository(gormDB),
+		authorizer,
+		repositories.NewTransactor(gormDB),
+		cfg.Outbox,
+	)
 
-	// ✅ Setup DB and server after
-	gormDB, err := db.NewGormDB(cfg.DB)
+	sessionStore, closeSessionStore, err := newSessionStore(context.Background(), cfg, gormDB)
 	if err != nil {
-		return fmt.Errorf("new db connection: %w", err)
+		return fmt.Errorf("new session store: %w", err)
 	}
+	defer closeSessionStore()
 
 	app := server.NewServer(echo.New(), gormDB, &cfg)
-	routes.ConfigureRoutes(slogx.NewTraceStarter(uuid.NewV7), app)
-
-	schemaVer, err := permify.UploadSchema(context.Background(),"t1")
+	err = routes.ConfigureRoutes(slogx.NewTraceStarter(uuid.NewV7), app, authorizer, relationshipOutbox, sessionStore)
 	if err != nil {
-		return fmt.Errorf("error on Upload Schema: %w", err)
+		return fmt.Errorf("configure routes: %w", err)
 	}
-	slog.Info("✅ Permify schema uploaded", "version", schemaVer)
+
+	dispatcherCtx, stopDispatcher := context.WithCancel(context.Background())
+	defer stopDispatcher()
+
+	dispatcherDone := make(chan struct{})
+
+	go func() {
+		defer close(dispatcherDone)
+		relationshipOutbox.Run(dispatcherCtx)
+	}()
 
 	go func() {
 		if err = app.Start(cfg.HTTP.Port); err != nil {
@@ -102,6 +131,9 @@ func run() error {
 		return fmt.Errorf("http server shutdown: %w", err)
 	}
 
+	stopDispatcher()
+	<-dispatcherDone
+
 	dbConnection, err := gormDB.DB()
 	if err != nil {
 		return fmt.Errorf("get db connection: %w", err)
@@ -111,6 +143,60 @@ func run() error {
 		return fmt.Errorf("close db connection: %w", err)
 	}
 
-
 	return nil
 }
+
+// newAuthorizer connects to Permify and syncs the schema of the tenant, or evaluates the embedded schema
+// in memory for local development.
+func newAuthorizer(ctx context.Context, cfg config.Permify, gormDB *gorm.DB) (permify.Authorizer, error) {
+	if cfg.Driver == "memory" {
+		content, err := permify.Schema()
+		if err != nil {
+			return nil, fmt.Errorf("load embedded schema: %w", err)
+		}
+
+		slog.Warn("Permify runs in memory, relationships are lost on restart")
+
+		return permify.NewMemoryAuthorizer(content)
+	}
+
+	client, err := permify.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaService := schema.NewService(repositories.NewSchemaVersionRepository(gormDB), client, cfg)
+
+	schemaVer, err := schemaService.Sync(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("sync permify schema: %w", err)
+	}
+
+	client.PinSchemaVersion(schemaVer)
+	slog.Info("✅ Permify schema synced", "version", schemaVer)
+
+	return client, nil
+}
+
+// newSessionStore returns the session store of the configuration together with a function closing it.
+func newSessionStore(ctx context.Context, cfg config.Config, gormDB *gorm.DB) (session.Store, func(), error) {
+	switch cfg.Session.Store {
+	case "postgres":
+		return repositories.NewSessionRepository(gormDB), func() {}, nil
+	case "redis":
+		client, err := db.NewRedisClient(ctx, cfg.Redis)
+		if err != nil {
+			return nil, nil, fmt.Errorf("new redis client: %w", err)
+		}
+
+		closeClient := func() {
+			if err := client.Close(); err != nil {
+				slog.Error("Failed to close redis client", "err", err.Error())
+			}
+		}
+
+		return repositories.NewRedisSessionRepository(client, cfg.Session.IdleTTL), closeClient, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported session store %q", cfg.Session.Store)
+	}
+}
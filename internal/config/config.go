@@ -1,10 +1,21 @@
 package config
 
+import "time"
+
 type Config struct {
-	Logger Log
-	Auth   Auth
-	DB     DB
-	HTTP   HTTP
+	Logger  Log
+	Auth    Auth
+	JWT     JWT
+	Refresh RefreshToken
+	PAT     PersonalAccessToken
+	Session Session
+	MFA     MFA
+	Redis   Redis
+	Mail    Mail
+	Outbox  Outbox
+	Permify Permify
+	DB      DB
+	HTTP    HTTP
 }
 
 type Log struct {
@@ -22,12 +33,118 @@ type DB struct {
 	Port     string `env:"DB_PORT"`
 }
 
+// Auth configures the OIDC login. The OIDC_ISSUER variables configure the default identity provider,
+// OIDCProviders names further ones, see OIDCProvider.
 type Auth struct {
-	OIDCIssuer          string
-	OIDCClientID        string
-	OIDCClientSecret    string
-	OIDCRedirectURL     string
-	OIDCSuccessRedirect string
+	OIDCIssuer          string   `env:"OIDC_ISSUER"`
+	OIDCClientID        string   `env:"OIDC_CLIENT_ID"`
+	OIDCClientSecret    string   `env:"OIDC_CLIENT_SECRET"`
+	OIDCRedirectURL     string   `env:"OIDC_REDIRECT_URL"`
+	OIDCSuccessRedirect string   `env:"OIDC_SUCCESS_REDIRECT"`
+	OIDCProviders       []string `env:"OIDC_PROVIDERS" envSeparator:","`
+	// Providers are parsed from the variables above by ParseOIDCProviders.
+	Providers []OIDCProvider `env:"-"`
+	// OIDCPostLogoutRedirect is where the identity provider sends the user after logging out, it has to be registered there.
+	OIDCPostLogoutRedirect string `env:"OIDC_POST_LOGOUT_REDIRECT"`
+	// OIDCCookieSecret encrypts the cookie keeping the state, PKCE code verifier and nonce of the login.
+	OIDCCookieSecret string `env:"OIDC_COOKIE_SECRET"`
+	// OIDCReturnToAllowlist are the origins the return_to parameter of the login may redirect to,
+	// the origin of OIDCSuccessRedirect is always allowed.
+	OIDCReturnToAllowlist []string `env:"OIDC_RETURN_TO_ALLOWLIST" envSeparator:","`
+	// OIDCGroupsClaim and OIDCGroupMapping configure the group sync of the default provider, see OIDCProvider.
+	OIDCGroupsClaim  string `env:"OIDC_GROUPS_CLAIM" envDefault:"groups"`
+	OIDCGroupMapping string `env:"OIDC_GROUP_MAPPING"`
+}
+
+// JWT configures the access tokens issued by the service.
+// SigningMethod is one of HS256, RS256 or EdDSA. HS256 signs with Secret,
+// the asymmetric methods sign with the PEM encoded key from PrivateKeyFile.
+type JWT struct {
+	SigningMethod        string        `env:"JWT_SIGNING_METHOD" envDefault:"HS256"`
+	Secret               string        `env:"ACCESS_SECRET"`
+	PrivateKeyFile       string        `env:"JWT_PRIVATE_KEY_FILE"`
+	KeyID                string        `env:"JWT_KEY_ID"`
+	Issuer               string        `env:"JWT_ISSUER" envDefault:"echo-app"`
+	AccessTokenTTL       time.Duration `env:"ACCESS_TOKEN_TTL" envDefault:"15m"`
+	EmailVerificationTTL time.Duration `env:"EMAIL_VERIFICATION_TOKEN_TTL" envDefault:"24h"`
+	// MFATokenTTL is how long the second step of a password login may take.
+	MFATokenTTL time.Duration `env:"MFA_TOKEN_TTL" envDefault:"5m"`
+}
+
+// RefreshToken configures the opaque refresh tokens. Secret is used as the HMAC key
+// for the token hashes stored in the database.
+type RefreshToken struct {
+	Secret string        `env:"REFRESH_SECRET"`
+	TTL    time.Duration `env:"REFRESH_TOKEN_TTL" envDefault:"720h"`
+}
+
+// PersonalAccessToken configures the tokens of API clients. Secret is used as the HMAC key
+// for the token hashes stored in the database, tokens expire MaxTTL after creation at the latest.
+type PersonalAccessToken struct {
+	Secret string        `env:"PAT_SECRET"`
+	MaxTTL time.Duration `env:"PAT_MAX_TTL" envDefault:"8760h"`
+}
+
+// MFA configures the TOTP two-factor authentication of password logins.
+// Issuer names the service in the authenticator apps.
+type MFA struct {
+	Issuer string `env:"MFA_ISSUER" envDefault:"echo-app"`
+}
+
+// Session configures the server side sessions of the logins. Store is "postgres" or "redis".
+// A session nobody used for IdleTTL expires, refreshing the access token uses it.
+type Session struct {
+	Store   string        `env:"SESSION_STORE" envDefault:"postgres"`
+	IdleTTL time.Duration `env:"SESSION_IDLE_TTL" envDefault:"720h"`
+}
+
+// Redis configures the connection to Redis, it's only used by the redis session store.
+type Redis struct {
+	Addr     string `env:"REDIS_ADDR" envDefault:"localhost:6379"`
+	Password string `env:"REDIS_PASSWORD"`
+	DB       int    `env:"REDIS_DB"`
+}
+
+// Mail configures outgoing emails. Driver is "log" to write emails to the application log
+// or "file" to store them as .eml files in Dir.
+type Mail struct {
+	Driver          string `env:"MAIL_DRIVER" envDefault:"log"`
+	From            string `env:"MAIL_FROM" envDefault:"no-reply@localhost"`
+	Dir             string `env:"MAIL_DIR" envDefault:"./mail"`
+	VerificationURL string `env:"EMAIL_VERIFICATION_URL"`
+}
+
+// Outbox configures the dispatcher pushing relationship changes from the outbox table to Permify.
+// Failed entries are retried with exponential backoff between MinBackoff and MaxBackoff
+// and given up after MaxAttempts attempts.
+type Outbox struct {
+	PollInterval time.Duration `env:"OUTBOX_POLL_INTERVAL" envDefault:"1s"`
+	BatchSize    int           `env:"OUTBOX_BATCH_SIZE" envDefault:"100"`
+	MinBackoff   time.Duration `env:"OUTBOX_MIN_BACKOFF" envDefault:"1s"`
+	MaxBackoff   time.Duration `env:"OUTBOX_MAX_BACKOFF" envDefault:"5m"`
+	MaxAttempts  int           `env:"OUTBOX_MAX_ATTEMPTS" envDefault:"20"`
+}
+
+// Permify configures the authorization. Driver is "grpc" to use a Permify server or "memory" to evaluate
+// the embedded schema in process, which keeps relationships only until the service stops. TLSEnabled dials with TLS, verifying the server with the
+// certificate from CAFile or with the system roots when CAFile is empty. Token is sent as a bearer token
+// when Permify runs with preshared key authentication. Every environment uses its own TenantID.
+// AllowSchemaDrift lets the service replace an active schema version that differs from the embedded schema.
+// BulkLimit caps concurrent permission checks, it defaults to the bulk_limit of the Permify server
+// configuration in ServerConfigFile, or to the default of Permify when the file doesn't exist.
+type Permify struct {
+	Driver     string        `env:"PERMIFY_DRIVER" envDefault:"grpc"`
+	Endpoint   string        `env:"PERMIFY_ENDPOINT" envDefault:"permify:3478"`
+	TLSEnabled bool          `env:"PERMIFY_TLS_ENABLED"`
+	CAFile     string        `env:"PERMIFY_CA_FILE"`
+	Token      string        `env:"PERMIFY_TOKEN"`
+	TenantID   string        `env:"PERMIFY_TENANT_ID" envDefault:"t1"`
+	Timeout    time.Duration `env:"PERMIFY_TIMEOUT" envDefault:"5s"`
+	CheckDepth int32         `env:"PERMIFY_CHECK_DEPTH" envDefault:"50"`
+
+	AllowSchemaDrift bool   `env:"PERMIFY_ALLOW_SCHEMA_DRIFT"`
+	BulkLimit        int    `env:"PERMIFY_BULK_LIMIT"`
+	ServerConfigFile string `env:"PERMIFY_SERVER_CONFIG_FILE" envDefault:"config/permify/config.yaml"`
 }
 
 type HTTP struct {
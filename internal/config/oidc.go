@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/caarlos0/env/v11"
+)
+
+// DefaultOIDCProvider is the name of the identity provider configured with the OIDC_ISSUER variables.
+const DefaultOIDCProvider = "default"
+
+var providerName = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+// OIDCProvider configures an identity provider. The providers listed in OIDC_PROVIDERS are configured
+// with variables prefixed with OIDC_<NAME>_, e.g. OIDC_GOOGLE_ISSUER for the provider named google.
+type OIDCProvider struct {
+	Name         string `env:"-"`
+	Issuer       string `env:"ISSUER,required"`
+	ClientID     string `env:"CLIENT_ID,required"`
+	ClientSecret string `env:"CLIENT_SECRET"`
+	// RedirectURL is the callback of the provider, /callback/<name>.
+	RedirectURL string   `env:"REDIRECT_URL,required"`
+	Scopes      []string `env:"SCOPES" envSeparator:"," envDefault:"openid,profile,email"`
+	// GroupsClaim is the ID token claim with the groups of the user, GroupMapping maps them to domain roles,
+	// see membership.ParseGroupMapping. Logins with a provider without a mapping leave the domain roles alone.
+	GroupsClaim  string `env:"GROUPS_CLAIM" envDefault:"groups"`
+	GroupMapping string `env:"GROUP_MAPPING"`
+}
+
+// ParseOIDCProviders returns the identity providers, the provider of the OIDC_ISSUER variables comes first.
+func ParseOIDCProviders(auth Auth) ([]OIDCProvider, error) {
+	providers := make([]OIDCProvider, 0, len(auth.OIDCProviders)+1)
+
+	if auth.OIDCIssuer != "" {
+		providers = append(providers, OIDCProvider{
+			Name:         DefaultOIDCProvider,
+			Issuer:       auth.OIDCIssuer,
+			ClientID:     auth.OIDCClientID,
+			ClientSecret: auth.OIDCClientSecret,
+			RedirectURL:  auth.OIDCRedirectURL,
+			Scopes:       []string{"openid", "profile", "email"},
+			GroupsClaim:  auth.OIDCGroupsClaim,
+			GroupMapping: auth.OIDCGroupMapping,
+		})
+	}
+
+	for _, name := range auth.OIDCProviders {
+		name = strings.TrimSpace(name)
+		if !providerName.MatchString(name) || name == DefaultOIDCProvider {
+			return nil, fmt.Errorf("invalid OIDC provider name %q", name)
+		}
+
+		provider := OIDCProvider{Name: name}
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		if err := env.ParseWithOptions(&provider, env.Options{Prefix: prefix}); err != nil {
+			return nil, fmt.Errorf("parse OIDC provider %s: %w", name, err)
+		}
+
+		providers = append(providers, provider)
+	}
+
+	return providers, nil
+}
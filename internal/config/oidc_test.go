@@ -0,0 +1,77 @@
+package config_test
+
+import (
+	"testing"
+
+	"echo-app/internal/config"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOIDCProviders(t *testing.T) {
+	t.Run("It should parse the default and the named providers", func(t *testing.T) {
+		t.Setenv("OIDC_ISSUER", "https://authentik.example.com/application/o/echo-app/")
+		t.Setenv("OIDC_CLIENT_ID", "authentik-client")
+		t.Setenv("OIDC_REDIRECT_URL", "https://api.example.com/callback")
+		t.Setenv("OIDC_PROVIDERS", "google,azure-ad")
+		t.Setenv("OIDC_GOOGLE_ISSUER", "https://accounts.google.com")
+		t.Setenv("OIDC_GOOGLE_CLIENT_ID", "google-client")
+		t.Setenv("OIDC_GOOGLE_CLIENT_SECRET", "google-secret")
+		t.Setenv("OIDC_GOOGLE_REDIRECT_URL", "https://api.example.com/callback/google")
+		t.Setenv("OIDC_AZURE_AD_ISSUER", "https://login.microsoftonline.com/tenant/v2.0")
+		t.Setenv("OIDC_AZURE_AD_CLIENT_ID", "azure-client")
+		t.Setenv("OIDC_AZURE_AD_REDIRECT_URL", "https://api.example.com/callback/azure-ad")
+		t.Setenv("OIDC_AZURE_AD_SCOPES", "openid,email")
+		t.Setenv("OIDC_AZURE_AD_GROUPS_CLAIM", "roles")
+		t.Setenv("OIDC_AZURE_AD_GROUP_MAPPING", "acme=11111111-1111-1111-1111-111111111111:member")
+		t.Setenv("OIDC_GROUP_MAPPING", "acme-admins=11111111-1111-1111-1111-111111111111:admin")
+
+		var cfg config.Config
+		require.NoError(t, env.Parse(&cfg))
+
+		providers, err := config.ParseOIDCProviders(cfg.Auth)
+		require.NoError(t, err)
+
+		assert.Equal(t, []config.OIDCProvider{
+			{
+				Name:         config.DefaultOIDCProvider,
+				Issuer:       "https://authentik.example.com/application/o/echo-app/",
+				ClientID:     "authentik-client",
+				RedirectURL:  "https://api.example.com/callback",
+				Scopes:       []string{"openid", "profile", "email"},
+				GroupsClaim:  "groups",
+				GroupMapping: "acme-admins=11111111-1111-1111-1111-111111111111:admin",
+			},
+			{
+				Name:         "google",
+				Issuer:       "https://accounts.google.com",
+				ClientID:     "google-client",
+				ClientSecret: "google-secret",
+				RedirectURL:  "https://api.example.com/callback/google",
+				Scopes:       []string{"openid", "profile", "email"},
+				GroupsClaim:  "groups",
+			},
+			{
+				Name:         "azure-ad",
+				Issuer:       "https://login.microsoftonline.com/tenant/v2.0",
+				ClientID:     "azure-client",
+				RedirectURL:  "https://api.example.com/callback/azure-ad",
+				Scopes:       []string{"openid", "email"},
+				GroupsClaim:  "roles",
+				GroupMapping: "acme=11111111-1111-1111-1111-111111111111:member",
+			},
+		}, providers)
+	})
+
+	t.Run("It should reject a provider without an issuer", func(t *testing.T) {
+		_, err := config.ParseOIDCProviders(config.Auth{OIDCProviders: []string{"missing"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("It should reject an invalid provider name", func(t *testing.T) {
+		_, err := config.ParseOIDCProviders(config.Auth{OIDCProviders: []string{"Google/1"}})
+		assert.Error(t, err)
+	})
+}
@@ -10,7 +10,7 @@ import (
 )
 
 func NewGormDB(cfg config.DB) (*gorm.DB, error) {
-	db, err := gorm.Open(postgres.Open(dsn(cfg)), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn(cfg)), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, fmt.Errorf("open db connection: %w", err)
 	}
@@ -0,0 +1,58 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileMailer stores every email as an .eml file in the directory, so it can be opened by a mail client.
+type FileMailer struct {
+	dir string
+	now func() time.Time
+}
+
+func NewFileMailer(dir string) FileMailer {
+	return FileMailer{dir: dir, now: time.Now}
+}
+
+func (m FileMailer) Send(_ context.Context, message Message) error {
+	const (
+		dirPermission  = 0o750
+		filePermission = 0o600
+	)
+
+	if err := os.MkdirAll(m.dir, dirPermission); err != nil {
+		return fmt.Errorf("create mail directory: %w", err)
+	}
+
+	now := m.now()
+
+	var content strings.Builder
+	fmt.Fprintf(&content, "From: %s\r\n", message.From)
+	fmt.Fprintf(&content, "To: %s\r\n", message.To)
+	fmt.Fprintf(&content, "Subject: %s\r\n", message.Subject)
+	fmt.Fprintf(&content, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	content.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	content.WriteString(message.Body)
+
+	name := fmt.Sprintf("%d-%s.eml", now.UnixNano(), sanitizeFileName(message.To))
+
+	if err := os.WriteFile(filepath.Join(m.dir, name), []byte(content.String()), filePermission); err != nil {
+		return fmt.Errorf("write mail file: %w", err)
+	}
+
+	return nil
+}
+
+func sanitizeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, name)
+}
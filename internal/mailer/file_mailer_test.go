@@ -0,0 +1,37 @@
+package mailer_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"echo-app/internal/mailer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileMailer_Send(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+
+	fileMailer := mailer.NewFileMailer(dir)
+
+	err := fileMailer.Send(t.Context(), mailer.Message{
+		From:    "no-reply@localhost",
+		To:      "example@email.com",
+		Subject: "Subject",
+		Body:    "Body",
+	})
+	require.NoError(t, err)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	content, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(content), "To: example@email.com\r\n")
+	assert.Contains(t, string(content), "Subject: Subject\r\n")
+	assert.Contains(t, string(content), "\r\n\r\nBody")
+}
@@ -0,0 +1,29 @@
+package mailer
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogMailer writes emails to the application log instead of sending them.
+// It's meant for local development only, the log contains the whole message.
+type LogMailer struct{}
+
+func NewLogMailer() LogMailer {
+	return LogMailer{}
+}
+
+func (LogMailer) Send(ctx context.Context, message Message) error {
+	slog.InfoContext(
+		ctx,
+		"Email sent",
+		slog.Group("email",
+			"from", message.From,
+			"to", message.To,
+			"subject", message.Subject,
+			"body", message.Body,
+		),
+	)
+
+	return nil
+}
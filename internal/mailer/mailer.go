@@ -0,0 +1,32 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+
+	"echo-app/internal/config"
+)
+
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers emails. Implementations must be safe for concurrent use.
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// New creates the mailer selected by the config driver.
+func New(cfg config.Mail) (Mailer, error) {
+	switch cfg.Driver {
+	case "", "log":
+		return NewLogMailer(), nil
+	case "file":
+		return NewFileMailer(cfg.Dir), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain is a tenant. Members and admins of the domain are kept in Permify only.
+type Domain struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	Name      string
+	SnapToken string `gorm:"->"`
+	CreatedAt time.Time
+}
+
+// DomainMember is a user together with their role in the domain.
+type DomainMember struct {
+	User User
+	Role string
+}
@@ -3,6 +3,13 @@ package models
 import "errors"
 
 var (
-	ErrUserNotFound = errors.New("user not found")
-	ErrPostNotFound = errors.New("post not found")
+	ErrUserNotFound                = errors.New("user not found")
+	ErrPostNotFound                = errors.New("post not found")
+	ErrRefreshTokenNotFound        = errors.New("refresh token not found")
+	ErrSessionNotFound             = errors.New("session not found")
+	ErrPersonalAccessTokenNotFound = errors.New("personal access token not found")
+	ErrDomainNotFound              = errors.New("domain not found")
+	ErrDomainNameTaken             = errors.New("domain name is already taken")
+	ErrMemberNotFound              = errors.New("domain member not found")
+	ErrSchemaVersionNotFound       = errors.New("schema version not found")
 )
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+type OutboxOperation string
+
+const (
+	// OutboxWrite writes the relationship tuple.
+	OutboxWrite OutboxOperation = "write"
+	// OutboxDelete deletes the relationships of the entity, narrowed down by the relation and subject when set.
+	OutboxDelete OutboxOperation = "delete"
+)
+
+// OutboxEntry is a pending Permify relationship change. Entries are inserted in the same
+// transaction as the change of the application data and dispatched to Permify afterwards.
+// FailedAt is set when the dispatcher gives the entry up, it no longer holds back the later entries of its entity.
+type OutboxEntry struct {
+	ID            uint `gorm:"primarykey"`
+	Operation     OutboxOperation
+	EntityType    string
+	EntityID      string
+	Relation      string
+	SubjectType   string
+	SubjectID     string
+	Attempts      int
+	LastError     string
+	SnapToken     string
+	NextAttemptAt time.Time
+	ProcessedAt   *time.Time
+	FailedAt      *time.Time
+	CreatedAt     time.Time
+}
+
+func (OutboxEntry) TableName() string {
+	return "permify_outbox"
+}
@@ -0,0 +1,13 @@
+package models
+
+// PermissionCheck asks for a permission of the current user on an entity.
+type PermissionCheck struct {
+	EntityType string
+	EntityID   string
+	Permission string
+}
+
+type PermissionCheckResult struct {
+	PermissionCheck
+	Allowed bool
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Scopes of the personal access tokens, each one allows the read or write endpoints of a resource.
+const (
+	ScopePostsRead    = "posts:read"
+	ScopePostsWrite   = "posts:write"
+	ScopeDomainsRead  = "domains:read"
+	ScopeDomainsWrite = "domains:write"
+)
+
+// PersonalAccessToken lets API clients authenticate as the user without a login session.
+// Only the hash of the token value is stored, the value is shown once when the token is created.
+type PersonalAccessToken struct {
+	ID         uint `gorm:"primarykey"`
+	UserID     uint
+	Name       string   `gorm:"type:varchar(100)"`
+	TokenHash  string   `gorm:"type:varchar(64)"`
+	Scopes     []string `gorm:"type:jsonb;serializer:json"`
+	ExpiresAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+func (t PersonalAccessToken) HasScope(scope string) bool {
+	return slices.Contains(t.Scopes, scope)
+}
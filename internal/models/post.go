@@ -1,6 +1,9 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
 
 type Post struct {
 	gorm.Model
@@ -8,4 +11,9 @@ type Post struct {
 	Content string `json:"content" gorm:"type:text"`
 	UserID  uint
 	User    User `gorm:"foreignkey:UserID"`
+	// DomainID is the domain the post belongs to, posts are only visible inside of it.
+	DomainID uuid.UUID `json:"domain_id" gorm:"type:uuid;not null"`
+	// SnapToken is the Permify snap token of the last relationship change of the post,
+	// it's written by the outbox dispatcher only.
+	SnapToken string `json:"-" gorm:"->"`
 }
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken is a persisted refresh token. Only the hash of the token value is stored.
+// Tokens issued by rotating each other share the same FamilyID.
+// Families started by an OIDC login keep the identity provider and its session ID, so back-channel logout can revoke them.
+type RefreshToken struct {
+	ID            uint `gorm:"primarykey"`
+	UserID        uint
+	FamilyID      uuid.UUID `gorm:"type:uuid"`
+	TokenHash     string    `gorm:"type:varchar(64)"`
+	OIDCProvider  string    `gorm:"column:oidc_provider;type:varchar(64)"`
+	OIDCSessionID string    `gorm:"column:oidc_session_id;type:varchar(255)"`
+	ExpiresAt     time.Time
+	UsedAt        *time.Time
+	RevokedAt     *time.Time
+	CreatedAt     time.Time
+}
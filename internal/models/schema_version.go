@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// SchemaVersion is a Permify schema version written by the service, the latest one of a tenant is active.
+type SchemaVersion struct {
+	ID        uint `gorm:"primarykey"`
+	TenantID  string
+	Hash      string
+	Version   string
+	CreatedAt time.Time
+}
+
+func (SchemaVersion) TableName() string {
+	return "permify_schema_versions"
+}
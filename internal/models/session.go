@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is a login of a user on a device. The refresh token family of the login has the ID of the session,
+// revoking the session revokes the family and rejects the access tokens issued in it.
+type Session struct {
+	ID        uuid.UUID `gorm:"type:uuid;primarykey"`
+	UserID    uint
+	Device    string `gorm:"type:varchar(200)"`
+	IP        string `gorm:"type:varchar(45)"`
+	UserAgent string `gorm:"type:text"`
+	// OIDCProvider and OIDCSessionID are the identity provider and its session for OIDC logins, back-channel logout
+	// refers to them. Session IDs are only unique per provider.
+	OIDCProvider  string `gorm:"column:oidc_provider;type:varchar(64)"`
+	OIDCSessionID string `gorm:"column:oidc_session_id;type:varchar(255)"`
+	CreatedAt     time.Time
+	LastSeenAt    time.Time
+	RevokedAt     *time.Time
+}
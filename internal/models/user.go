@@ -1,19 +1,75 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
 
 type User struct {
 	gorm.Model
-	Email       string `json:"email" gorm:"type:varchar(200);"`
-	Name        string `json:"name" gorm:"type:varchar(200);"`
-	Password    string `json:"password" gorm:"type:varchar(200);"`
-	OIDCSubject string `gorm:"uniqueIndex"`
-	Post        []Post
+	Email    string     `json:"email" gorm:"type:varchar(200);"`
+	Name     string     `json:"name" gorm:"type:varchar(200);"`
+	Password string     `json:"password" gorm:"type:varchar(200);"`
+	DomainID *uuid.UUID `json:"domain_id" gorm:"type:uuid"`
+	// EmailVerifiedAt is nil until the user confirms the email address.
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	// VerificationTokenID is the ID of the only verification token that is still accepted.
+	VerificationTokenID string `json:"-" gorm:"type:varchar(64)"`
+	// TOTPSecret is the base32 secret of the authenticator app, TOTPEnabledAt is set once a code confirmed it.
+	TOTPSecret    string     `json:"-" gorm:"column:totp_secret;type:varchar(64)"`
+	TOTPEnabledAt *time.Time `json:"-" gorm:"column:totp_enabled_at"`
+	// TOTPLastStep is the time step of the last accepted code, so a code can't be used twice.
+	TOTPLastStep int64 `json:"-" gorm:"column:totp_last_step"`
+	// MFAFailedAttempts counts the invalid codes since the last accepted one, too many lock the second step until MFALockedUntil.
+	MFAFailedAttempts int        `json:"-" gorm:"column:mfa_failed_attempts"`
+	MFALockedUntil    *time.Time `json:"-" gorm:"column:mfa_locked_until"`
+	Post              []Post
+}
+
+// MFAEnabled reports whether the password login of the user needs a second factor.
+// OIDC users have no password, their identity provider handles MFA.
+func (u User) MFAEnabled() bool {
+	return u.TOTPEnabledAt != nil && u.Password != ""
+}
+
+// MFARecoveryCode is a one-time code that replaces a TOTP code when the authenticator app is lost.
+// Only the bcrypt hash of the code is stored.
+type MFARecoveryCode struct {
+	ID        uint `gorm:"primarykey"`
+	UserID    uint
+	CodeHash  string `gorm:"type:varchar(60)"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// UserIdentity links the user to their subject at an OIDC identity provider, a user can have one per provider.
+type UserIdentity struct {
+	ID        uint `gorm:"primarykey"`
+	UserID    uint
+	Provider  string `gorm:"type:varchar(64)"`
+	Subject   string `gorm:"type:varchar(255)"`
+	CreatedAt time.Time
 }
 
 type OIDCClaims struct {
-	Email    string `json:"email"`
-	Name     string `json:"name"`
-	Username string `json:"preferred_username"`
-	Sub      string `json:"sub"`
+	Email string `json:"email"`
+	// EmailVerified is required to link the login to an existing user with the same email.
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Username      string `json:"preferred_username"`
+	Sub           string `json:"sub"`
+	// SessionID is the session of the identity provider, back-channel logout refers to it.
+	SessionID string `json:"sid"`
+	// Groups are read from the claim configured with OIDC_GROUPS_CLAIM.
+	Groups []string `json:"-"`
+}
+
+// LogoutTokenClaims are the claims of an OIDC back-channel logout token.
+type LogoutTokenClaims struct {
+	Sub    string         `json:"sub"`
+	SID    string         `json:"sid"`
+	Nonce  string         `json:"nonce"`
+	Events map[string]any `json:"events"`
 }
@@ -0,0 +1,143 @@
+package oidcx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"echo-app/internal/config"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// discoveryTimeout bounds a discovery attempt, so a hanging identity provider doesn't hold up the login.
+	discoveryTimeout = 10 * time.Second
+	// retryInterval is how long a failed discovery is remembered before it's retried.
+	retryInterval = 5 * time.Second
+)
+
+var ErrUnavailable = errors.New("OIDC provider unavailable")
+
+// Discovery is the configuration of the identity provider discovered from its issuer.
+type Discovery struct {
+	OAuth2   *oauth2.Config
+	Verifier *oidc.IDTokenVerifier
+	// EndSessionEndpoint is empty when the provider doesn't support RP-initiated logout.
+	EndSessionEndpoint string
+}
+
+// Provider is an identity provider discovered on first use, so the service starts while the provider is down.
+// A failed discovery is retried by the first use after the retry interval.
+type Provider struct {
+	cfg   config.OIDCProvider
+	group singleflight.Group
+
+	mu          sync.Mutex
+	discovery   *Discovery
+	err         error
+	nextAttempt time.Time
+	now         func() time.Time
+}
+
+func NewProvider(cfg config.OIDCProvider) *Provider {
+	return &Provider{cfg: cfg, now: time.Now}
+}
+
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+// GroupsClaim is the ID token claim with the groups of the user.
+func (p *Provider) GroupsClaim() string {
+	return p.cfg.GroupsClaim
+}
+
+// Discover returns the discovered configuration of the provider, discovering it if needed. Concurrent calls share
+// one discovery, the lock is only held to read and store its result.
+func (p *Provider) Discover(ctx context.Context) (*Discovery, error) {
+	if discovery, ok, err := p.cached(); ok {
+		return discovery, err
+	}
+
+	result, err, _ := p.group.Do(p.cfg.Name, func() (any, error) {
+		// The discovery may have finished since the cache was checked
+		if discovery, ok, err := p.cached(); ok {
+			return discovery, err
+		}
+
+		// The shared discovery must not fail for the other callers when the first one is canceled
+		discovery, err := p.discover(context.WithoutCancel(ctx))
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		if err != nil {
+			p.err = fmt.Errorf("%w: %s: %w", ErrUnavailable, p.cfg.Name, err)
+			p.nextAttempt = p.now().Add(retryInterval)
+
+			return nil, p.err
+		}
+
+		p.discovery, p.err = discovery, nil
+
+		return discovery, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	discovery, _ := result.(*Discovery)
+
+	return discovery, nil
+}
+
+// cached returns the discovered configuration or the failure of the last discovery until the retry interval passes,
+// ok is false when the provider must be discovered.
+func (p *Provider) cached() (*Discovery, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, true, nil
+	}
+
+	if p.err != nil && p.now().Before(p.nextAttempt) {
+		return nil, true, p.err
+	}
+
+	return nil, false, nil
+}
+
+func (p *Provider) discover(ctx context.Context) (*Discovery, error) {
+	ctx, cancel := context.WithTimeout(ctx, discoveryTimeout)
+	defer cancel()
+
+	provider, err := oidc.NewProvider(ctx, p.cfg.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("discover issuer: %w", err)
+	}
+
+	var metadata struct {
+		EndSessionEndpoint string `json:"end_session_endpoint"`
+	}
+	if err := provider.Claims(&metadata); err != nil {
+		return nil, fmt.Errorf("parse provider metadata: %w", err)
+	}
+
+	return &Discovery{
+		OAuth2: &oauth2.Config{
+			ClientID:     p.cfg.ClientID,
+			ClientSecret: p.cfg.ClientSecret,
+			RedirectURL:  p.cfg.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       p.cfg.Scopes,
+		},
+		Verifier:           provider.Verifier(&oidc.Config{ClientID: p.cfg.ClientID}),
+		EndSessionEndpoint: metadata.EndSessionEndpoint,
+	}, nil
+}
@@ -0,0 +1,119 @@
+package oidcx_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"echo-app/internal/config"
+	"echo-app/internal/oidcx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newIssuer serves the discovery document of an issuer, it fails until up is set.
+func newIssuer(t *testing.T, up *atomic.Bool, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if !up.Load() || r.URL.Path != "/.well-known/openid-configuration" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                 server.URL,
+			"authorization_endpoint": server.URL + "/authorize",
+			"token_endpoint":         server.URL + "/token",
+			"jwks_uri":               server.URL + "/jwks",
+			"end_session_endpoint":   server.URL + "/end-session",
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestProvider_Discover(t *testing.T) {
+	t.Run("It should discover the provider once", func(t *testing.T) {
+		var (
+			up       atomic.Bool
+			requests atomic.Int32
+		)
+		up.Store(true)
+		issuer := newIssuer(t, &up, &requests)
+
+		provider := oidcx.NewProvider(config.OIDCProvider{Name: "test", Issuer: issuer.URL, ClientID: "client", Scopes: []string{"openid"}})
+
+		discovery, err := provider.Discover(t.Context())
+		require.NoError(t, err)
+
+		assert.Equal(t, issuer.URL+"/authorize", discovery.OAuth2.Endpoint.AuthURL)
+		assert.Equal(t, issuer.URL+"/end-session", discovery.EndSessionEndpoint)
+		assert.Equal(t, "client", discovery.OAuth2.ClientID)
+
+		_, err = provider.Discover(t.Context())
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("It should not retry a failed discovery before the retry interval", func(t *testing.T) {
+		var (
+			up       atomic.Bool
+			requests atomic.Int32
+		)
+		issuer := newIssuer(t, &up, &requests)
+
+		provider := oidcx.NewProvider(config.OIDCProvider{Name: "test", Issuer: issuer.URL, ClientID: "client"})
+
+		_, err := provider.Discover(t.Context())
+		require.ErrorIs(t, err, oidcx.ErrUnavailable)
+
+		up.Store(true)
+
+		_, err = provider.Discover(t.Context())
+		require.ErrorIs(t, err, oidcx.ErrUnavailable)
+
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("It should share one discovery between concurrent calls", func(t *testing.T) {
+		var (
+			up       atomic.Bool
+			requests atomic.Int32
+		)
+		up.Store(true)
+		issuer := newIssuer(t, &up, &requests)
+
+		provider := oidcx.NewProvider(config.OIDCProvider{Name: "test", Issuer: issuer.URL, ClientID: "client"})
+
+		var wg sync.WaitGroup
+		errs := make([]error, 10)
+
+		for i := range errs {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				_, errs[i] = provider.Discover(t.Context())
+			}()
+		}
+
+		wg.Wait()
+
+		for _, err := range errs {
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int32(1), requests.Load())
+	})
+}
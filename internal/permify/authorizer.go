@@ -0,0 +1,29 @@
+package permify
+
+import "context"
+
+// Authorizer is the part of Permify the service works with. Client talks to a Permify server,
+// MemoryAuthorizer evaluates the schema in process for tests and local development.
+type Authorizer interface {
+	// Check checks the permission of the user on the entity.
+	Check(ctx context.Context, request CheckRequest) (bool, error)
+	// SubjectPermission returns the result of every permission of the entity for the user, at least as fresh
+	// as the snap token.
+	SubjectPermission(ctx context.Context, entityType, entityID, userID, snapToken string) (map[string]bool, error)
+	// LookupEntity returns the IDs of all entities of the type the user has the permission on, at least as fresh
+	// as the snap token.
+	LookupEntity(ctx context.Context, entityType, permission, userID, snapToken string) ([]string, error)
+	// Write writes the tuples and returns the snap token of the write.
+	Write(ctx context.Context, tuples ...Tuple) (string, error)
+	// Delete deletes the relationships matching the filter and returns the snap token of the deletion.
+	Delete(ctx context.Context, filter Tuple) (string, error)
+	// Read returns the relationships matching the filter, at least as fresh as the snap token.
+	Read(ctx context.Context, filter Tuple, snapToken string) ([]Tuple, error)
+	// WriteSchema writes the schema and returns the new schema version.
+	WriteSchema(ctx context.Context, schema string) (string, error)
+}
+
+var (
+	_ Authorizer = (*Client)(nil)
+	_ Authorizer = (*MemoryAuthorizer)(nil)
+)
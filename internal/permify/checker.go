@@ -2,31 +2,119 @@ package permify
 
 import (
 	"context"
+	"fmt"
 
 	base "buf.build/gen/go/permifyco/permify/protocolbuffers/go/base/v1"
 )
 
-func CanUser(userID, domainID, permission, schemaVersion, snapToken string) (bool, error) {
-	res, err := Client.Permission.Check(context.Background(), &base.PermissionCheckRequest{
-		TenantId: "t1",
+// CheckRequest asks whether the user has the permission on the entity.
+type CheckRequest struct {
+	EntityType    string
+	EntityID      string
+	Permission    string
+	UserID        string
+	SchemaVersion string
+	SnapToken     string
+}
+
+// Check checks the permission of the user on any entity of the schema.
+func (c *Client) Check(ctx context.Context, request CheckRequest) (bool, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.grpc.Permission.Check(ctx, &base.PermissionCheckRequest{
+		TenantId: c.tenantID,
 		Metadata: &base.PermissionCheckRequestMetadata{
-			SchemaVersion: schemaVersion,
-			SnapToken:     snapToken,
-			Depth:         50,
+			SchemaVersion: c.schemaVersionOr(request.SchemaVersion),
+			SnapToken:     request.SnapToken,
+			Depth:         c.checkDepth,
 		},
 		Entity: &base.Entity{
-			Type: "domain",
-			Id:   domainID,
+			Type: request.EntityType,
+			Id:   request.EntityID,
 		},
-		Permission: permission,
+		Permission: request.Permission,
 		Subject: &base.Subject{
 			Type: "user",
-			Id:   userID,
+			Id:   request.UserID,
 		},
 	})
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("check permission: %w", err)
 	}
 
 	return res.Can == base.CheckResult_CHECK_RESULT_ALLOWED, nil
 }
+
+// SubjectPermission returns the result of every permission of the entity for the user, relations are left out.
+func (c *Client) SubjectPermission(ctx context.Context, entityType, entityID, userID, snapToken string) (map[string]bool, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.grpc.Permission.SubjectPermission(ctx, &base.PermissionSubjectPermissionRequest{
+		TenantId: c.tenantID,
+		Metadata: &base.PermissionSubjectPermissionRequestMetadata{
+			SchemaVersion:  c.schemaVersion,
+			SnapToken:      snapToken,
+			OnlyPermission: true,
+			Depth:          c.checkDepth,
+		},
+		Entity: &base.Entity{
+			Type: entityType,
+			Id:   entityID,
+		},
+		Subject: &base.Subject{
+			Type: "user",
+			Id:   userID,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get permissions on %s:%s: %w", entityType, entityID, err)
+	}
+
+	permissions := make(map[string]bool, len(res.Results))
+	for permission, result := range res.Results {
+		permissions[permission] = result == base.CheckResult_CHECK_RESULT_ALLOWED
+	}
+
+	return permissions, nil
+}
+
+// LookupEntity returns the IDs of all entities of the type the user has the permission on, at least as fresh
+// as the snap token.
+func (c *Client) LookupEntity(ctx context.Context, entityType, permission, userID, snapToken string) ([]string, error) {
+	request := &base.PermissionLookupEntityRequest{
+		TenantId: c.tenantID,
+		Metadata: &base.PermissionLookupEntityRequestMetadata{
+			SchemaVersion: c.schemaVersion,
+			SnapToken:     snapToken,
+			Depth:         c.checkDepth,
+		},
+		EntityType: entityType,
+		Permission: permission,
+		Subject: &base.Subject{
+			Type: "user",
+			Id:   userID,
+		},
+		PageSize: 100,
+	}
+
+	var entityIDs []string
+	for {
+		pageCtx, cancel := c.withTimeout(ctx)
+		res, err := c.grpc.Permission.LookupEntity(pageCtx, request)
+		cancel()
+
+		if err != nil {
+			return nil, fmt.Errorf("lookup %s entities: %w", entityType, err)
+		}
+
+		entityIDs = append(entityIDs, res.EntityIds...)
+
+		if res.ContinuousToken == "" {
+			return entityIDs, nil
+		}
+
+		request.ContinuousToken = res.ContinuousToken
+	}
+}
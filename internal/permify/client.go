@@ -1,26 +1,117 @@
 package permify
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"os"
+	"time"
+
+	"echo-app/internal/config"
 
 	permify_grpc "github.com/Permify/permify-go/grpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-var Client *permify_grpc.Client
+var ErrInvalidCAFile = errors.New("no certificates in permify CA file")
+
+// Client talks to a single Permify tenant, every request is limited by the configured timeout.
+// Checks and relationship writes use the pinned schema version unless the request names one.
+type Client struct {
+	grpc          *permify_grpc.Client
+	tenantID      string
+	timeout       time.Duration
+	checkDepth    int32
+	schemaVersion string
+}
+
+func NewClient(cfg config.Permify) (*Client, error) {
+	transportCredentials := insecure.NewCredentials()
+	if cfg.TLSEnabled {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+		if cfg.CAFile != "" {
+			pool, err := loadCAFile(cfg.CAFile)
+			if err != nil {
+				return nil, err
+			}
 
-func InitClient() error {
-	var err error
-	Client, err = permify_grpc.NewClient(
-		permify_grpc.Config{
-			Endpoint: "permify:3478",
-		},
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+			tlsConfig.RootCAs = pool
+		}
+
+		transportCredentials = credentials.NewTLS(tlsConfig)
+	}
+
+	options := []grpc.DialOption{grpc.WithTransportCredentials(transportCredentials)}
+	if cfg.Token != "" {
+		options = append(options, grpc.WithPerRPCCredentials(bearerToken{token: cfg.Token, secure: cfg.TLSEnabled}))
+	}
+
+	client, err := permify_grpc.NewClient(permify_grpc.Config{Endpoint: cfg.Endpoint}, options...)
+	if err != nil {
+		return nil, fmt.Errorf("new permify client: %w", err)
+	}
+
+	return &Client{
+		grpc:       client,
+		tenantID:   cfg.TenantID,
+		timeout:    cfg.Timeout,
+		checkDepth: cfg.CheckDepth,
+	}, nil
+}
+
+// loadCAFile returns the pool of the PEM encoded certificates of the file.
+func loadCAFile(path string) (*x509.CertPool, error) {
+	pemCerts, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("permify client init error: %v", err)
+		return nil, fmt.Errorf("read permify CA file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemCerts) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCAFile, path)
 	}
 
-	return nil
+	return pool, nil
+}
+
+// PinSchemaVersion makes requests use the schema version, it has to be called before the client is shared.
+func (c *Client) PinSchemaVersion(version string) {
+	c.schemaVersion = version
+}
+
+// schemaVersionOr returns the version, or the pinned schema version when it's empty.
+func (c *Client) schemaVersionOr(version string) string {
+	if version == "" {
+		return c.schemaVersion
+	}
+
+	return version
+}
+
+// withTimeout limits a single request to Permify.
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// bearerToken authenticates requests with the preshared key of Permify's authn.
+type bearerToken struct {
+	token  string
+	secure bool
+}
+
+func (b bearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + b.token}, nil
+}
+
+func (b bearerToken) RequireTransportSecurity() bool {
+	return b.secure
 }
@@ -0,0 +1,123 @@
+package permify_test
+
+import (
+	"context"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"echo-app/internal/config"
+	"echo-app/internal/permify"
+
+	"buf.build/gen/go/permifyco/permify/grpc/go/base/v1/basev1grpc"
+	base "buf.build/gen/go/permifyco/permify/protocolbuffers/go/base/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type permissionServer struct {
+	basev1grpc.UnimplementedPermissionServer
+
+	request       *base.PermissionCheckRequest
+	authorization []string
+}
+
+func (s *permissionServer) Check(ctx context.Context, request *base.PermissionCheckRequest) (*base.PermissionCheckResponse, error) {
+	s.request = request
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.authorization = md.Get("authorization")
+
+	return &base.PermissionCheckResponse{Can: base.CheckResult_CHECK_RESULT_ALLOWED}, nil
+}
+
+func startPermify(t *testing.T) (string, *permissionServer) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	permissions := &permissionServer{}
+	basev1grpc.RegisterPermissionServer(server, permissions)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String(), permissions
+}
+
+func TestClient_Check(t *testing.T) {
+	endpoint, permissions := startPermify(t)
+
+	client, err := permify.NewClient(config.Permify{
+		Endpoint:   endpoint,
+		Token:      "secret",
+		TenantID:   "staging",
+		Timeout:    time.Second,
+		CheckDepth: 20,
+	})
+	require.NoError(t, err)
+
+	allowed, err := client.Check(t.Context(), permify.CheckRequest{
+		EntityType: "post",
+		EntityID:   "3",
+		Permission: "edit",
+		UserID:     "7",
+	})
+	require.NoError(t, err)
+
+	assert.True(t, allowed)
+	assert.Equal(t, "staging", permissions.request.GetTenantId())
+	assert.Equal(t, int32(20), permissions.request.GetMetadata().GetDepth())
+	assert.Equal(t, []string{"Bearer secret"}, permissions.authorization)
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("It should fail on a missing CA file", func(t *testing.T) {
+		_, err := permify.NewClient(config.Permify{
+			Endpoint:   "permify:3478",
+			TLSEnabled: true,
+			CAFile:     "/nonexistent/ca.pem",
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("It should load the certificates of the CA file", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.NotFoundHandler())
+		t.Cleanup(server.Close)
+
+		caFile := filepath.Join(t.TempDir(), "ca.pem")
+		pemCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		require.NoError(t, os.WriteFile(caFile, pemCert, 0o600))
+
+		_, err := permify.NewClient(config.Permify{
+			Endpoint:   "permify:3478",
+			TLSEnabled: true,
+			CAFile:     caFile,
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("It should fail on a CA file without certificates", func(t *testing.T) {
+		caFile := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(caFile, []byte("not a certificate"), 0o600))
+
+		_, err := permify.NewClient(config.Permify{
+			Endpoint:   "permify:3478",
+			TLSEnabled: true,
+			CAFile:     caFile,
+		})
+		assert.ErrorIs(t, err, permify.ErrInvalidCAFile)
+	})
+}
@@ -0,0 +1,238 @@
+package permify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// memoryCheckDepth limits the nesting of a check like the Permify depth does.
+const memoryCheckDepth = 50
+
+var ErrDepthExceeded = errors.New("permission check depth exceeded")
+
+// MemoryAuthorizer is an Authorizer keeping the relationships in memory and evaluating checks against the schema
+// itself, see memorySchema for the supported language. Writes are visible right away, so snap tokens
+// are only counters. It isn't persistent and is meant for tests and local development.
+type MemoryAuthorizer struct {
+	mu            sync.RWMutex
+	schema        memorySchema
+	schemaVersion int
+	tuples        []Tuple
+	revision      int
+}
+
+func NewMemoryAuthorizer(schema string) (*MemoryAuthorizer, error) {
+	authorizer := &MemoryAuthorizer{}
+	if _, err := authorizer.WriteSchema(context.Background(), schema); err != nil {
+		return nil, err
+	}
+
+	return authorizer, nil
+}
+
+func (a *MemoryAuthorizer) WriteSchema(_ context.Context, schema string) (string, error) {
+	parsed, err := parseMemorySchema(schema)
+	if err != nil {
+		return "", fmt.Errorf("parse schema: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.schema = parsed
+	a.schemaVersion++
+
+	return strconv.Itoa(a.schemaVersion), nil
+}
+
+func (a *MemoryAuthorizer) Check(_ context.Context, request CheckRequest) (bool, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.check(request.EntityType, request.EntityID, request.Permission, request.UserID, memoryCheckDepth)
+}
+
+func (a *MemoryAuthorizer) SubjectPermission(_ context.Context, entityType, entityID, userID, _ string) (map[string]bool, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	entity, ok := a.schema[entityType]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown entity type %s", ErrInvalidSchema, entityType)
+	}
+
+	permissions := make(map[string]bool, len(entity.actions))
+	for action := range entity.actions {
+		allowed, err := a.check(entityType, entityID, action, userID, memoryCheckDepth)
+		if err != nil {
+			return nil, fmt.Errorf("get permissions on %s:%s: %w", entityType, entityID, err)
+		}
+
+		permissions[action] = allowed
+	}
+
+	return permissions, nil
+}
+
+func (a *MemoryAuthorizer) LookupEntity(_ context.Context, entityType, permission, userID, _ string) ([]string, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var candidates []string
+	for _, tuple := range a.tuples {
+		if tuple.EntityType == entityType {
+			candidates = append(candidates, tuple.EntityID)
+		}
+
+		if tuple.SubjectType == entityType {
+			candidates = append(candidates, tuple.SubjectID)
+		}
+	}
+
+	slices.Sort(candidates)
+
+	entityIDs := make([]string, 0)
+	for _, entityID := range slices.Compact(candidates) {
+		allowed, err := a.check(entityType, entityID, permission, userID, memoryCheckDepth)
+		if err != nil {
+			return nil, fmt.Errorf("lookup %s entities: %w", entityType, err)
+		}
+
+		if allowed {
+			entityIDs = append(entityIDs, entityID)
+		}
+	}
+
+	return entityIDs, nil
+}
+
+// Write writes the tuples, they have to match a relation of the schema like in Permify.
+func (a *MemoryAuthorizer) Write(_ context.Context, tuples ...Tuple) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, tuple := range tuples {
+		subjectTypes, ok := a.schema[tuple.EntityType].relations[tuple.Relation]
+		if !ok || !slices.Contains(subjectTypes, tuple.SubjectType) {
+			return "", fmt.Errorf("write relationships: %w: %s:%s#%s@%s:%s is not allowed by the schema",
+				ErrInvalidSchema, tuple.EntityType, tuple.EntityID, tuple.Relation, tuple.SubjectType, tuple.SubjectID)
+		}
+	}
+
+	for _, tuple := range tuples {
+		if !slices.Contains(a.tuples, tuple) {
+			a.tuples = append(a.tuples, tuple)
+		}
+	}
+
+	return a.snapToken(), nil
+}
+
+// Delete deletes the relationships matching the filter, empty fields of the filter other than the entity
+// match any value.
+func (a *MemoryAuthorizer) Delete(_ context.Context, filter Tuple) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.tuples = slices.DeleteFunc(a.tuples, func(tuple Tuple) bool {
+		return matchesFilter(filter, tuple)
+	})
+
+	return a.snapToken(), nil
+}
+
+// Read returns the relationships matching the filter, empty fields of the filter other than the entity type
+// match any value. Writes are visible right away, so the snap token is ignored.
+func (a *MemoryAuthorizer) Read(_ context.Context, filter Tuple, _ string) ([]Tuple, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var tuples []Tuple
+	for _, tuple := range a.tuples {
+		if matchesFilter(filter, tuple) {
+			tuples = append(tuples, tuple)
+		}
+	}
+
+	return tuples, nil
+}
+
+// snapToken returns the token of a new revision, it has to be called with the write lock held.
+func (a *MemoryAuthorizer) snapToken() string {
+	a.revision++
+	return strconv.Itoa(a.revision)
+}
+
+func (a *MemoryAuthorizer) check(entityType, entityID, name, userID string, depth int) (bool, error) {
+	if depth <= 0 {
+		return false, ErrDepthExceeded
+	}
+
+	entity, ok := a.schema[entityType]
+	if !ok {
+		return false, fmt.Errorf("%w: unknown entity type %s", ErrInvalidSchema, entityType)
+	}
+
+	if _, ok := entity.relations[name]; ok {
+		for _, tuple := range a.tuples {
+			if tuple.EntityType == entityType && tuple.EntityID == entityID && tuple.Relation == name &&
+				tuple.SubjectType == "user" && tuple.SubjectID == userID {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	expr, ok := entity.actions[name]
+	if !ok {
+		return false, fmt.Errorf("%w: entity %s has no relation or action %s", ErrInvalidSchema, entityType, name)
+	}
+
+	return a.eval(entityType, entityID, expr, userID, depth-1)
+}
+
+func (a *MemoryAuthorizer) eval(entityType, entityID string, expr *memoryExpr, userID string, depth int) (bool, error) {
+	switch expr.op {
+	case "or", "and":
+		left, err := a.eval(entityType, entityID, expr.left, userID, depth)
+		if err != nil {
+			return false, err
+		}
+
+		if left == (expr.op == "or") {
+			return left, nil
+		}
+
+		return a.eval(entityType, entityID, expr.right, userID, depth)
+	}
+
+	if expr.walk == "" {
+		return a.check(entityType, entityID, expr.name, userID, depth)
+	}
+
+	for _, tuple := range a.tuples {
+		if tuple.EntityType != entityType || tuple.EntityID != entityID || tuple.Relation != expr.name {
+			continue
+		}
+
+		allowed, err := a.check(tuple.SubjectType, tuple.SubjectID, expr.walk, userID, depth)
+		if err != nil || allowed {
+			return allowed, err
+		}
+	}
+
+	return false, nil
+}
+
+func matchesFilter(filter, tuple Tuple) bool {
+	return tuple.EntityType == filter.EntityType &&
+		(filter.EntityID == "" || tuple.EntityID == filter.EntityID) &&
+		(filter.Relation == "" || tuple.Relation == filter.Relation) &&
+		(filter.SubjectType == "" || tuple.SubjectType == filter.SubjectType) &&
+		(filter.SubjectID == "" || tuple.SubjectID == filter.SubjectID)
+}
@@ -0,0 +1,282 @@
+package permify
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidSchema = errors.New("invalid permify schema")
+
+// memorySchema is the subset of the Permify schema language MemoryAuthorizer understands: entities with
+// relations to subject types and actions combining relations, other actions and relation walks
+// like parent.view with or, and and parentheses.
+type memorySchema map[string]memoryEntity
+
+type memoryEntity struct {
+	// relations maps the relation names to the allowed subject types.
+	relations map[string][]string
+	actions   map[string]*memoryExpr
+}
+
+// memoryExpr is either an or/and of two expressions or a reference to a relation or permission of the entity.
+// A reference with walk set evaluates the walk permission on the subjects of the relation.
+type memoryExpr struct {
+	op          string
+	left, right *memoryExpr
+	name        string
+	walk        string
+}
+
+func parseMemorySchema(schema string) (memorySchema, error) {
+	parser := schemaParser{tokens: tokenizeSchema(schema)}
+
+	parsed := make(memorySchema)
+	for !parser.done() {
+		if err := parser.expect("entity"); err != nil {
+			return nil, err
+		}
+
+		name := parser.next()
+		if err := parser.expect("{"); err != nil {
+			return nil, err
+		}
+
+		entity, err := parser.entityBody()
+		if err != nil {
+			return nil, fmt.Errorf("entity %s: %w", name, err)
+		}
+
+		parsed[name] = entity
+	}
+
+	if err := parsed.validate(); err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+// validate makes sure every reference resolves, so evaluation doesn't have to deal with unknown names.
+func (s memorySchema) validate() error {
+	for entityName, entity := range s {
+		for relation, subjectTypes := range entity.relations {
+			for _, subjectType := range subjectTypes {
+				if _, ok := s[subjectType]; !ok {
+					return fmt.Errorf("%w: %s#%s references unknown entity %s", ErrInvalidSchema, entityName, relation, subjectType)
+				}
+			}
+		}
+
+		for action, expr := range entity.actions {
+			if err := s.validateExpr(entityName, expr); err != nil {
+				return fmt.Errorf("%w: %s#%s: %w", ErrInvalidSchema, entityName, action, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s memorySchema) validateExpr(entityName string, expr *memoryExpr) error {
+	if expr.op != "" {
+		return errors.Join(s.validateExpr(entityName, expr.left), s.validateExpr(entityName, expr.right))
+	}
+
+	entity := s[entityName]
+	if expr.walk == "" {
+		if _, ok := entity.relations[expr.name]; ok {
+			return nil
+		}
+
+		if _, ok := entity.actions[expr.name]; ok {
+			return nil
+		}
+
+		return fmt.Errorf("unknown relation or action %s", expr.name)
+	}
+
+	subjectTypes, ok := entity.relations[expr.name]
+	if !ok {
+		return fmt.Errorf("unknown relation %s", expr.name)
+	}
+
+	for _, subjectType := range subjectTypes {
+		subject := s[subjectType]
+		_, isRelation := subject.relations[expr.walk]
+		_, isAction := subject.actions[expr.walk]
+
+		if !isRelation && !isAction {
+			return fmt.Errorf("entity %s has no relation or action %s", subjectType, expr.walk)
+		}
+	}
+
+	return nil
+}
+
+type schemaParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *schemaParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *schemaParser) peek() string {
+	if p.done() {
+		return ""
+	}
+
+	return p.tokens[p.pos]
+}
+
+func (p *schemaParser) next() string {
+	token := p.peek()
+	p.pos++
+
+	return token
+}
+
+func (p *schemaParser) expect(token string) error {
+	if got := p.next(); got != token {
+		return fmt.Errorf("%w: expected %q, got %q", ErrInvalidSchema, token, got)
+	}
+
+	return nil
+}
+
+func (p *schemaParser) entityBody() (memoryEntity, error) {
+	entity := memoryEntity{
+		relations: make(map[string][]string),
+		actions:   make(map[string]*memoryExpr),
+	}
+
+	for {
+		switch token := p.next(); token {
+		case "}":
+			return entity, nil
+		case "relation":
+			name := p.next()
+			for strings.HasPrefix(p.peek(), "@") {
+				entity.relations[name] = append(entity.relations[name], strings.TrimPrefix(p.next(), "@"))
+			}
+
+			if len(entity.relations[name]) == 0 {
+				return memoryEntity{}, fmt.Errorf("%w: relation %s has no subject types", ErrInvalidSchema, name)
+			}
+		case "action", "permission":
+			name := p.next()
+			if err := p.expect("="); err != nil {
+				return memoryEntity{}, err
+			}
+
+			expr, err := p.or()
+			if err != nil {
+				return memoryEntity{}, fmt.Errorf("action %s: %w", name, err)
+			}
+
+			entity.actions[name] = expr
+		default:
+			return memoryEntity{}, fmt.Errorf("%w: unexpected %q", ErrInvalidSchema, token)
+		}
+	}
+}
+
+func (p *schemaParser) or() (*memoryExpr, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == "or" {
+		p.next()
+
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &memoryExpr{op: "or", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *schemaParser) and() (*memoryExpr, error) {
+	left, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == "and" {
+		p.next()
+
+		right, err := p.primary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &memoryExpr{op: "and", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *schemaParser) primary() (*memoryExpr, error) {
+	token := p.next()
+
+	switch token {
+	case "(":
+		expr, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+
+		return expr, nil
+	case "", ")", "{", "}", "=", "or", "and", "not", "relation", "action", "permission", "entity":
+		return nil, fmt.Errorf("%w: unexpected %q in expression", ErrInvalidSchema, token)
+	}
+
+	name, walk, _ := strings.Cut(token, ".")
+
+	return &memoryExpr{name: name, walk: walk}, nil
+}
+
+// tokenizeSchema splits the schema into words and the punctuation the parser cares about, comments are dropped.
+func tokenizeSchema(schema string) []string {
+	var tokens []string
+
+	for _, line := range strings.Split(schema, "\n") {
+		line, _, _ = strings.Cut(line, "//")
+
+		var word strings.Builder
+		flush := func() {
+			if word.Len() > 0 {
+				tokens = append(tokens, word.String())
+				word.Reset()
+			}
+		}
+
+		for _, r := range line {
+			switch {
+			case unicode.IsSpace(r):
+				flush()
+			case strings.ContainsRune("{}()=", r):
+				flush()
+				tokens = append(tokens, string(r))
+			default:
+				word.WriteRune(r)
+			}
+		}
+
+		flush()
+	}
+
+	return tokens
+}
@@ -0,0 +1,185 @@
+package permify_test
+
+import (
+	"testing"
+
+	"echo-app/internal/permify"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMemoryAuthorizer(t *testing.T) *permify.MemoryAuthorizer {
+	t.Helper()
+
+	schema, err := permify.Schema()
+	require.NoError(t, err)
+
+	authorizer, err := permify.NewMemoryAuthorizer(schema)
+	require.NoError(t, err)
+
+	return authorizer
+}
+
+func TestMemoryAuthorizer_Check(t *testing.T) {
+	authorizer := newMemoryAuthorizer(t)
+
+	_, err := authorizer.Write(t.Context(),
+		permify.Tuple{EntityType: "domain", EntityID: "d1", Relation: "member", SubjectType: "user", SubjectID: "1"},
+		permify.Tuple{EntityType: "post", EntityID: "p1", Relation: "owner", SubjectType: "user", SubjectID: "2"},
+		permify.Tuple{EntityType: "post", EntityID: "p1", Relation: "parent", SubjectType: "domain", SubjectID: "d1"},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		request permify.CheckRequest
+		want    bool
+	}{
+		{
+			name:    "It should allow a relation of the action",
+			request: permify.CheckRequest{EntityType: "post", EntityID: "p1", Permission: "edit", UserID: "2"},
+			want:    true,
+		},
+		{
+			name:    "It should allow through the parent relation",
+			request: permify.CheckRequest{EntityType: "post", EntityID: "p1", Permission: "view", UserID: "1"},
+			want:    true,
+		},
+		{
+			name:    "It should deny a missing relation",
+			request: permify.CheckRequest{EntityType: "post", EntityID: "p1", Permission: "edit", UserID: "1"},
+			want:    false,
+		},
+		{
+			name:    "It should deny another entity",
+			request: permify.CheckRequest{EntityType: "post", EntityID: "p2", Permission: "view", UserID: "1"},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := authorizer.Check(t.Context(), tt.request)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, allowed)
+		})
+	}
+
+	t.Run("It should return every permission of the entity", func(t *testing.T) {
+		permissions, err := authorizer.SubjectPermission(t.Context(), "post", "p1", "1", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]bool{"view": true, "edit": false, "delete": false}, permissions)
+	})
+
+	t.Run("It should fail on an unknown permission", func(t *testing.T) {
+		_, err := authorizer.Check(t.Context(), permify.CheckRequest{EntityType: "post", EntityID: "p1", Permission: "share", UserID: "1"})
+		assert.ErrorIs(t, err, permify.ErrInvalidSchema)
+	})
+}
+
+func TestMemoryAuthorizer_Operators(t *testing.T) {
+	authorizer, err := permify.NewMemoryAuthorizer(`
+entity user {}
+
+// and binds tighter than or, so owners publish alone and everybody else needs both roles
+entity document {
+    relation owner @user
+    relation editor @user
+    relation reviewer @user
+
+    action publish = owner or editor and reviewer
+    action comment = (owner or editor) and reviewer
+}
+`)
+	require.NoError(t, err)
+
+	_, err = authorizer.Write(t.Context(),
+		permify.Tuple{EntityType: "document", EntityID: "1", Relation: "editor", SubjectType: "user", SubjectID: "1"},
+		permify.Tuple{EntityType: "document", EntityID: "1", Relation: "reviewer", SubjectType: "user", SubjectID: "1"},
+		permify.Tuple{EntityType: "document", EntityID: "1", Relation: "reviewer", SubjectType: "user", SubjectID: "2"},
+		permify.Tuple{EntityType: "document", EntityID: "1", Relation: "owner", SubjectType: "user", SubjectID: "3"},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		permission string
+		userID     string
+		want       bool
+	}{
+		{permission: "publish", userID: "1", want: true},
+		{permission: "publish", userID: "2", want: false},
+		{permission: "publish", userID: "3", want: true},
+		{permission: "comment", userID: "1", want: true},
+		{permission: "comment", userID: "3", want: false},
+	}
+
+	for _, tt := range tests {
+		allowed, err := authorizer.Check(t.Context(), permify.CheckRequest{
+			EntityType: "document",
+			EntityID:   "1",
+			Permission: tt.permission,
+			UserID:     tt.userID,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, tt.want, allowed, "%s by user %s", tt.permission, tt.userID)
+	}
+}
+
+func TestMemoryAuthorizer_Relationships(t *testing.T) {
+	authorizer := newMemoryAuthorizer(t)
+
+	admin := permify.Tuple{EntityType: "domain", EntityID: "d1", Relation: "admin", SubjectType: "user", SubjectID: "1"}
+	member := permify.Tuple{EntityType: "domain", EntityID: "d1", Relation: "member", SubjectType: "user", SubjectID: "2"}
+	other := permify.Tuple{EntityType: "domain", EntityID: "d2", Relation: "member", SubjectType: "user", SubjectID: "2"}
+
+	_, err := authorizer.Write(t.Context(), admin, member, other)
+	require.NoError(t, err)
+
+	t.Run("It should reject tuples the schema doesn't allow", func(t *testing.T) {
+		_, err := authorizer.Write(t.Context(), permify.Tuple{EntityType: "domain", EntityID: "d1", Relation: "owner", SubjectType: "user", SubjectID: "1"})
+		assert.ErrorIs(t, err, permify.ErrInvalidSchema)
+	})
+
+	t.Run("It should read the tuples matching the filter", func(t *testing.T) {
+		tuples, err := authorizer.Read(t.Context(), permify.Tuple{EntityType: "domain", EntityID: "d1", SubjectType: "user"}, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, []permify.Tuple{admin, member}, tuples)
+	})
+
+	t.Run("It should look up the entities the user has the permission on", func(t *testing.T) {
+		entityIDs, err := authorizer.LookupEntity(t.Context(), "domain", "view", "2", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"d1", "d2"}, entityIDs)
+	})
+
+	t.Run("It should delete the tuples matching the filter", func(t *testing.T) {
+		_, err := authorizer.Delete(t.Context(), permify.Tuple{EntityType: "domain", EntityID: "d1", SubjectType: "user", SubjectID: "2"})
+		require.NoError(t, err)
+
+		entityIDs, err := authorizer.LookupEntity(t.Context(), "domain", "view", "2", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"d2"}, entityIDs)
+	})
+}
+
+func TestNewMemoryAuthorizer(t *testing.T) {
+	t.Run("It should reject references to unknown relations", func(t *testing.T) {
+		_, err := permify.NewMemoryAuthorizer(`
+entity user {}
+
+entity post {
+    relation admin @user
+
+    action edit = admin or owner
+}
+`)
+		assert.ErrorIs(t, err, permify.ErrInvalidSchema)
+	})
+}
@@ -2,29 +2,119 @@ package permify
 
 import (
 	"context"
+	"fmt"
 
 	base "buf.build/gen/go/permifyco/permify/protocolbuffers/go/base/v1"
 )
 
-func AssignUserToDomain(userID, domainID, role, schemaVersion string) error {
-	_, err := Client.Data.WriteRelationships(context.Background(), &base.RelationshipWriteRequest{
-		TenantId: "t1",
-		Metadata: &base.RelationshipWriteRequestMetadata{
-			SchemaVersion: schemaVersion,
-		},
-		Tuples: []*base.Tuple{
-			{
-				Entity: &base.Entity{
-					Type: "domain",
-					Id:   domainID,
-				},
-				Relation: role, // "member" or "admin"
-				Subject: &base.Subject{
-					Type: "user",
-					Id:   userID,
-				},
-			},
-		},
+// Tuple is a relationship between an entity and a subject, e.g. post:1#admin@user:7.
+type Tuple struct {
+	EntityType  string
+	EntityID    string
+	Relation    string
+	SubjectType string
+	SubjectID   string
+}
+
+// Write writes the tuples and returns the snap token of the write.
+func (c *Client) Write(ctx context.Context, tuples ...Tuple) (string, error) {
+	request := &base.RelationshipWriteRequest{
+		TenantId: c.tenantID,
+		Metadata: &base.RelationshipWriteRequestMetadata{SchemaVersion: c.schemaVersion},
+		Tuples:   make([]*base.Tuple, 0, len(tuples)),
+	}
+
+	for _, tuple := range tuples {
+		request.Tuples = append(request.Tuples, &base.Tuple{
+			Entity:   &base.Entity{Type: tuple.EntityType, Id: tuple.EntityID},
+			Relation: tuple.Relation,
+			Subject:  &base.Subject{Type: tuple.SubjectType, Id: tuple.SubjectID},
+		})
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.grpc.Data.WriteRelationships(ctx, request)
+	if err != nil {
+		return "", fmt.Errorf("write relationships: %w", err)
+	}
+
+	return res.SnapToken, nil
+}
+
+// Delete deletes the relationships matching the filter and returns the snap token of the deletion.
+// Empty fields of the filter other than the entity match any value.
+func (c *Client) Delete(ctx context.Context, filter Tuple) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	res, err := c.grpc.Data.DeleteRelationships(ctx, &base.RelationshipDeleteRequest{
+		TenantId: c.tenantID,
+		Filter:   tupleFilter(filter),
 	})
-	return err
+	if err != nil {
+		return "", fmt.Errorf("delete relationships of %s:%s: %w", filter.EntityType, filter.EntityID, err)
+	}
+
+	return res.SnapToken, nil
+}
+
+// Read returns the relationships matching the filter, at least as fresh as the snap token.
+// Empty fields of the filter other than the entity type match any value.
+func (c *Client) Read(ctx context.Context, filter Tuple, snapToken string) ([]Tuple, error) {
+	request := &base.RelationshipReadRequest{
+		TenantId: c.tenantID,
+		Metadata: &base.RelationshipReadRequestMetadata{SnapToken: snapToken},
+		Filter:   tupleFilter(filter),
+		PageSize: 100,
+	}
+
+	var tuples []Tuple
+	for {
+		pageCtx, cancel := c.withTimeout(ctx)
+		res, err := c.grpc.Data.ReadRelationships(pageCtx, request)
+		cancel()
+
+		if err != nil {
+			return nil, fmt.Errorf("read relationships of %s:%s: %w", filter.EntityType, filter.EntityID, err)
+		}
+
+		for _, tuple := range res.Tuples {
+			tuples = append(tuples, Tuple{
+				EntityType:  tuple.GetEntity().GetType(),
+				EntityID:    tuple.GetEntity().GetId(),
+				Relation:    tuple.GetRelation(),
+				SubjectType: tuple.GetSubject().GetType(),
+				SubjectID:   tuple.GetSubject().GetId(),
+			})
+		}
+
+		if res.ContinuousToken == "" {
+			return tuples, nil
+		}
+
+		request.ContinuousToken = res.ContinuousToken
+	}
+}
+
+func tupleFilter(filter Tuple) *base.TupleFilter {
+	tupleFilter := &base.TupleFilter{
+		Entity:   &base.EntityFilter{Type: filter.EntityType},
+		Relation: filter.Relation,
+	}
+
+	if filter.EntityID != "" {
+		tupleFilter.Entity.Ids = []string{filter.EntityID}
+	}
+
+	if filter.SubjectType != "" {
+		tupleFilter.Subject = &base.SubjectFilter{Type: filter.SubjectType}
+
+		if filter.SubjectID != "" {
+			tupleFilter.Subject.Ids = []string{filter.SubjectID}
+		}
+	}
+
+	return tupleFilter
 }
@@ -2,51 +2,55 @@ package permify
 
 import (
 	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
 	"fmt"
-	"time"
+	"io/fs"
+	"strings"
 
 	base "buf.build/gen/go/permifyco/permify/protocolbuffers/go/base/v1"
 )
 
-const (
-	defaultTenantID = "t1"
-	defaultTimeout  = 5 * time.Second
-)
+// schemaFiles holds the authorization schema, one file per entity. Changes to them are rolled out
+// by the schema sync on startup, see the schema service.
+//
+//go:embed schema/*.perm
+var schemaFiles embed.FS
 
-func UploadSchema(ctx context.Context, tenantID string) (string, error) {
-	if tenantID == "" {
-		tenantID = defaultTenantID
+// Schema returns the embedded schema, the files are joined in lexical order so the result is stable.
+func Schema() (string, error) {
+	paths, err := fs.Glob(schemaFiles, "schema/*.perm")
+	if err != nil {
+		return "", fmt.Errorf("list schema files: %w", err)
 	}
 
-	schema := `
-entity user {}
+	parts := make([]string, 0, len(paths))
+	for _, path := range paths {
+		content, err := schemaFiles.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("read schema file %s: %w", path, err)
+		}
 
-entity domain {
-  relation member @user
-  relation admin @user
+		parts = append(parts, strings.TrimSpace(string(content)))
+	}
 
-  action view = member or admin
-  action edit = admin
+	return strings.Join(parts, "\n\n") + "\n", nil
 }
 
-entity post {
-	relation member @user 
-	relation admin @user 
-	
-	action view = member or admin 
-	action edit = admin
+// SchemaHash returns the hex encoded SHA-256 of the schema.
+func SchemaHash(schema string) string {
+	sum := sha256.Sum256([]byte(schema))
+	return hex.EncodeToString(sum[:])
 }
-`
 
-	// Create a context with timeout if none provided
-	if ctx == nil {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(context.Background(), defaultTimeout)
-		defer cancel()
-	}
+// WriteSchema writes the schema to the tenant of the client and returns the new schema version.
+func (c *Client) WriteSchema(ctx context.Context, schema string) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 
-	res, err := Client.Schema.Write(ctx, &base.SchemaWriteRequest{
-		TenantId: tenantID,
+	res, err := c.grpc.Schema.Write(ctx, &base.SchemaWriteRequest{
+		TenantId: c.tenantID,
 		Schema:   schema,
 	})
 	if err != nil {
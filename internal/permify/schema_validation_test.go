@@ -0,0 +1,118 @@
+package permify_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"echo-app/internal/permify"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// schemaValidation is the part of the Permify schema validation format the in-memory authorizer can evaluate.
+type schemaValidation struct {
+	Schema        string   `yaml:"schema"`
+	Relationships []string `yaml:"relationships"`
+	Scenarios     []struct {
+		Name   string `yaml:"name"`
+		Checks []struct {
+			Entity     string          `yaml:"entity"`
+			Subject    string          `yaml:"subject"`
+			Assertions map[string]bool `yaml:"assertions"`
+		} `yaml:"checks"`
+		EntityFilters []struct {
+			EntityType string              `yaml:"entity_type"`
+			Subject    string              `yaml:"subject"`
+			Assertions map[string][]string `yaml:"assertions"`
+		} `yaml:"entity_filters"`
+	} `yaml:"scenarios"`
+}
+
+// parseReference parses an entity or a subject reference, e.g. post:1 or user:1.
+func parseReference(t *testing.T, reference string) (string, string) {
+	t.Helper()
+
+	referenceType, id, ok := strings.Cut(reference, ":")
+	require.True(t, ok, "invalid reference %q", reference)
+
+	return referenceType, id
+}
+
+// parseRelationship parses a relationship tuple, e.g. post:1#owner@user:1.
+func parseRelationship(t *testing.T, relationship string) permify.Tuple {
+	t.Helper()
+
+	entity, subject, ok := strings.Cut(relationship, "@")
+	require.True(t, ok, "invalid relationship %q", relationship)
+
+	entity, relation, ok := strings.Cut(entity, "#")
+	require.True(t, ok, "invalid relationship %q", relationship)
+
+	entityType, entityID := parseReference(t, entity)
+	subjectType, subjectID := parseReference(t, subject)
+
+	return permify.Tuple{
+		EntityType:  entityType,
+		EntityID:    entityID,
+		Relation:    relation,
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+	}
+}
+
+func TestSchemaValidation(t *testing.T) {
+	content, err := os.ReadFile("schema/validation.yaml")
+	require.NoError(t, err)
+
+	var validation schemaValidation
+	require.NoError(t, yaml.Unmarshal(content, &validation))
+
+	schema, err := permify.Schema()
+	require.NoError(t, err)
+
+	require.Equal(t, strings.TrimSpace(schema), strings.TrimSpace(validation.Schema),
+		"the schema of schema/validation.yaml is out of date with the .perm files")
+
+	authorizer, err := permify.NewMemoryAuthorizer(schema)
+	require.NoError(t, err)
+
+	for _, relationship := range validation.Relationships {
+		_, err := authorizer.Write(t.Context(), parseRelationship(t, relationship))
+		require.NoError(t, err)
+	}
+
+	for _, scenario := range validation.Scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			for _, check := range scenario.Checks {
+				entityType, entityID := parseReference(t, check.Entity)
+				_, userID := parseReference(t, check.Subject)
+
+				for permission, want := range check.Assertions {
+					allowed, err := authorizer.Check(t.Context(), permify.CheckRequest{
+						EntityType: entityType,
+						EntityID:   entityID,
+						Permission: permission,
+						UserID:     userID,
+					})
+					require.NoError(t, err)
+
+					assert.Equal(t, want, allowed, "%s on %s by %s", permission, check.Entity, check.Subject)
+				}
+			}
+
+			for _, filter := range scenario.EntityFilters {
+				_, userID := parseReference(t, filter.Subject)
+
+				for permission, want := range filter.Assertions {
+					entityIDs, err := authorizer.LookupEntity(t.Context(), filter.EntityType, permission, userID, "")
+					require.NoError(t, err)
+
+					assert.ElementsMatch(t, want, entityIDs, "%s on %s by %s", permission, filter.EntityType, filter.Subject)
+				}
+			}
+		})
+	}
+}
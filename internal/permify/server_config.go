@@ -0,0 +1,50 @@
+package permify
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"echo-app/internal/config"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultBulkLimit is the bulk_limit the Permify server uses when its configuration doesn't set one.
+const defaultBulkLimit = 100
+
+// serverConfig is the part of the Permify server configuration the service follows.
+type serverConfig struct {
+	Service struct {
+		Permission struct {
+			BulkLimit int `yaml:"bulk_limit"`
+		} `yaml:"permission"`
+	} `yaml:"service"`
+}
+
+// BulkLimit returns the number of permission checks the service may run against Permify at the same time.
+// It is the bulk_limit of the Permify server configuration unless configured explicitly, or the default of
+// the Permify server when the configuration isn't deployed with the service.
+func BulkLimit(cfg config.Permify) (int, error) {
+	if cfg.BulkLimit > 0 {
+		return cfg.BulkLimit, nil
+	}
+
+	content, err := os.ReadFile(cfg.ServerConfigFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return defaultBulkLimit, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("read permify server config: %w", err)
+	}
+
+	var server serverConfig
+	if err := yaml.Unmarshal(content, &server); err != nil {
+		return 0, fmt.Errorf("parse permify server config: %w", err)
+	}
+
+	if server.Service.Permission.BulkLimit <= 0 {
+		return 0, fmt.Errorf("permify server config %s has no service.permission.bulk_limit", cfg.ServerConfigFile)
+	}
+
+	return server.Service.Permission.BulkLimit, nil
+}
@@ -0,0 +1,51 @@
+package permify_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"echo-app/internal/config"
+	"echo-app/internal/permify"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkLimit(t *testing.T) {
+	t.Run("It should follow the Permify server config", func(t *testing.T) {
+		bulkLimit, err := permify.BulkLimit(config.Permify{ServerConfigFile: "../../config/permify/config.yaml"})
+		require.NoError(t, err)
+
+		assert.Equal(t, 100, bulkLimit)
+	})
+
+	t.Run("It should prefer the configured limit", func(t *testing.T) {
+		bulkLimit, err := permify.BulkLimit(config.Permify{BulkLimit: 10, ServerConfigFile: "/nonexistent.yaml"})
+		require.NoError(t, err)
+
+		assert.Equal(t, 10, bulkLimit)
+	})
+
+	t.Run("It should fall back to the Permify default without the server config", func(t *testing.T) {
+		bulkLimit, err := permify.BulkLimit(config.Permify{ServerConfigFile: filepath.Join(t.TempDir(), "config.yaml")})
+		require.NoError(t, err)
+
+		assert.Equal(t, 100, bulkLimit)
+	})
+
+	t.Run("It should fail without a bulk limit", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("service:\n  permission: {}\n"), 0o600))
+
+		_, err := permify.BulkLimit(config.Permify{ServerConfigFile: path})
+		assert.Error(t, err)
+	})
+	t.Run("It should fail on an invalid server config", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("service: ["), 0o600))
+
+		_, err := permify.BulkLimit(config.Permify{ServerConfigFile: path})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"echo-app/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DomainRepository struct {
+	db *gorm.DB
+}
+
+func NewDomainRepository(db *gorm.DB) DomainRepository {
+	return DomainRepository{db: db}
+}
+
+func (r DomainRepository) Create(ctx context.Context, domain *models.Domain) error {
+	err := connection(ctx, r.db).Create(domain).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return errors.Join(models.ErrDomainNameTaken, err)
+	} else if err != nil {
+		return fmt.Errorf("execute insert domain query: %w", err)
+	}
+
+	return nil
+}
+
+func (r DomainRepository) GetByID(ctx context.Context, id uuid.UUID) (models.Domain, error) {
+	var domain models.Domain
+	err := connection(ctx, r.db).Where("id = ?", id).Take(&domain).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Domain{}, errors.Join(models.ErrDomainNotFound, err)
+	} else if err != nil {
+		return models.Domain{}, fmt.Errorf("execute select domain by id query: %w", err)
+	}
+
+	return domain, nil
+}
+
+// GetForUpdate returns the domain and locks it until the end of the transaction carried by ctx.
+func (r DomainRepository) GetForUpdate(ctx context.Context, id uuid.UUID) (models.Domain, error) {
+	var domain models.Domain
+	err := connection(ctx, r.db).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("id = ?", id).
+		Take(&domain).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Domain{}, errors.Join(models.ErrDomainNotFound, err)
+	} else if err != nil {
+		return models.Domain{}, fmt.Errorf("execute select domain for update query: %w", err)
+	}
+
+	return domain, nil
+}
+
+func (r DomainRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Domain, error) {
+	domains := make([]models.Domain, 0, len(ids))
+	if len(ids) == 0 {
+		return domains, nil
+	}
+
+	if err := connection(ctx, r.db).Where("id IN ?", ids).Order("name").Find(&domains).Error; err != nil {
+		return nil, fmt.Errorf("execute select domains by ids query: %w", err)
+	}
+
+	return domains, nil
+}
+
+func (r DomainRepository) Update(ctx context.Context, domain *models.Domain) error {
+	err := connection(ctx, r.db).Model(domain).Update("name", domain.Name).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return errors.Join(models.ErrDomainNameTaken, err)
+	} else if err != nil {
+		return fmt.Errorf("execute update domain query: %w", err)
+	}
+
+	return nil
+}
+
+// GetPostIDs returns the IDs of the posts of the domain, deleting the domain deletes them too.
+func (r DomainRepository) GetPostIDs(ctx context.Context, id uuid.UUID) ([]uint, error) {
+	var ids []uint
+	if err := connection(ctx, r.db).Model(&models.Post{}).Where("domain_id = ?", id).Order("id").Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("execute select domain post ids query: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Delete deletes the domain and detaches its users from it.
+func (r DomainRepository) Delete(ctx context.Context, domain *models.Domain) error {
+	err := connection(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.User{}).Where("domain_id = ?", domain.ID).Update("domain_id", nil).Error; err != nil {
+			return fmt.Errorf("execute detach domain users query: %w", err)
+		}
+
+		if err := tx.Delete(domain).Error; err != nil {
+			return fmt.Errorf("execute delete domain query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("delete domain: %w", err)
+	}
+
+	return nil
+}
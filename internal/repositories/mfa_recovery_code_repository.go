@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"echo-app/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type MFARecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewMFARecoveryCodeRepository(db *gorm.DB) MFARecoveryCodeRepository {
+	return MFARecoveryCodeRepository{db: db}
+}
+
+// Replace replaces the recovery codes of the user, the previous codes stop working.
+func (r MFARecoveryCodeRepository) Replace(ctx context.Context, userID uint, codes []models.MFARecoveryCode) error {
+	err := connection(ctx, r.db).Where("user_id = ?", userID).Delete(&models.MFARecoveryCode{}).Error
+	if err != nil {
+		return fmt.Errorf("execute delete recovery codes query: %w", err)
+	}
+
+	if len(codes) == 0 {
+		return nil
+	}
+
+	if err := connection(ctx, r.db).Create(&codes).Error; err != nil {
+		return fmt.Errorf("execute insert recovery codes query: %w", err)
+	}
+
+	return nil
+}
+
+func (r MFARecoveryCodeRepository) ListUnused(ctx context.Context, userID uint) ([]models.MFARecoveryCode, error) {
+	var codes []models.MFARecoveryCode
+	err := connection(ctx, r.db).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Order("id").
+		Find(&codes).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("execute select unused recovery codes query: %w", err)
+	}
+
+	return codes, nil
+}
+
+// MarkUsed marks the code as used. It reports false if the code has already been used,
+// so concurrent logins can't both use the same code.
+func (r MFARecoveryCodeRepository) MarkUsed(ctx context.Context, id uint, usedAt time.Time) (bool, error) {
+	result := connection(ctx, r.db).
+		Model(&models.MFARecoveryCode{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, fmt.Errorf("execute update recovery code used_at query: %w", result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
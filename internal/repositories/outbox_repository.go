@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"echo-app/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return OutboxRepository{db: db}
+}
+
+// Enqueue inserts the entries and returns their IDs in the same order.
+func (r OutboxRepository) Enqueue(ctx context.Context, entries ...models.OutboxEntry) ([]uint, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	if err := connection(ctx, r.db).Create(&entries).Error; err != nil {
+		return nil, fmt.Errorf("execute insert outbox entries query: %w", err)
+	}
+
+	ids := make([]uint, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+
+	return ids, nil
+}
+
+// LockPending selects the entries due for dispatch in insertion order and locks them until the end of the transaction,
+// only the entries with the IDs unless ids is empty. An entry isn't selected while an earlier entry of the same entity
+// is pending, so changes of an entity are dispatched in order. Entries given up are neither selected nor hold back others.
+func (r OutboxRepository) LockPending(ctx context.Context, now time.Time, limit int, ids []uint) ([]models.OutboxEntry, error) {
+	query := connection(ctx, r.db).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+		Where("processed_at IS NULL AND failed_at IS NULL AND next_attempt_at <= ?", now).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM permify_outbox earlier
+			WHERE earlier.processed_at IS NULL
+				AND earlier.failed_at IS NULL
+				AND earlier.entity_type = permify_outbox.entity_type
+				AND earlier.entity_id = permify_outbox.entity_id
+				AND earlier.id < permify_outbox.id
+		)`)
+
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+
+	var entries []models.OutboxEntry
+	if err := query.Order("id").Limit(limit).Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("execute select pending outbox entries query: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Lease postpones the next attempt of the entries until the time, so other dispatchers skip them meanwhile.
+func (r OutboxRepository) Lease(ctx context.Context, ids []uint, until time.Time) error {
+	err := connection(ctx, r.db).
+		Model(&models.OutboxEntry{}).
+		Where("id IN ?", ids).
+		Update("next_attempt_at", until).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute lease outbox entries query: %w", err)
+	}
+
+	return nil
+}
+
+// ListPending returns the entries of the entity that aren't processed or given up yet in insertion order.
+func (r OutboxRepository) ListPending(ctx context.Context, entityType, entityID string) ([]models.OutboxEntry, error) {
+	var entries []models.OutboxEntry
+	err := connection(ctx, r.db).
+		Where("processed_at IS NULL AND failed_at IS NULL AND entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("id").
+		Find(&entries).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("execute select pending entity outbox entries query: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r OutboxRepository) Update(ctx context.Context, entry *models.OutboxEntry) error {
+	if err := connection(ctx, r.db).Save(entry).Error; err != nil {
+		return fmt.Errorf("execute update outbox entry query: %w", err)
+	}
+
+	return nil
+}
+
+// snapTokenTables are the tables of the entities keeping the snap token of their last relationship change.
+var snapTokenTables = map[string]string{
+	"post":   "posts",
+	"domain": "domains",
+}
+
+// SetSnapToken stores the snap token on the entity, entities without a snap token column are skipped.
+func (r OutboxRepository) SetSnapToken(ctx context.Context, entityType, entityID, snapToken string) error {
+	table, ok := snapTokenTables[entityType]
+	if !ok {
+		return nil
+	}
+
+	err := connection(ctx, r.db).Table(table).Where("id = ?", entityID).Update("snap_token", snapToken).Error
+	if err != nil {
+		return fmt.Errorf("execute update %s snap token query: %w", table, err)
+	}
+
+	return nil
+}
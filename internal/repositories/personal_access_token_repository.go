@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"echo-app/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type PersonalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPersonalAccessTokenRepository(db *gorm.DB) PersonalAccessTokenRepository {
+	return PersonalAccessTokenRepository{db: db}
+}
+
+func (r PersonalAccessTokenRepository) Create(ctx context.Context, token *models.PersonalAccessToken) error {
+	if err := connection(ctx, r.db).Create(token).Error; err != nil {
+		return fmt.Errorf("execute insert personal access token query: %w", err)
+	}
+
+	return nil
+}
+
+func (r PersonalAccessTokenRepository) GetByHash(ctx context.Context, hash string) (models.PersonalAccessToken, error) {
+	var token models.PersonalAccessToken
+	err := connection(ctx, r.db).Where("token_hash = ?", hash).Take(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.PersonalAccessToken{}, errors.Join(models.ErrPersonalAccessTokenNotFound, err)
+	} else if err != nil {
+		return models.PersonalAccessToken{}, fmt.Errorf("execute select personal access token by hash query: %w", err)
+	}
+
+	return token, nil
+}
+
+// ListUnrevoked returns the tokens of the user that aren't revoked, the newest first.
+// Expired tokens are listed too, so the user can see which ones to replace.
+func (r PersonalAccessTokenRepository) ListUnrevoked(ctx context.Context, userID uint) ([]models.PersonalAccessToken, error) {
+	var tokens []models.PersonalAccessToken
+	err := connection(ctx, r.db).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("created_at DESC, id DESC").
+		Find(&tokens).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("execute select personal access tokens query: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// Touch records the token was used at usedAt.
+func (r PersonalAccessTokenRepository) Touch(ctx context.Context, id uint, usedAt time.Time) error {
+	err := connection(ctx, r.db).
+		Model(&models.PersonalAccessToken{}).
+		Where("id = ?", id).
+		Update("last_used_at", usedAt).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute update personal access token last_used_at query: %w", err)
+	}
+
+	return nil
+}
+
+// Revoke revokes the token of the user. Tokens of other users and revoked tokens are not found.
+func (r PersonalAccessTokenRepository) Revoke(ctx context.Context, userID, id uint, revokedAt time.Time) error {
+	result := connection(ctx, r.db).
+		Model(&models.PersonalAccessToken{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return fmt.Errorf("execute revoke personal access token query: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return models.ErrPersonalAccessTokenNotFound
+	}
+
+	return nil
+}
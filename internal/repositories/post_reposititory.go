@@ -6,10 +6,13 @@ import (
 	"fmt"
 
 	"echo-app/internal/models"
+	"echo-app/internal/tenant"
 
 	"gorm.io/gorm"
 )
 
+// PostRepository scopes every query to the domain carried by the context, see tenant.WithDomain.
+// Queries fail with tenant.ErrNoDomain on unscoped contexts.
 type PostRepository struct {
 	db *gorm.DB
 }
@@ -18,8 +21,26 @@ func NewPostRepository(db *gorm.DB) PostRepository {
 	return PostRepository{db: db}
 }
 
+// scoped returns the connection restricted to the posts of the current domain.
+func (r PostRepository) scoped(ctx context.Context) (*gorm.DB, error) {
+	domainID, err := tenant.DomainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("scope posts: %w", err)
+	}
+
+	return connection(ctx, r.db).Where("posts.domain_id = ?", domainID), nil
+}
+
+// Create stores the post in the current domain, the domain of the post is overwritten.
 func (r PostRepository) Create(ctx context.Context, post *models.Post) error {
-	if err := r.db.WithContext(ctx).Create(post).Error; err != nil {
+	domainID, err := tenant.DomainID(ctx)
+	if err != nil {
+		return fmt.Errorf("scope post: %w", err)
+	}
+
+	post.DomainID = domainID
+
+	if err := connection(ctx, r.db).Create(post).Error; err != nil {
 		return fmt.Errorf("execute insert post query: %w", err)
 	}
 
@@ -27,17 +48,60 @@ func (r PostRepository) Create(ctx context.Context, post *models.Post) error {
 }
 
 func (r PostRepository) GetPosts(ctx context.Context) ([]models.Post, error) {
+	db, err := r.scoped(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var posts []models.Post
-	if err := r.db.WithContext(ctx).Find(&posts).Error; err != nil {
+	if err := db.Find(&posts).Error; err != nil {
 		return nil, fmt.Errorf("execute select posts query: %w", err)
 	}
 
 	return posts, nil
 }
 
+// GetPostsByIDs returns the posts with the IDs ordered by ID, unknown IDs are skipped.
+func (r PostRepository) GetPostsByIDs(ctx context.Context, ids []uint) ([]models.Post, error) {
+	if len(ids) == 0 {
+		return []models.Post{}, nil
+	}
+
+	db, err := r.scoped(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var posts []models.Post
+	if err := db.Where("id IN ?", ids).Order("id").Find(&posts).Error; err != nil {
+		return nil, fmt.Errorf("execute select posts by ids query: %w", err)
+	}
+
+	return posts, nil
+}
+
+func (r PostRepository) CountPosts(ctx context.Context) (int64, error) {
+	db, err := r.scoped(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := db.Model(&models.Post{}).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("execute count posts query: %w", err)
+	}
+
+	return count, nil
+}
+
 func (r PostRepository) GetPost(ctx context.Context, id uint) (models.Post, error) {
+	db, err := r.scoped(ctx)
+	if err != nil {
+		return models.Post{}, err
+	}
+
 	var post models.Post
-	err := r.db.WithContext(ctx).Where("id = ?", id).Take(&post).Error
+	err = db.Where("id = ?", id).Take(&post).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return models.Post{}, errors.Join(models.ErrPostNotFound, err)
 	} else if err != nil {
@@ -47,17 +111,39 @@ func (r PostRepository) GetPost(ctx context.Context, id uint) (models.Post, erro
 	return post, nil
 }
 
+// Update updates the title and the content of the post, posts of other domains are reported as not found.
 func (r PostRepository) Update(ctx context.Context, post *models.Post) error {
-	if err := r.db.WithContext(ctx).Save(post).Error; err != nil {
-		return fmt.Errorf("execute update post query: %w", err)
+	db, err := r.scoped(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Model(post).Select("title", "content").Updates(post)
+	if result.Error != nil {
+		return fmt.Errorf("execute update post query: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return models.ErrPostNotFound
 	}
 
 	return nil
 }
 
+// Delete deletes the post, posts of other domains are reported as not found.
 func (r PostRepository) Delete(ctx context.Context, post *models.Post) error {
-	if err := r.db.WithContext(ctx).Delete(post).Error; err != nil {
-		return fmt.Errorf("execute delete post query: %w", err)
+	db, err := r.scoped(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Delete(post)
+	if result.Error != nil {
+		return fmt.Errorf("execute delete post query: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return models.ErrPostNotFound
 	}
 
 	return nil
@@ -0,0 +1,236 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"echo-app/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	sessionKeyPrefix      = "session:"
+	userSessionsKeyPrefix = "user_sessions:"
+	oidcSessionsKeyPrefix = "oidc_sessions:"
+)
+
+// RedisSessionRepository keeps every session under its own key expiring after the idle TTL, every use of the session
+// renews it. Revoked sessions are deleted. The sessions of a user and of an OIDC session are indexed in sets,
+// the sets are cleaned up lazily when their sessions expired.
+type RedisSessionRepository struct {
+	client  *redis.Client
+	idleTTL time.Duration
+}
+
+func NewRedisSessionRepository(client *redis.Client, idleTTL time.Duration) RedisSessionRepository {
+	return RedisSessionRepository{client: client, idleTTL: idleTTL}
+}
+
+func (r RedisSessionRepository) Create(ctx context.Context, session *models.Session) error {
+	value, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("marshal session: %w", err)
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sessionKey(session.ID), value, r.idleTTL)
+		pipe.SAdd(ctx, userSessionsKey(session.UserID), session.ID.String())
+		pipe.Expire(ctx, userSessionsKey(session.UserID), r.idleTTL)
+
+		if session.OIDCSessionID != "" {
+			pipe.SAdd(ctx, oidcSessionsKey(session.OIDCProvider, session.OIDCSessionID), session.ID.String())
+			pipe.Expire(ctx, oidcSessionsKey(session.OIDCProvider, session.OIDCSessionID), r.idleTTL)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("execute create session commands: %w", err)
+	}
+
+	return nil
+}
+
+func (r RedisSessionRepository) Get(ctx context.Context, id uuid.UUID) (models.Session, error) {
+	value, err := r.client.Get(ctx, sessionKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return models.Session{}, errors.Join(models.ErrSessionNotFound, err)
+	} else if err != nil {
+		return models.Session{}, fmt.Errorf("execute get session command: %w", err)
+	}
+
+	var session models.Session
+	if err := json.Unmarshal(value, &session); err != nil {
+		return models.Session{}, fmt.Errorf("unmarshal session: %w", err)
+	}
+
+	return session, nil
+}
+
+// ListActive returns the sessions of the user used after since, the last used first.
+func (r RedisSessionRepository) ListActive(ctx context.Context, userID uint, since time.Time) ([]models.Session, error) {
+	ids, err := r.client.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("execute get user sessions command: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, sessionKeyPrefix+id)
+	}
+
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("execute get sessions command: %w", err)
+	}
+
+	sessions := make([]models.Session, 0, len(values))
+	expired := make([]any, 0)
+
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			expired = append(expired, ids[i])
+			continue
+		}
+
+		var session models.Session
+		if err := json.Unmarshal([]byte(raw), &session); err != nil {
+			return nil, fmt.Errorf("unmarshal session: %w", err)
+		}
+
+		if session.LastSeenAt.After(since) {
+			sessions = append(sessions, session)
+		}
+	}
+
+	if len(expired) > 0 {
+		if err := r.client.SRem(ctx, userSessionsKey(userID), expired...).Err(); err != nil {
+			return nil, fmt.Errorf("execute remove expired user sessions command: %w", err)
+		}
+	}
+
+	slices.SortFunc(sessions, func(a, b models.Session) int {
+		return b.LastSeenAt.Compare(a.LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// Touch records the session was used at seenAt from the IP and renews its expiry.
+func (r RedisSessionRepository) Touch(ctx context.Context, id uuid.UUID, ip string, seenAt time.Time) error {
+	session, err := r.Get(ctx, id)
+	if errors.Is(err, models.ErrSessionNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	session.IP = ip
+	session.LastSeenAt = seenAt
+
+	value, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("marshal session: %w", err)
+	}
+
+	// SET XX doesn't bring back a session revoked in the meantime
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SetXX(ctx, sessionKey(id), value, r.idleTTL)
+		pipe.Expire(ctx, userSessionsKey(session.UserID), r.idleTTL)
+
+		if session.OIDCSessionID != "" {
+			pipe.Expire(ctx, oidcSessionsKey(session.OIDCProvider, session.OIDCSessionID), r.idleTTL)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("execute touch session commands: %w", err)
+	}
+
+	return nil
+}
+
+// Revoke deletes the session, revokedAt isn't kept.
+func (r RedisSessionRepository) Revoke(ctx context.Context, id uuid.UUID, _ time.Time) error {
+	session, err := r.Get(ctx, id)
+	if errors.Is(err, models.ErrSessionNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionKey(id))
+		pipe.SRem(ctx, userSessionsKey(session.UserID), id.String())
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("execute revoke session commands: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeUser deletes every session of the user.
+func (r RedisSessionRepository) RevokeUser(ctx context.Context, userID uint, _ time.Time) error {
+	if err := r.deleteIndexed(ctx, userSessionsKey(userID)); err != nil {
+		return fmt.Errorf("revoke user sessions: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeOIDCSession deletes the sessions started in the session of the identity provider.
+func (r RedisSessionRepository) RevokeOIDCSession(ctx context.Context, oidcProvider, oidcSessionID string, _ time.Time) error {
+	if err := r.deleteIndexed(ctx, oidcSessionsKey(oidcProvider, oidcSessionID)); err != nil {
+		return fmt.Errorf("revoke OIDC session sessions: %w", err)
+	}
+
+	return nil
+}
+
+// deleteIndexed deletes the sessions of the index set together with the set.
+func (r RedisSessionRepository) deleteIndexed(ctx context.Context, indexKey string) error {
+	ids, err := r.client.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return fmt.Errorf("execute get indexed sessions command: %w", err)
+	}
+
+	keys := make([]string, 0, len(ids)+1)
+	for _, id := range ids {
+		keys = append(keys, sessionKeyPrefix+id)
+	}
+
+	if err := r.client.Del(ctx, append(keys, indexKey)...).Err(); err != nil {
+		return fmt.Errorf("execute delete sessions command: %w", err)
+	}
+
+	return nil
+}
+
+func sessionKey(id uuid.UUID) string {
+	return sessionKeyPrefix + id.String()
+}
+
+func userSessionsKey(userID uint) string {
+	return userSessionsKeyPrefix + strconv.FormatUint(uint64(userID), 10)
+}
+
+// oidcSessionsKey is scoped to the provider, provider names can't contain a colon.
+func oidcSessionsKey(oidcProvider, oidcSessionID string) string {
+	return oidcSessionsKeyPrefix + oidcProvider + ":" + oidcSessionID
+}
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"echo-app/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return RefreshTokenRepository{db: db}
+}
+
+func (r RefreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	if err := connection(ctx, r.db).Create(token).Error; err != nil {
+		return fmt.Errorf("execute insert refresh token query: %w", err)
+	}
+
+	return nil
+}
+
+func (r RefreshTokenRepository) GetByHash(ctx context.Context, hash string) (models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := connection(ctx, r.db).Where("token_hash = ?", hash).Take(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.RefreshToken{}, errors.Join(models.ErrRefreshTokenNotFound, err)
+	} else if err != nil {
+		return models.RefreshToken{}, fmt.Errorf("execute select refresh token by hash query: %w", err)
+	}
+
+	return token, nil
+}
+
+// MarkUsed marks the token as used. It reports false if the token has already been used,
+// so concurrent rotations of the same token can't both succeed.
+func (r RefreshTokenRepository) MarkUsed(ctx context.Context, id uint, usedAt time.Time) (bool, error) {
+	result := connection(ctx, r.db).
+		Model(&models.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, fmt.Errorf("execute update refresh token used_at query: %w", result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID, revokedAt time.Time) error {
+	err := connection(ctx, r.db).
+		Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", revokedAt).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute revoke refresh token family query: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeUser revokes every token family of the user.
+func (r RefreshTokenRepository) RevokeUser(ctx context.Context, userID uint, revokedAt time.Time) error {
+	err := connection(ctx, r.db).
+		Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute revoke user refresh tokens query: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeOIDCSession revokes the token families started in the session of the identity provider.
+func (r RefreshTokenRepository) RevokeOIDCSession(ctx context.Context, provider, sessionID string, revokedAt time.Time) error {
+	err := connection(ctx, r.db).
+		Model(&models.RefreshToken{}).
+		Where("oidc_provider = ? AND oidc_session_id = ? AND revoked_at IS NULL", provider, sessionID).
+		Update("revoked_at", revokedAt).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute revoke OIDC session refresh tokens query: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"echo-app/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type SchemaVersionRepository struct {
+	db *gorm.DB
+}
+
+func NewSchemaVersionRepository(db *gorm.DB) SchemaVersionRepository {
+	return SchemaVersionRepository{db: db}
+}
+
+func (r SchemaVersionRepository) Create(ctx context.Context, version *models.SchemaVersion) error {
+	if err := connection(ctx, r.db).Create(version).Error; err != nil {
+		return fmt.Errorf("execute insert schema version query: %w", err)
+	}
+
+	return nil
+}
+
+// Latest returns the schema version written last for the tenant.
+func (r SchemaVersionRepository) Latest(ctx context.Context, tenantID string) (models.SchemaVersion, error) {
+	var version models.SchemaVersion
+	err := connection(ctx, r.db).Where("tenant_id = ?", tenantID).Order("id DESC").Take(&version).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.SchemaVersion{}, errors.Join(models.ErrSchemaVersionNotFound, err)
+	} else if err != nil {
+		return models.SchemaVersion{}, fmt.Errorf("execute select latest schema version query: %w", err)
+	}
+
+	return version, nil
+}
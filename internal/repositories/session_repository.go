@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"echo-app/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return SessionRepository{db: db}
+}
+
+func (r SessionRepository) Create(ctx context.Context, session *models.Session) error {
+	if err := connection(ctx, r.db).Create(session).Error; err != nil {
+		return fmt.Errorf("execute insert session query: %w", err)
+	}
+
+	return nil
+}
+
+func (r SessionRepository) Get(ctx context.Context, id uuid.UUID) (models.Session, error) {
+	var session models.Session
+	err := connection(ctx, r.db).Where("id = ?", id).Take(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Session{}, errors.Join(models.ErrSessionNotFound, err)
+	} else if err != nil {
+		return models.Session{}, fmt.Errorf("execute select session by id query: %w", err)
+	}
+
+	return session, nil
+}
+
+// ListActive returns the sessions of the user that aren't revoked and were used after since, the last used first.
+func (r SessionRepository) ListActive(ctx context.Context, userID uint, since time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+	err := connection(ctx, r.db).
+		Where("user_id = ? AND revoked_at IS NULL AND last_seen_at > ?", userID, since).
+		Order("last_seen_at DESC").
+		Find(&sessions).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("execute select active sessions query: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Touch records the session was used at seenAt from the IP.
+func (r SessionRepository) Touch(ctx context.Context, id uuid.UUID, ip string, seenAt time.Time) error {
+	err := connection(ctx, r.db).
+		Model(&models.Session{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"ip": ip, "last_seen_at": seenAt}).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute update session last_seen_at query: %w", err)
+	}
+
+	return nil
+}
+
+func (r SessionRepository) Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	err := connection(ctx, r.db).
+		Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", revokedAt).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute revoke session query: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeUser revokes every session of the user.
+func (r SessionRepository) RevokeUser(ctx context.Context, userID uint, revokedAt time.Time) error {
+	err := connection(ctx, r.db).
+		Model(&models.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute revoke user sessions query: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeOIDCSession revokes the sessions started in the session of the identity provider.
+func (r SessionRepository) RevokeOIDCSession(ctx context.Context, oidcProvider, oidcSessionID string, revokedAt time.Time) error {
+	err := connection(ctx, r.db).
+		Model(&models.Session{}).
+		Where("oidc_provider = ? AND oidc_session_id = ? AND revoked_at IS NULL", oidcProvider, oidcSessionID).
+		Update("revoked_at", revokedAt).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute revoke OIDC session sessions query: %w", err)
+	}
+
+	return nil
+}
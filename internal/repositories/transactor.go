@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type transactionKey struct{}
+
+// Transactor runs functions in a database transaction carried by the context.
+type Transactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) Transactor {
+	return Transactor{db: db}
+}
+
+// Transaction runs fn in a transaction, repositories called with the context passed to fn take part in it.
+// Nested calls join the outer transaction.
+func (t Transactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, transactionKey{}, tx))
+	})
+	if err != nil {
+		return fmt.Errorf("run transaction: %w", err)
+	}
+
+	return nil
+}
+
+// connection returns the transaction carried by the context or the db otherwise.
+func connection(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
+		return tx
+	}
+
+	return db.WithContext(ctx)
+}
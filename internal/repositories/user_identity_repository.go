@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"echo-app/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type UserIdentityRepository struct {
+	db *gorm.DB
+}
+
+func NewUserIdentityRepository(db *gorm.DB) UserIdentityRepository {
+	return UserIdentityRepository{db: db}
+}
+
+func (r UserIdentityRepository) Create(ctx context.Context, identity *models.UserIdentity) error {
+	if err := connection(ctx, r.db).Create(identity).Error; err != nil {
+		return fmt.Errorf("execute insert user identity query: %w", err)
+	}
+
+	return nil
+}
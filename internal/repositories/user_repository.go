@@ -4,11 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"echo-app/internal/models"
-	"echo-app/internal/server/builders"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository struct {
@@ -20,7 +21,7 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 }
 
 func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
-	if err := r.db.WithContext(ctx).Create(user).Error; err != nil {
+	if err := connection(ctx, r.db).Create(user).Error; err != nil {
 		return fmt.Errorf("execute insert user query: %w", err)
 	}
 	return nil
@@ -28,7 +29,7 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 
 func (r *UserRepository) GetByID(ctx context.Context, id uint) (models.User, error) {
 	var user models.User
-	err := r.db.WithContext(ctx).Where("id = ?", id).Take(&user).Error
+	err := connection(ctx, r.db).Where("id = ?", id).Take(&user).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return models.User{}, errors.Join(models.ErrUserNotFound, err)
 	} else if err != nil {
@@ -37,9 +38,21 @@ func (r *UserRepository) GetByID(ctx context.Context, id uint) (models.User, err
 	return user, nil
 }
 
+func (r *UserRepository) GetByIDs(ctx context.Context, ids []uint) ([]models.User, error) {
+	users := make([]models.User, 0, len(ids))
+	if len(ids) == 0 {
+		return users, nil
+	}
+
+	if err := connection(ctx, r.db).Where("id IN ?", ids).Order("id").Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("execute select users by ids query: %w", err)
+	}
+	return users, nil
+}
+
 func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
 	var user models.User
-	err := r.db.WithContext(ctx).Where("email = ?", email).Take(&user).Error
+	err := connection(ctx, r.db).Where("email = ?", email).Take(&user).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return models.User{}, errors.Join(models.ErrUserNotFound, err)
 	} else if err != nil {
@@ -48,52 +61,93 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (mode
 	return user, nil
 }
 
-func (r *UserRepository) GetUserByOIDCSubject(ctx context.Context, sub string) (models.User, error) {
+// GetUserByIdentity returns the user linked to the subject of the identity provider.
+func (r *UserRepository) GetUserByIdentity(ctx context.Context, provider, subject string) (models.User, error) {
 	var user models.User
-	err := r.db.WithContext(ctx).Where("oidc_subject = ?", sub).Take(&user).Error
+	err := connection(ctx, r.db).
+		Joins("JOIN user_identities ON user_identities.user_id = users.id").
+		Where("user_identities.provider = ? AND user_identities.subject = ?", provider, subject).
+		Take(&user).
+		Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return models.User{}, errors.Join(models.ErrUserNotFound, err)
 	} else if err != nil {
-		return models.User{}, fmt.Errorf("execute select user by OIDC subject query: %w", err)
+		return models.User{}, fmt.Errorf("execute select user by identity query: %w", err)
 	}
 	return user, nil
 }
 
 func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
-	if err := r.db.WithContext(ctx).Save(user).Error; err != nil {
+	if err := connection(ctx, r.db).Save(user).Error; err != nil {
 		return fmt.Errorf("execute update user query: %w", err)
 	}
 	return nil
 }
 
-func (r *UserRepository) GetOrCreateUserFromOIDC(ctx context.Context, claims *models.OIDCClaims) (models.User, error) {
-	// First try to find by OIDC subject
-	user, err := r.GetUserByOIDCSubject(ctx, claims.Sub)
-	if err == nil {
-		return user, nil
+// VerifyEmail marks the email of the user as verified and consumes the verification token. It reports false
+// if the token isn't the current one of the user or the email changed, so a token can be used only once.
+func (r *UserRepository) VerifyEmail(ctx context.Context, id uint, tokenID, email string, verifiedAt time.Time) (bool, error) {
+	result := connection(ctx, r.db).
+		Model(&models.User{}).
+		Where("id = ? AND verification_token_id = ? AND email = ?", id, tokenID, email).
+		Updates(map[string]any{"email_verified_at": verifiedAt, "verification_token_id": ""})
+	if result.Error != nil {
+		return false, fmt.Errorf("execute update user email_verified_at query: %w", result.Error)
 	}
 
-	// If not found by subject, try by email
-	user, err = r.GetUserByEmail(ctx, claims.Email)
-	if err == nil {
-		// Update existing user with OIDC subject
-		user.OIDCSubject = claims.Sub
-		if err := r.Update(ctx, &user); err != nil {
-			return models.User{}, fmt.Errorf("update user with OIDC subject: %w", err)
-		}
-		return user, nil
+	return result.RowsAffected == 1, nil
+}
+
+// UseTOTPStep records the time step of an accepted TOTP code. It reports false if a code of the step or a later one
+// has already been accepted, so concurrent logins can't both use the same code.
+func (r *UserRepository) UseTOTPStep(ctx context.Context, id uint, step int64) (bool, error) {
+	result := connection(ctx, r.db).
+		Model(&models.User{}).
+		Where("id = ? AND totp_last_step < ?", id, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		return false, fmt.Errorf("execute update user totp_last_step query: %w", result.Error)
 	}
 
-	// Create new user if not found
-	newUser := builders.NewUserBuilder().
-		SetEmail(claims.Email).
-		SetName(claims.Name).
-		SetOIDCSubject(claims.Sub).
-		Build()
+	return result.RowsAffected == 1, nil
+}
 
-	if err := r.Create(ctx, newUser); err != nil {
-		return models.User{}, fmt.Errorf("create OIDC user: %w", err)
+// RecordMFAFailure counts an invalid second factor code of the user. The maxAttempts-th one in a row resets the count
+// and locks the second step until lockedUntil, it reports whether the user got locked.
+func (r *UserRepository) RecordMFAFailure(ctx context.Context, id uint, maxAttempts int, lockedUntil time.Time) (bool, error) {
+	var user models.User
+
+	// Both expressions read the count before the update, concurrent failures are counted one by one
+	result := connection(ctx, r.db).
+		Model(&user).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "mfa_failed_attempts"}}}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"mfa_failed_attempts": gorm.Expr("CASE WHEN mfa_failed_attempts + 1 >= ? THEN 0 ELSE mfa_failed_attempts + 1 END", maxAttempts),
+			"mfa_locked_until":    gorm.Expr("CASE WHEN mfa_failed_attempts + 1 >= ? THEN ? ELSE mfa_locked_until END", maxAttempts, lockedUntil),
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("execute update user mfa_failed_attempts query: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return false, models.ErrUserNotFound
+	}
+
+	// The count only goes back to zero when the failure locks the user
+	return user.MFAFailedAttempts == 0, nil
+}
+
+// ResetMFAFailures clears the invalid second factor codes and the lock of the user after an accepted code.
+func (r *UserRepository) ResetMFAFailures(ctx context.Context, id uint) error {
+	err := connection(ctx, r.db).
+		Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"mfa_failed_attempts": 0, "mfa_locked_until": nil}).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute reset user mfa_failed_attempts query: %w", err)
 	}
 
-	return *newUser, nil
+	return nil
 }
@@ -0,0 +1,47 @@
+package requests
+
+import validation "github.com/go-ozzo/ozzo-validation/v4"
+
+type BasicDomain struct {
+	Name string `json:"name" validate:"required" example:"Acme"`
+}
+
+func (bd BasicDomain) Validate() error {
+	return validation.ValidateStruct(&bd,
+		validation.Field(&bd.Name, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+type CreateDomainRequest struct {
+	BasicDomain
+}
+
+type RenameDomainRequest struct {
+	BasicDomain
+}
+
+type BasicMemberRole struct {
+	Role string `json:"role" validate:"required" enums:"viewer,member,admin" example:"member"`
+}
+
+func (br BasicMemberRole) Validate() error {
+	return validation.ValidateStruct(&br,
+		validation.Field(&br.Role, validation.Required, validation.In("viewer", "member", "admin")),
+	)
+}
+
+type AddMemberRequest struct {
+	Email string `json:"email" validate:"required" example:"john.doe@example.com"`
+	BasicMemberRole
+}
+
+func (ar AddMemberRequest) Validate() error {
+	return validation.ValidateStruct(&ar,
+		validation.Field(&ar.Email, validation.Required),
+		validation.Field(&ar.BasicMemberRole),
+	)
+}
+
+type ChangeMemberRoleRequest struct {
+	BasicMemberRole
+}
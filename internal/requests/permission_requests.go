@@ -0,0 +1,30 @@
+package requests
+
+import validation "github.com/go-ozzo/ozzo-validation/v4"
+
+// maxPermissionChecks caps a bulk request, it's meant for a page of entities.
+const maxPermissionChecks = 100
+
+type PermissionCheck struct {
+	EntityType string `json:"entityType" validate:"required" enums:"post,domain" example:"post"`
+	EntityID   string `json:"entityId" validate:"required" example:"1"`
+	Permission string `json:"permission" validate:"required" example:"edit"`
+}
+
+func (pc PermissionCheck) Validate() error {
+	return validation.ValidateStruct(&pc,
+		validation.Field(&pc.EntityType, validation.Required, validation.In("post", "domain")),
+		validation.Field(&pc.EntityID, validation.Required, validation.Length(1, 255)),
+		validation.Field(&pc.Permission, validation.Required, validation.Length(1, 64)),
+	)
+}
+
+type BulkPermissionCheckRequest struct {
+	Checks []PermissionCheck `json:"checks" validate:"required"`
+}
+
+func (br BulkPermissionCheckRequest) Validate() error {
+	return validation.ValidateStruct(&br,
+		validation.Field(&br.Checks, validation.Required, validation.Length(1, maxPermissionChecks)),
+	)
+}
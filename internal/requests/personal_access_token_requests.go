@@ -0,0 +1,28 @@
+package requests
+
+import (
+	"time"
+
+	"echo-app/internal/models"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type CreatePersonalAccessTokenRequest struct {
+	Name      string    `json:"name" validate:"required" example:"CI pipeline"`
+	Scopes    []string  `json:"scopes" validate:"required" enums:"posts:read,posts:write,domains:read,domains:write" example:"posts:read"`
+	ExpiresAt time.Time `json:"expiresAt" validate:"required" example:"2027-01-01T00:00:00Z"`
+}
+
+func (cr CreatePersonalAccessTokenRequest) Validate() error {
+	return validation.ValidateStruct(&cr,
+		validation.Field(&cr.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&cr.Scopes, validation.Required, validation.Each(validation.In(
+			models.ScopePostsRead,
+			models.ScopePostsWrite,
+			models.ScopeDomainsRead,
+			models.ScopeDomainsWrite,
+		))),
+		validation.Field(&cr.ExpiresAt, validation.Required),
+	)
+}
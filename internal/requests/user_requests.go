@@ -44,3 +44,60 @@ func (rr RegisterRequest) Validate() error {
 type RefreshRequest struct {
 	Token string `json:"token" validate:"required" example:"refresh_token"`
 }
+
+func (rr RefreshRequest) Validate() error {
+	return validation.ValidateStruct(&rr,
+		validation.Field(&rr.Token, validation.Required),
+	)
+}
+
+// Validate only requires the credentials to be present: the password length rules
+// of registration shouldn't lock out existing accounts.
+func (lr LoginRequest) Validate() error {
+	return validation.ValidateStruct(&lr.BasicAuth,
+		validation.Field(&lr.Email, validation.Required),
+		validation.Field(&lr.Password, validation.Required),
+	)
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required" example:"verification_token"`
+}
+
+func (vr VerifyEmailRequest) Validate() error {
+	return validation.ValidateStruct(&vr,
+		validation.Field(&vr.Token, validation.Required),
+	)
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required" example:"john.doe@example.com"`
+}
+
+func (rr ResendVerificationRequest) Validate() error {
+	return validation.ValidateStruct(&rr,
+		validation.Field(&rr.Email, validation.Required),
+	)
+}
+
+type MFALoginRequest struct {
+	MFAToken string `json:"mfaToken" validate:"required" example:"mfa_token"`
+	Code     string `json:"code" validate:"required" example:"123456"`
+}
+
+func (mr MFALoginRequest) Validate() error {
+	return validation.ValidateStruct(&mr,
+		validation.Field(&mr.MFAToken, validation.Required),
+		validation.Field(&mr.Code, validation.Required),
+	)
+}
+
+type ConfirmTOTPRequest struct {
+	Code string `json:"code" validate:"required" example:"123456"`
+}
+
+func (cr ConfirmTOTPRequest) Validate() error {
+	return validation.ValidateStruct(&cr,
+		validation.Field(&cr.Code, validation.Required),
+	)
+}
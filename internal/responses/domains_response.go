@@ -0,0 +1,57 @@
+package responses
+
+import (
+	"time"
+
+	"echo-app/internal/models"
+)
+
+type DomainResponse struct {
+	ID        string    `json:"id" example:"0b6d3a55-5a3f-4a0f-9d8e-2f5f6b1c7a10"`
+	Name      string    `json:"name" example:"Acme"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewDomainResponse(domain models.Domain) DomainResponse {
+	return DomainResponse{
+		ID:        domain.ID.String(),
+		Name:      domain.Name,
+		CreatedAt: domain.CreatedAt,
+	}
+}
+
+func NewDomainsResponse(domains []models.Domain) *[]DomainResponse {
+	domainsResponse := make([]DomainResponse, 0, len(domains))
+
+	for i := range domains {
+		domainsResponse = append(domainsResponse, NewDomainResponse(domains[i]))
+	}
+
+	return &domainsResponse
+}
+
+type MemberResponse struct {
+	UserID uint   `json:"userId" example:"1"`
+	Email  string `json:"email" example:"john.doe@example.com"`
+	Name   string `json:"name" example:"John Doe"`
+	Role   string `json:"role" example:"member"`
+}
+
+func NewMemberResponse(member models.DomainMember) MemberResponse {
+	return MemberResponse{
+		UserID: member.User.ID,
+		Email:  member.User.Email,
+		Name:   member.User.Name,
+		Role:   member.Role,
+	}
+}
+
+func NewMembersResponse(members []models.DomainMember) *[]MemberResponse {
+	membersResponse := make([]MemberResponse, 0, len(members))
+
+	for i := range members {
+		membersResponse = append(membersResponse, NewMemberResponse(members[i]))
+	}
+
+	return &membersResponse
+}
@@ -0,0 +1,25 @@
+package responses
+
+// MFAChallengeResponse answers a password login that needs the second factor, see LoginResponse.
+type MFAChallengeResponse struct {
+	MFAToken string `json:"mfaToken"`
+	Exp      int64  `json:"exp"`
+}
+
+func NewMFAChallengeResponse(mfaToken string, exp int64) *MFAChallengeResponse {
+	return &MFAChallengeResponse{
+		MFAToken: mfaToken,
+		Exp:      exp,
+	}
+}
+
+type TOTPEnrollmentResponse struct {
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	// URI is shown as a QR code to authenticator apps.
+	URI string `json:"uri" example:"otpauth://totp/echo-app:john.doe@example.com?secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP&issuer=echo-app"`
+}
+
+// RecoveryCodesResponse is the only response with the recovery codes, they can't be read again later.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes" example:"abcde-fghij"`
+}
@@ -0,0 +1,25 @@
+package responses
+
+import "echo-app/internal/models"
+
+type PermissionCheckResponse struct {
+	EntityType string `json:"entityType" example:"post"`
+	EntityID   string `json:"entityId" example:"1"`
+	Permission string `json:"permission" example:"edit"`
+	Allowed    bool   `json:"allowed" example:"true"`
+}
+
+func NewPermissionChecksResponse(results []models.PermissionCheckResult) *[]PermissionCheckResponse {
+	response := make([]PermissionCheckResponse, 0, len(results))
+
+	for i := range results {
+		response = append(response, PermissionCheckResponse{
+			EntityType: results[i].EntityType,
+			EntityID:   results[i].EntityID,
+			Permission: results[i].Permission,
+			Allowed:    results[i].Allowed,
+		})
+	}
+
+	return &response
+}
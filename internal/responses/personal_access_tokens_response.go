@@ -0,0 +1,43 @@
+package responses
+
+import (
+	"time"
+
+	"echo-app/internal/models"
+)
+
+type PersonalAccessTokenResponse struct {
+	ID         uint       `json:"id" example:"1"`
+	Name       string     `json:"name" example:"CI pipeline"`
+	Scopes     []string   `json:"scopes" example:"posts:read"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+func NewPersonalAccessTokenResponse(token models.PersonalAccessToken) PersonalAccessTokenResponse {
+	return PersonalAccessTokenResponse{
+		ID:         token.ID,
+		Name:       token.Name,
+		Scopes:     token.Scopes,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
+
+func NewPersonalAccessTokensResponse(tokens []models.PersonalAccessToken) *[]PersonalAccessTokenResponse {
+	tokensResponse := make([]PersonalAccessTokenResponse, 0, len(tokens))
+
+	for i := range tokens {
+		tokensResponse = append(tokensResponse, NewPersonalAccessTokenResponse(tokens[i]))
+	}
+
+	return &tokensResponse
+}
+
+// CreatedPersonalAccessTokenResponse is the only response with the token value, it can't be read again later.
+type CreatedPersonalAccessTokenResponse struct {
+	PersonalAccessTokenResponse
+	Token string `json:"token" example:"pat_8Zk2c0Vq7n1JtX4yR6bW3mLpQeA9sDfGhUiOoPzYxCv"`
+}
@@ -0,0 +1,38 @@
+package responses
+
+import (
+	"time"
+
+	"echo-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type SessionResponse struct {
+	ID         string    `json:"id" example:"0b6d3a55-5a3f-4a0f-9d8e-2f5f6b1c7a10"`
+	Device     string    `json:"device" example:"Firefox on Linux"`
+	IP         string    `json:"ip" example:"203.0.113.7"`
+	UserAgent  string    `json:"userAgent" example:"Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	// Current is set for the session of the request.
+	Current bool `json:"current" example:"true"`
+}
+
+func NewSessionsResponse(sessions []models.Session, currentID uuid.UUID) *[]SessionResponse {
+	sessionsResponse := make([]SessionResponse, 0, len(sessions))
+
+	for _, session := range sessions {
+		sessionsResponse = append(sessionsResponse, SessionResponse{
+			ID:         session.ID.String(),
+			Device:     session.Device,
+			IP:         session.IP,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			Current:    session.ID == currentID,
+		})
+	}
+
+	return &sessionsResponse
+}
@@ -1,12 +1,16 @@
 package builders
 
-import "echo-app/internal/models"
+import (
+	"time"
+
+	"echo-app/internal/models"
+)
 
 type UserBuilder struct {
-	email       string
-	name        string
-	password    string
-	oidcsubject string
+	email           string
+	name            string
+	password        string
+	emailVerifiedAt *time.Time
 }
 
 func NewUserBuilder() *UserBuilder {
@@ -28,16 +32,17 @@ func (userBuilder *UserBuilder) SetPassword(password string) (u *UserBuilder) {
 	return userBuilder
 }
 
-func (userBuilder *UserBuilder) SetOIDCSubject(sub string) *UserBuilder {
-	userBuilder.oidcsubject = sub
+func (userBuilder *UserBuilder) SetEmailVerifiedAt(verifiedAt time.Time) *UserBuilder {
+	userBuilder.emailVerifiedAt = &verifiedAt
 	return userBuilder
 }
 
 func (userBuilder *UserBuilder) Build() *models.User {
 	user := &models.User{
-		Email:    userBuilder.email,
-		Name:     userBuilder.name,
-		Password: userBuilder.password,
+		Email:           userBuilder.email,
+		Name:            userBuilder.name,
+		Password:        userBuilder.password,
+		EmailVerifiedAt: userBuilder.emailVerifiedAt,
 	}
 
 	return user
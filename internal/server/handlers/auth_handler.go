@@ -2,117 +2,178 @@ package handlers
 
 import (
 	"context"
-	"crypto/rand"
-	"encoding/base64"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
-	"time"
+	"net/url"
+	"strings"
 
 	"echo-app/internal/models"
-	"echo-app/internal/repositories"
+	"echo-app/internal/oidcx"
 	"echo-app/internal/responses"
 	s "echo-app/internal/server"
+	"echo-app/internal/services/loginflow"
+	"echo-app/internal/services/logout"
+	"echo-app/internal/services/token"
 	"echo-app/internal/services/user"
 
 	"echo-app/internal/config"
 
 	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"golang.org/x/oauth2"
 )
 
+const (
+	// loginFlowCookie keeps the encrypted state, PKCE code verifier and nonce of the OIDC login until the callback.
+	loginFlowCookie = "auth_state"
+	// idTokenCookie keeps the ID token of the OIDC login, it's the id_token_hint of the logout.
+	idTokenCookie = "id_token"
+	// providerCookie keeps the name of the identity provider of the OIDC login for the logout.
+	providerCookie = "oidc_provider"
+)
+
+var errUnknownProvider = errors.New("unknown OIDC provider")
+
+type authAccessTokens interface {
+	accessTokenCreator
+	ParseAccessToken(raw string) (*token.Claims, error)
+}
+
+type authSessions interface {
+	sessionStarter
+	Logout(ctx context.Context, refreshToken string) error
+	Revoke(ctx context.Context, userID uint, id uuid.UUID) error
+}
+
+type backChannelLogouter interface {
+	BackChannelLogout(ctx context.Context, provider string, claims models.LogoutTokenClaims) error
+}
+
 type AuthHandler struct {
-	oauth2Config   *oauth2.Config
-	oidcProvider   *oidc.Provider
-	tokenVerifier  *oidc.IDTokenVerifier
-	server         *s.Server
-	userRepository *repositories.UserRepository
-	userGetter     *user.Service
+	providers map[string]*oidcx.Provider
+	// defaultProvider serves the routes without a provider, it's the first configured provider.
+	defaultProvider string
+	loginFlows      *loginflow.Service
+	server          *s.Server
+	userGetter      *user.Service
+	accessTokens    authAccessTokens
+	sessions        authSessions
+	logouts         backChannelLogouter
 }
 
-func NewAuthHandler(server *s.Server, userGetter *user.Service, userRepository *repositories.UserRepository, aconf *config.Auth) (*AuthHandler, error) {
-	// Initialize OIDC provider
-	provider, err := oidc.NewProvider(context.Background(), server.Config.Auth.OIDCIssuer)
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize OIDC provider: %w", err)
+func NewAuthHandler(
+	server *s.Server,
+	userGetter *user.Service,
+	accessTokens authAccessTokens,
+	sessions authSessions,
+	logouts backChannelLogouter,
+	aconf *config.Auth,
+) (*AuthHandler, error) {
+	// The providers are discovered on first use, so the service starts while an identity provider is down
+	providers := make(map[string]*oidcx.Provider, len(aconf.Providers))
+	for _, provider := range aconf.Providers {
+		providers[provider.Name] = oidcx.NewProvider(provider)
 	}
 
-	// Configure OAuth2
-	oauth2Config := &oauth2.Config{
-		ClientID:     aconf.OIDCClientID,
-		ClientSecret: aconf.OIDCClientSecret,
-		RedirectURL:  aconf.OIDCRedirectURL,
-		Endpoint:     provider.Endpoint(),
-		Scopes:       []string{oidc.ScopeOpenID, "profile", "email"},
+	var defaultProvider string
+	if len(aconf.Providers) > 0 {
+		defaultProvider = aconf.Providers[0].Name
+	}
+
+	loginFlows, err := loginflow.NewService(*aconf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize OIDC login flows: %w", err)
 	}
 
 	return &AuthHandler{
-		oauth2Config:   oauth2Config,
-		oidcProvider:   provider,
-		tokenVerifier:  provider.Verifier(&oidc.Config{ClientID: server.Config.Auth.OIDCClientID}),
-		server:         server,
-		userGetter:     userGetter,
-		userRepository: userRepository,
+		providers:       providers,
+		defaultProvider: defaultProvider,
+		loginFlows:      loginFlows,
+		server:          server,
+		userGetter:      userGetter,
+		accessTokens:    accessTokens,
+		sessions:        sessions,
+		logouts:         logouts,
 	}, nil
 }
 
 // InitiateLogin godoc
 // @Summary Initiate OIDC login
-// @Description Redirects to Authentik for authentication
-// @ID initiate-login
+// @Description Redirects to the identity provider for authentication with PKCE and a nonce
 // @Tags Authentication
+// @Param provider path string true "Name of the identity provider, the route without it uses the default provider"
+// @Param return_to query string false "URL to redirect to after the login, its origin has to be allowed"
 // @Success 302
+// @Failure 400 {object} responses.Error
+// @Failure 404 {object} responses.Error
+// @Failure 503 {object} responses.Error
 // @Router /login [get]
+// @Router /login/{provider} [get]
 func (h *AuthHandler) InitiateLogin(c echo.Context) error {
-	// Add these checks at the start
-	if h == nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "auth handler not initialized")
-	}
-	if h.oauth2Config == nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "OAuth2 config not initialized")
+	provider, discovery, err := h.discover(c)
+	if err != nil {
+		return discoveryErrorResponse(c, err)
 	}
 
-	state, err := generateRandomState()
-	if err != nil {
-		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to generate state")
+	flow, sealed, err := h.loginFlows.Start(provider, c.QueryParam("return_to"))
+	if errors.Is(err, loginflow.ErrReturnToNotAllowed) {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Invalid return_to parameter")
+	} else if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to start login")
 	}
 
 	c.SetCookie(&http.Cookie{
-		Name:     "auth_state",
-		Value:    state,
+		Name:     loginFlowCookie,
+		Value:    sealed,
 		Path:     "/",
-		MaxAge:   3600,
+		MaxAge:   int(loginflow.TTL.Seconds()),
 		HttpOnly: true,
 		Secure:   false, // Use config
+		SameSite: http.SameSiteLaxMode,
 	})
 
-	authURL := h.oauth2Config.AuthCodeURL(state)
+	authURL := discovery.OAuth2.AuthCodeURL(flow.State, oauth2.S256ChallengeOption(flow.CodeVerifier), oidc.Nonce(flow.Nonce))
 	return c.Redirect(http.StatusFound, authURL)
 }
 
 // HandleCallback godoc
 // @Summary OIDC callback handler
-// @Description Handles the callback from Authentik after login
-// @ID handle-callback
+// @Description Handles the callback from the identity provider after login and redirects to the return_to URL of the login
 // @Tags Authentication
+// @Param provider path string true "Name of the identity provider, the route without it uses the default provider"
 // @Success 302
 // @Failure 400 {object} responses.Error
 // @Failure 401 {object} responses.Error
+// @Failure 404 {object} responses.Error
 // @Failure 500 {object} responses.Error
+// @Failure 503 {object} responses.Error
 // @Router /callback [get]
+// @Router /callback/{provider} [get]
 func (h *AuthHandler) HandleCallback(c echo.Context) error {
+	provider, discovery, err := h.discover(c)
+	if err != nil {
+		return discoveryErrorResponse(c, err)
+	}
+
 	// Verify state
-	stateCookie, err := c.Cookie("auth_state")
+	flowCookie, err := c.Cookie(loginFlowCookie)
 	if err != nil {
 		return responses.ErrorResponse(c, http.StatusBadRequest, "State cookie missing")
 	}
 
-	if c.QueryParam("state") != stateCookie.Value {
+	// The flow can only be used once
+	clearCookie(c, loginFlowCookie)
+
+	flow, err := h.loginFlows.Resume(flowCookie.Value, provider, c.QueryParam("state"))
+	if err != nil {
 		return responses.ErrorResponse(c, http.StatusBadRequest, "Invalid state parameter")
 	}
 
 	// Exchange code for token
-	token, err := h.oauth2Config.Exchange(c.Request().Context(), c.QueryParam("code"))
+	token, err := discovery.OAuth2.Exchange(c.Request().Context(), c.QueryParam("code"), oauth2.VerifierOption(flow.CodeVerifier))
 	if err != nil {
 		return responses.ErrorResponse(c, http.StatusUnauthorized, "Failed to exchange token: "+err.Error())
 	}
@@ -124,86 +185,263 @@ func (h *AuthHandler) HandleCallback(c echo.Context) error {
 	}
 
 	// Verify ID token
-	idToken, err := h.tokenVerifier.Verify(c.Request().Context(), rawIDToken)
+	idToken, err := discovery.Verifier.Verify(c.Request().Context(), rawIDToken)
 	if err != nil {
 		return responses.ErrorResponse(c, http.StatusUnauthorized, "Failed to verify ID Token: "+err.Error())
 	}
 
+	if err := flow.VerifyNonce(idToken.Nonce); err != nil {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Invalid ID Token nonce")
+	}
+
 	// Extract claims
 	var claims models.OIDCClaims
 	if err := idToken.Claims(&claims); err != nil {
 		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to parse claims: "+err.Error())
 	}
 
+	var rawClaims map[string]any
+	if err := idToken.Claims(&rawClaims); err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to parse claims: "+err.Error())
+	}
+
+	claims.Groups = stringsClaim(rawClaims[h.providers[provider].GroupsClaim()])
+
 	// Get or create user in our system
-	user, err := h.userRepository.GetOrCreateUserFromOIDC(c.Request().Context(), &claims)
-	if err != nil {
+	oidcUser, err := h.userGetter.GetOrCreateUserFromOIDC(c.Request().Context(), provider, &claims)
+	if errors.Is(err, user.ErrOIDCEmailNotVerified) {
+		return responses.ErrorResponse(c, http.StatusConflict, "An account with the email already exists, verify the email at the identity provider to link it")
+	} else if err != nil {
 		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to process user: "+err.Error())
 	}
 
-	// Create session tokens (simplified - in production use proper session management)
-	accessToken, err := h.createAccessToken(user)
+	response, refreshToken, err := issueTokens(c, h.accessTokens, h.sessions, &oidcUser, provider, claims.SessionID)
 	if err != nil {
-		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to create access token")
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to create session tokens")
 	}
 
-	// Set cookies (simplified - consider HttpOnly, Secure flags in production)
+	setAuthCookies(c, response, refreshToken)
+
+	c.SetCookie(&http.Cookie{
+		Name:     idTokenCookie,
+		Value:    rawIDToken,
+		Path:     "/",
+		Expires:  refreshToken.ExpiresAt,
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteLaxMode,
+	})
+
 	c.SetCookie(&http.Cookie{
-		Name:     "access_token",
-		Value:    accessToken,
+		Name:     providerCookie,
+		Value:    provider,
 		Path:     "/",
-		Expires:  time.Now().Add(24 * time.Hour),
+		Expires:  refreshToken.ExpiresAt,
 		HttpOnly: true,
 		Secure:   false,
+		SameSite: http.SameSiteLaxMode,
 	})
 
 	// Redirect to frontend or return success
-	return c.Redirect(http.StatusFound, h.server.Config.Auth.OIDCSuccessRedirect)
+	return c.Redirect(http.StatusFound, flow.ReturnTo)
 }
 
 // HandleLogout godoc
 // @Summary Logout user
-// @Description Clears authentication cookies
+// @Description Revokes the current session of the refresh token cookie or the access token with its refresh token family
+// @Description and clears authentication cookies.
+// @Description Users logged in with OIDC are redirected to the end session endpoint of the identity provider.
 // @ID handle-logout
 // @Tags Authentication
 // @Success 200
+// @Success 303
 // @Router /logout [post]
 func (h *AuthHandler) HandleLogout(c echo.Context) error {
-	// Clear cookies
-	c.SetCookie(&http.Cookie{
-		Name:     "access_token",
-		Value:    "",
-		Path:     "/",
-		MaxAge:   -1,
-		HttpOnly: true,
-	})
+	if cookie, err := c.Cookie(refreshTokenCookie); err == nil && cookie.Value != "" {
+		if err := h.sessions.Logout(c.Request().Context(), cookie.Value); err != nil && !errors.Is(err, models.ErrRefreshTokenNotFound) {
+			return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to revoke session")
+		}
+	}
 
-	c.SetCookie(&http.Cookie{
-		Name:     "auth_state",
-		Value:    "",
-		Path:     "/",
-		MaxAge:   -1,
-		HttpOnly: true,
-	})
+	// Clients without the refresh token cookie log out with the access token
+	if err := h.revokeAccessTokenSession(c); err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to revoke session")
+	}
+
+	var idTokenHint, provider string
+	if cookie, err := c.Cookie(idTokenCookie); err == nil {
+		idTokenHint = cookie.Value
+	}
+	if cookie, err := c.Cookie(providerCookie); err == nil {
+		provider = cookie.Value
+	}
 
-	// You might also want to hit Authentik's logout endpoint
-	// logoutURL := h.oidcProvider.Endpoint().LogoutURL
-	// return c.Redirect(http.StatusFound, logoutURL)
+	clearAuthCookies(c)
+
+	clearCookie(c, loginFlowCookie)
+	clearCookie(c, idTokenCookie)
+	clearCookie(c, providerCookie)
+
+	if idTokenHint == "" {
+		return responses.MessageResponse(c, http.StatusOK, "Successfully logged out")
+	}
+
+	// RP-initiated logout ends the session at the identity provider too, when it's reachable
+	discovery, err := h.discoverProvider(c.Request().Context(), provider)
+	if err != nil {
+		slog.WarnContext(c.Request().Context(), "Skipping RP-initiated logout", "provider", provider, "err", err)
+	} else if discovery.EndSessionEndpoint != "" {
+		logoutURL, err := endSessionURL(discovery, idTokenHint, h.server.Config.Auth.OIDCPostLogoutRedirect)
+		if err != nil {
+			return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to build logout URL")
+		}
+
+		return c.Redirect(http.StatusSeeOther, logoutURL)
+	}
 
-	return c.JSON(http.StatusOK, responses.MessageResponse(c, 200, "Successfully logged out"))
+	return responses.MessageResponse(c, http.StatusOK, "Successfully logged out")
 }
 
-func (h *AuthHandler) createAccessToken(user models.User) (string, error) {
-	// In a real implementation, you might want to use JWT or keep using OIDC tokens
-	// This is a simplified version - consider proper token generation
-	return "generated-access-token", nil
+// revokeAccessTokenSession revokes the session of the access token from the Authorization header or the access_token
+// cookie. Requests without a valid access token have no session to revoke.
+func (h *AuthHandler) revokeAccessTokenSession(c echo.Context) error {
+	raw, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+	if !ok {
+		cookie, err := c.Cookie(accessTokenCookie)
+		if err != nil || cookie.Value == "" {
+			return nil
+		}
+
+		raw = cookie.Value
+	}
+
+	claims, err := h.accessTokens.ParseAccessToken(raw)
+	if err != nil {
+		return nil
+	}
+
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return nil
+	}
+
+	err = h.sessions.Revoke(c.Request().Context(), claims.UserID, sessionID)
+	if err != nil && !errors.Is(err, models.ErrSessionNotFound) {
+		return fmt.Errorf("revoke access token session: %w", err)
+	}
+
+	return nil
 }
 
-func generateRandomState() (string, error) {
-	b := make([]byte, 32)
-	_, err := rand.Read(b)
+// BackChannelLogout godoc
+// @Summary OIDC back-channel logout
+// @Description Revokes the sessions ended at the identity provider, called by the identity provider with a logout token
+// @Tags Authentication
+// @Accept x-www-form-urlencoded
+// @Param provider path string true "Name of the identity provider, the route without it uses the default provider"
+// @Param logout_token formData string true "Logout token"
+// @Success 200
+// @Failure 400 {object} responses.Error
+// @Failure 404 {object} responses.Error
+// @Failure 500 {object} responses.Error
+// @Failure 503 {object} responses.Error
+// @Router /backchannel-logout [post]
+// @Router /backchannel-logout/{provider} [post]
+func (h *AuthHandler) BackChannelLogout(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	provider, discovery, err := h.discover(c)
 	if err != nil {
-		return "", err
+		return discoveryErrorResponse(c, err)
+	}
+
+	logoutToken, err := discovery.Verifier.Verify(c.Request().Context(), c.FormValue("logout_token"))
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to verify logout token: "+err.Error())
+	}
+
+	var claims models.LogoutTokenClaims
+	if err := logoutToken.Claims(&claims); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to parse logout token claims: "+err.Error())
+	}
+
+	if err := h.logouts.BackChannelLogout(c.Request().Context(), provider, claims); errors.Is(err, logout.ErrInvalidLogoutToken) {
+		return responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	} else if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to revoke sessions")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// discover returns the discovered identity provider of the provider path parameter, the default provider without one.
+func (h *AuthHandler) discover(c echo.Context) (string, *oidcx.Discovery, error) {
+	name := c.Param("provider")
+	if name == "" {
+		name = h.defaultProvider
 	}
-	return base64.URLEncoding.EncodeToString(b), nil
+
+	discovery, err := h.discoverProvider(c.Request().Context(), name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return name, discovery, nil
+}
+
+func (h *AuthHandler) discoverProvider(ctx context.Context, name string) (*oidcx.Discovery, error) {
+	provider, ok := h.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownProvider, name)
+	}
+
+	return provider.Discover(ctx)
+}
+
+func discoveryErrorResponse(c echo.Context, err error) error {
+	if errors.Is(err, errUnknownProvider) {
+		return responses.ErrorResponse(c, http.StatusNotFound, "Unknown identity provider")
+	}
+
+	slog.ErrorContext(c.Request().Context(), "OIDC discovery failed", "err", err)
+
+	return responses.ErrorResponse(c, http.StatusServiceUnavailable, "Identity provider unavailable")
+}
+
+// endSessionURL returns the end session endpoint of the identity provider for the logout of the ID token.
+func endSessionURL(discovery *oidcx.Discovery, idTokenHint, postLogoutRedirect string) (string, error) {
+	endSessionURL, err := url.Parse(discovery.EndSessionEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("parse end session endpoint: %w", err)
+	}
+
+	query := endSessionURL.Query()
+	query.Set("id_token_hint", idTokenHint)
+	query.Set("client_id", discovery.OAuth2.ClientID)
+	if postLogoutRedirect != "" {
+		query.Set("post_logout_redirect_uri", postLogoutRedirect)
+	}
+
+	endSessionURL.RawQuery = query.Encode()
+
+	return endSessionURL.String(), nil
+}
+
+// stringsClaim returns the strings of a claim, identity providers emit either a list or a single string.
+func stringsClaim(claim any) []string {
+	switch value := claim.(type) {
+	case string:
+		return []string{value}
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if item, ok := item.(string); ok {
+				values = append(values, item)
+			}
+		}
+
+		return values
+	}
+
+	return nil
 }
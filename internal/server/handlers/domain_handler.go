@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"echo-app/internal/models"
+	"echo-app/internal/requests"
+	"echo-app/internal/responses"
+	"echo-app/internal/server/middleware"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=domain_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type domainService interface {
+	Create(ctx context.Context, domain *models.Domain, creatorID uint) error
+	GetDomains(ctx context.Context, userID uint) ([]models.Domain, error)
+	GetDomain(ctx context.Context, id uuid.UUID) (models.Domain, error)
+	Rename(ctx context.Context, domain *models.Domain, name string) error
+	Delete(ctx context.Context, domain *models.Domain) error
+}
+
+type DomainHandlers struct {
+	domainService domainService
+}
+
+func NewDomainHandlers(domainService domainService) DomainHandlers {
+	return DomainHandlers{domainService: domainService}
+}
+
+// CreateDomain godoc
+//
+//	@Summary		Create domain
+//	@Description	Create domain, the current user becomes its admin
+//	@ID				domains-create
+//	@Tags			Domains Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.CreateDomainRequest	true	"Domain name"
+//	@Success		201		{object}	responses.DomainResponse
+//	@Failure		400		{object}	responses.Error
+//	@Failure		401		{object}	responses.Error
+//	@Failure		409		{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/domains [post]
+func (d *DomainHandlers) CreateDomain(c echo.Context) error {
+	user, err := middleware.CurrentUser(c)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	var createDomainRequest requests.CreateDomainRequest
+	if err := c.Bind(&createDomainRequest); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to bind request: "+err.Error())
+	}
+
+	if err := createDomainRequest.Validate(); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Required fields are empty or not valid")
+	}
+
+	domain := &models.Domain{Name: createDomainRequest.Name}
+
+	err = d.domainService.Create(c.Request().Context(), domain, user.ID)
+	if errors.Is(err, models.ErrDomainNameTaken) {
+		return responses.ErrorResponse(c, http.StatusConflict, "Domain name is already taken")
+	} else if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to create domain")
+	}
+
+	return responses.Response(c, http.StatusCreated, responses.NewDomainResponse(*domain))
+}
+
+// GetDomains godoc
+//
+//	@Summary		Get domains
+//	@Description	Get the list of the domains the current user can view
+//	@ID				domains-get
+//	@Tags			Domains Actions
+//	@Produce		json
+//	@Success		200	{array}		responses.DomainResponse
+//	@Failure		401	{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/domains [get]
+func (d *DomainHandlers) GetDomains(c echo.Context) error {
+	user, err := middleware.CurrentUser(c)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	domains, err := d.domainService.GetDomains(c.Request().Context(), user.ID)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to get domains")
+	}
+
+	return responses.Response(c, http.StatusOK, responses.NewDomainsResponse(domains))
+}
+
+// GetDomain godoc
+//
+//	@Summary		Get domain
+//	@Description	Get domain
+//	@ID				domains-get-one
+//	@Tags			Domains Actions
+//	@Produce		json
+//	@Param			id	path		string	true	"Domain ID"
+//	@Success		200	{object}	responses.DomainResponse
+//	@Failure		401	{object}	responses.Error
+//	@Failure		403	{object}	responses.Error
+//	@Failure		404	{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/domains/{id} [get]
+func (d *DomainHandlers) GetDomain(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to parse domain id: "+err.Error())
+	}
+
+	domain, err := d.domainService.GetDomain(c.Request().Context(), id)
+	if errors.Is(err, models.ErrDomainNotFound) {
+		return responses.ErrorResponse(c, http.StatusNotFound, "Domain not found")
+	} else if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to get domain")
+	}
+
+	return responses.Response(c, http.StatusOK, responses.NewDomainResponse(domain))
+}
+
+// RenameDomain godoc
+//
+//	@Summary		Rename domain
+//	@Description	Rename domain
+//	@ID				domains-rename
+//	@Tags			Domains Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Domain ID"
+//	@Param			params	body		requests.RenameDomainRequest	true	"Domain name"
+//	@Success		200		{object}	responses.DomainResponse
+//	@Failure		400		{object}	responses.Error
+//	@Failure		401		{object}	responses.Error
+//	@Failure		403		{object}	responses.Error
+//	@Failure		404		{object}	responses.Error
+//	@Failure		409		{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/domains/{id} [put]
+func (d *DomainHandlers) RenameDomain(c echo.Context) error {
+	var renameDomainRequest requests.RenameDomainRequest
+	if err := c.Bind(&renameDomainRequest); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to bind request: "+err.Error())
+	}
+
+	if err := renameDomainRequest.Validate(); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Required fields are empty or not valid")
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to parse domain id: "+err.Error())
+	}
+
+	domain, err := d.domainService.GetDomain(c.Request().Context(), id)
+	if errors.Is(err, models.ErrDomainNotFound) {
+		return responses.ErrorResponse(c, http.StatusNotFound, "Domain not found")
+	} else if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to get domain")
+	}
+
+	err = d.domainService.Rename(c.Request().Context(), &domain, renameDomainRequest.Name)
+	if errors.Is(err, models.ErrDomainNameTaken) {
+		return responses.ErrorResponse(c, http.StatusConflict, "Domain name is already taken")
+	} else if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to rename domain")
+	}
+
+	return responses.Response(c, http.StatusOK, responses.NewDomainResponse(domain))
+}
+
+// DeleteDomain godoc
+//
+//	@Summary		Delete domain
+//	@Description	Delete domain, its users are detached from it
+//	@ID				domains-delete
+//	@Tags			Domains Actions
+//	@Param			id	path	string	true	"Domain ID"
+//	@Success		204
+//	@Failure		401	{object}	responses.Error
+//	@Failure		403	{object}	responses.Error
+//	@Failure		404	{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/domains/{id} [delete]
+func (d *DomainHandlers) DeleteDomain(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to parse domain id: "+err.Error())
+	}
+
+	domain, err := d.domainService.GetDomain(c.Request().Context(), id)
+	if errors.Is(err, models.ErrDomainNotFound) {
+		return responses.ErrorResponse(c, http.StatusNotFound, "Domain not found")
+	} else if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to get domain")
+	}
+
+	if err := d.domainService.Delete(c.Request().Context(), &domain); err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete domain")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
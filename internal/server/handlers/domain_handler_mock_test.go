@@ -0,0 +1,235 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain_handler.go -destination=domain_handler_mock_test.go -package=handlers_test -typed=true
+//
+
+// Package handlers_test is a generated GoMock package.
+package handlers_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "echo-app/internal/models"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockdomainService is a mock of domainService interface.
+type MockdomainService struct {
+	ctrl     *gomock.Controller
+	recorder *MockdomainServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockdomainServiceMockRecorder is the mock recorder for MockdomainService.
+type MockdomainServiceMockRecorder struct {
+	mock *MockdomainService
+}
+
+// NewMockdomainService creates a new mock instance.
+func NewMockdomainService(ctrl *gomock.Controller) *MockdomainService {
+	mock := &MockdomainService{ctrl: ctrl}
+	mock.recorder = &MockdomainServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdomainService) EXPECT() *MockdomainServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockdomainService) Create(ctx context.Context, domain *models.Domain, creatorID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, domain, creatorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockdomainServiceMockRecorder) Create(ctx, domain, creatorID any) *MockdomainServiceCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockdomainService)(nil).Create), ctx, domain, creatorID)
+	return &MockdomainServiceCreateCall{Call: call}
+}
+
+// MockdomainServiceCreateCall wrap *gomock.Call
+type MockdomainServiceCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockdomainServiceCreateCall) Return(arg0 error) *MockdomainServiceCreateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockdomainServiceCreateCall) Do(f func(context.Context, *models.Domain, uint) error) *MockdomainServiceCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockdomainServiceCreateCall) DoAndReturn(f func(context.Context, *models.Domain, uint) error) *MockdomainServiceCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Delete mocks base method.
+func (m *MockdomainService) Delete(ctx context.Context, domain *models.Domain) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, domain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockdomainServiceMockRecorder) Delete(ctx, domain any) *MockdomainServiceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockdomainService)(nil).Delete), ctx, domain)
+	return &MockdomainServiceDeleteCall{Call: call}
+}
+
+// MockdomainServiceDeleteCall wrap *gomock.Call
+type MockdomainServiceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockdomainServiceDeleteCall) Return(arg0 error) *MockdomainServiceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockdomainServiceDeleteCall) Do(f func(context.Context, *models.Domain) error) *MockdomainServiceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockdomainServiceDeleteCall) DoAndReturn(f func(context.Context, *models.Domain) error) *MockdomainServiceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetDomain mocks base method.
+func (m *MockdomainService) GetDomain(ctx context.Context, id uuid.UUID) (models.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDomain", ctx, id)
+	ret0, _ := ret[0].(models.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDomain indicates an expected call of GetDomain.
+func (mr *MockdomainServiceMockRecorder) GetDomain(ctx, id any) *MockdomainServiceGetDomainCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDomain", reflect.TypeOf((*MockdomainService)(nil).GetDomain), ctx, id)
+	return &MockdomainServiceGetDomainCall{Call: call}
+}
+
+// MockdomainServiceGetDomainCall wrap *gomock.Call
+type MockdomainServiceGetDomainCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockdomainServiceGetDomainCall) Return(arg0 models.Domain, arg1 error) *MockdomainServiceGetDomainCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockdomainServiceGetDomainCall) Do(f func(context.Context, uuid.UUID) (models.Domain, error)) *MockdomainServiceGetDomainCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockdomainServiceGetDomainCall) DoAndReturn(f func(context.Context, uuid.UUID) (models.Domain, error)) *MockdomainServiceGetDomainCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetDomains mocks base method.
+func (m *MockdomainService) GetDomains(ctx context.Context, userID uint) ([]models.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDomains", ctx, userID)
+	ret0, _ := ret[0].([]models.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDomains indicates an expected call of GetDomains.
+func (mr *MockdomainServiceMockRecorder) GetDomains(ctx, userID any) *MockdomainServiceGetDomainsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDomains", reflect.TypeOf((*MockdomainService)(nil).GetDomains), ctx, userID)
+	return &MockdomainServiceGetDomainsCall{Call: call}
+}
+
+// MockdomainServiceGetDomainsCall wrap *gomock.Call
+type MockdomainServiceGetDomainsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockdomainServiceGetDomainsCall) Return(arg0 []models.Domain, arg1 error) *MockdomainServiceGetDomainsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockdomainServiceGetDomainsCall) Do(f func(context.Context, uint) ([]models.Domain, error)) *MockdomainServiceGetDomainsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockdomainServiceGetDomainsCall) DoAndReturn(f func(context.Context, uint) ([]models.Domain, error)) *MockdomainServiceGetDomainsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Rename mocks base method.
+func (m *MockdomainService) Rename(ctx context.Context, domain *models.Domain, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", ctx, domain, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockdomainServiceMockRecorder) Rename(ctx, domain, name any) *MockdomainServiceRenameCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockdomainService)(nil).Rename), ctx, domain, name)
+	return &MockdomainServiceRenameCall{Call: call}
+}
+
+// MockdomainServiceRenameCall wrap *gomock.Call
+type MockdomainServiceRenameCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockdomainServiceRenameCall) Return(arg0 error) *MockdomainServiceRenameCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockdomainServiceRenameCall) Do(f func(context.Context, *models.Domain, string) error) *MockdomainServiceRenameCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockdomainServiceRenameCall) DoAndReturn(f func(context.Context, *models.Domain, string) error) *MockdomainServiceRenameCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,137 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"echo-app/internal/models"
+	"echo-app/internal/requests"
+	"echo-app/internal/server/handlers"
+	"echo-app/internal/server/middleware"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func newDomainHandlers(t *testing.T) (*echo.Echo, handlers.DomainHandlers, *MockdomainService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	domainService := NewMockdomainService(ctrl)
+	domainHandlers := handlers.NewDomainHandlers(domainService)
+	engine := echo.New()
+
+	return engine, domainHandlers, domainService
+}
+
+func newDomainRequest(t *testing.T, method, target, name string) *http.Request {
+	t.Helper()
+
+	buffer := new(bytes.Buffer)
+	err := json.NewEncoder(buffer).Encode(requests.CreateDomainRequest{
+		BasicDomain: requests.BasicDomain{Name: name},
+	})
+	require.NoError(t, err)
+
+	request := httptest.NewRequestWithContext(t.Context(), method, target, buffer)
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	return request
+}
+
+func TestDomainHandlers_CreateDomain(t *testing.T) {
+	t.Run("It should create a domain administered by the current user", func(t *testing.T) {
+		engine, domainHandlers, domainService := newDomainHandlers(t)
+
+		domainService.
+			EXPECT().
+			Create(gomock.Any(), &models.Domain{Name: "Acme"}, uint(7)).
+			Return(nil)
+
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(newDomainRequest(t, http.MethodPost, "/domains", "Acme"), recorder)
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+
+		err := domainHandlers.CreateDomain(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, recorder.Result().StatusCode)
+	})
+
+	t.Run("It should reject a taken name", func(t *testing.T) {
+		engine, domainHandlers, domainService := newDomainHandlers(t)
+
+		domainService.
+			EXPECT().
+			Create(gomock.Any(), gomock.Any(), uint(7)).
+			Return(models.ErrDomainNameTaken)
+
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(newDomainRequest(t, http.MethodPost, "/domains", "Acme"), recorder)
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+
+		err := domainHandlers.CreateDomain(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusConflict, recorder.Result().StatusCode)
+	})
+}
+
+func TestDomainHandlers_GetDomain(t *testing.T) {
+	domainID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+
+	t.Run("It should return not found for an unknown domain", func(t *testing.T) {
+		engine, domainHandlers, domainService := newDomainHandlers(t)
+
+		domainService.
+			EXPECT().
+			GetDomain(gomock.Any(), domainID).
+			Return(models.Domain{}, models.ErrDomainNotFound)
+
+		request := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/domains/"+domainID.String(), http.NoBody)
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(request, recorder)
+		c.SetParamNames("id")
+		c.SetParamValues(domainID.String())
+
+		err := domainHandlers.GetDomain(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Result().StatusCode)
+	})
+}
+
+func TestDomainHandlers_RenameDomain(t *testing.T) {
+	domainID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+
+	engine, domainHandlers, domainService := newDomainHandlers(t)
+
+	domain := models.Domain{ID: domainID, Name: "Acme"}
+
+	domainService.
+		EXPECT().
+		GetDomain(gomock.Any(), domainID).
+		Return(domain, nil)
+
+	domainService.
+		EXPECT().
+		Rename(gomock.Any(), &domain, "Acme Corp").
+		Return(nil)
+
+	recorder := httptest.NewRecorder()
+	c := engine.NewContext(newDomainRequest(t, http.MethodPut, "/domains/"+domainID.String(), "Acme Corp"), recorder)
+	c.SetParamNames("id")
+	c.SetParamValues(domainID.String())
+
+	err := domainHandlers.RenameDomain(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"echo-app/internal/models"
+	"echo-app/internal/requests"
+	"echo-app/internal/responses"
+	"echo-app/internal/services/mfa"
+	"echo-app/internal/services/token"
+	userservice "echo-app/internal/services/user"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=login_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type userAuthenticator interface {
+	Authenticate(ctx context.Context, email, password string) (models.User, error)
+}
+
+type mfaTokenIssuer interface {
+	CreateMFAToken(user *models.User) (string, time.Time, error)
+	ParseMFAToken(raw string) (*token.MFAClaims, error)
+}
+
+type mfaVerifier interface {
+	Verify(ctx context.Context, userID uint, code string) (models.User, error)
+}
+
+type LoginHandler struct {
+	userAuthenticator userAuthenticator
+	accessTokens      accessTokenCreator
+	sessions          sessionStarter
+	mfaTokens         mfaTokenIssuer
+	mfaVerifier       mfaVerifier
+}
+
+func NewLoginHandler(
+	userAuthenticator userAuthenticator,
+	accessTokens accessTokenCreator,
+	sessions sessionStarter,
+	mfaTokens mfaTokenIssuer,
+	mfaVerifier mfaVerifier,
+) *LoginHandler {
+	return &LoginHandler{
+		userAuthenticator: userAuthenticator,
+		accessTokens:      accessTokens,
+		sessions:          sessions,
+		mfaTokens:         mfaTokens,
+		mfaVerifier:       mfaVerifier,
+	}
+}
+
+// Login godoc
+//
+//	@Summary		Password login
+//	@Description	Authenticates a user with email and password, for accounts that don't use OIDC.
+//	@Description	Users with two-factor authentication get an MFA token instead, /login/mfa exchanges it with a code for the tokens.
+//	@ID				user-login
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.LoginRequest	true	"User's email, user's password"
+//	@Success		200		{object}	responses.LoginResponse
+//	@Success		202		{object}	responses.MFAChallengeResponse
+//	@Failure		400		{object}	responses.Error
+//	@Failure		401		{object}	responses.Error
+//	@Failure		403		{object}	responses.Error
+//	@Router			/login [post]
+func (h *LoginHandler) Login(c echo.Context) error {
+	loginRequest := new(requests.LoginRequest)
+	if err := c.Bind(loginRequest); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to bind request")
+	}
+
+	if err := loginRequest.Validate(); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Required fields are empty or invalid")
+	}
+
+	user, err := h.userAuthenticator.Authenticate(c.Request().Context(), loginRequest.Email, loginRequest.Password)
+	if errors.Is(err, userservice.ErrInvalidCredentials) {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Invalid credentials")
+	} else if errors.Is(err, userservice.ErrEmailNotVerified) {
+		return responses.ErrorResponse(c, http.StatusForbidden, "Email is not verified")
+	} else if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to authenticate user")
+	}
+
+	if user.MFAEnabled() {
+		mfaToken, expiresAt, err := h.mfaTokens.CreateMFAToken(&user)
+		if err != nil {
+			return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to create MFA token")
+		}
+
+		return responses.Response(c, http.StatusAccepted, responses.NewMFAChallengeResponse(mfaToken, expiresAt.Unix()))
+	}
+
+	return h.login(c, &user)
+}
+
+// LoginMFA godoc
+//
+//	@Summary		Second step of the password login
+//	@Description	Exchanges the MFA token of the password login and a TOTP or recovery code for the tokens.
+//	@Description	Too many invalid codes lock the second step for a while.
+//	@ID				user-login-mfa
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.MFALoginRequest	true	"MFA token of the login, TOTP or recovery code"
+//	@Success		200		{object}	responses.LoginResponse
+//	@Failure		400		{object}	responses.Error
+//	@Failure		401		{object}	responses.Error
+//	@Failure		429		{object}	responses.Error
+//	@Router			/login/mfa [post]
+func (h *LoginHandler) LoginMFA(c echo.Context) error {
+	mfaLoginRequest := new(requests.MFALoginRequest)
+	if err := c.Bind(mfaLoginRequest); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to bind request")
+	}
+
+	if err := mfaLoginRequest.Validate(); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Required fields are empty or invalid")
+	}
+
+	claims, err := h.mfaTokens.ParseMFAToken(mfaLoginRequest.MFAToken)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Invalid or expired MFA token")
+	}
+
+	user, err := h.mfaVerifier.Verify(c.Request().Context(), claims.UserID, mfaLoginRequest.Code)
+	if errors.Is(err, mfa.ErrInvalidCode) {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Invalid code")
+	} else if errors.Is(err, mfa.ErrTooManyAttempts) {
+		return responses.ErrorResponse(c, http.StatusTooManyRequests, "Too many invalid codes, try again later")
+	} else if errors.Is(err, mfa.ErrMFANotEnrolled) {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Invalid or expired MFA token")
+	} else if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to verify code")
+	}
+
+	return h.login(c, &user)
+}
+
+// login starts the session of the authenticated user and responds with its tokens.
+func (h *LoginHandler) login(c echo.Context, user *models.User) error {
+	response, refreshToken, err := issueTokens(c, h.accessTokens, h.sessions, user, "", "")
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to create session tokens")
+	}
+
+	setAuthCookies(c, response, refreshToken)
+
+	return responses.Response(c, http.StatusOK, response)
+}
@@ -0,0 +1,249 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: login_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=login_handler.go -destination=login_handler_mock_test.go -package=handlers_test -typed=true
+//
+
+// Package handlers_test is a generated GoMock package.
+package handlers_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "echo-app/internal/models"
+	token "echo-app/internal/services/token"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockuserAuthenticator is a mock of userAuthenticator interface.
+type MockuserAuthenticator struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserAuthenticatorMockRecorder
+	isgomock struct{}
+}
+
+// MockuserAuthenticatorMockRecorder is the mock recorder for MockuserAuthenticator.
+type MockuserAuthenticatorMockRecorder struct {
+	mock *MockuserAuthenticator
+}
+
+// NewMockuserAuthenticator creates a new mock instance.
+func NewMockuserAuthenticator(ctrl *gomock.Controller) *MockuserAuthenticator {
+	mock := &MockuserAuthenticator{ctrl: ctrl}
+	mock.recorder = &MockuserAuthenticatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserAuthenticator) EXPECT() *MockuserAuthenticatorMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockuserAuthenticator) Authenticate(ctx context.Context, email, password string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, email, password)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockuserAuthenticatorMockRecorder) Authenticate(ctx, email, password any) *MockuserAuthenticatorAuthenticateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockuserAuthenticator)(nil).Authenticate), ctx, email, password)
+	return &MockuserAuthenticatorAuthenticateCall{Call: call}
+}
+
+// MockuserAuthenticatorAuthenticateCall wrap *gomock.Call
+type MockuserAuthenticatorAuthenticateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserAuthenticatorAuthenticateCall) Return(arg0 models.User, arg1 error) *MockuserAuthenticatorAuthenticateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserAuthenticatorAuthenticateCall) Do(f func(context.Context, string, string) (models.User, error)) *MockuserAuthenticatorAuthenticateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserAuthenticatorAuthenticateCall) DoAndReturn(f func(context.Context, string, string) (models.User, error)) *MockuserAuthenticatorAuthenticateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockmfaTokenIssuer is a mock of mfaTokenIssuer interface.
+type MockmfaTokenIssuer struct {
+	ctrl     *gomock.Controller
+	recorder *MockmfaTokenIssuerMockRecorder
+	isgomock struct{}
+}
+
+// MockmfaTokenIssuerMockRecorder is the mock recorder for MockmfaTokenIssuer.
+type MockmfaTokenIssuerMockRecorder struct {
+	mock *MockmfaTokenIssuer
+}
+
+// NewMockmfaTokenIssuer creates a new mock instance.
+func NewMockmfaTokenIssuer(ctrl *gomock.Controller) *MockmfaTokenIssuer {
+	mock := &MockmfaTokenIssuer{ctrl: ctrl}
+	mock.recorder = &MockmfaTokenIssuerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmfaTokenIssuer) EXPECT() *MockmfaTokenIssuerMockRecorder {
+	return m.recorder
+}
+
+// CreateMFAToken mocks base method.
+func (m *MockmfaTokenIssuer) CreateMFAToken(user *models.User) (string, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMFAToken", user)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateMFAToken indicates an expected call of CreateMFAToken.
+func (mr *MockmfaTokenIssuerMockRecorder) CreateMFAToken(user any) *MockmfaTokenIssuerCreateMFATokenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMFAToken", reflect.TypeOf((*MockmfaTokenIssuer)(nil).CreateMFAToken), user)
+	return &MockmfaTokenIssuerCreateMFATokenCall{Call: call}
+}
+
+// MockmfaTokenIssuerCreateMFATokenCall wrap *gomock.Call
+type MockmfaTokenIssuerCreateMFATokenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmfaTokenIssuerCreateMFATokenCall) Return(arg0 string, arg1 time.Time, arg2 error) *MockmfaTokenIssuerCreateMFATokenCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmfaTokenIssuerCreateMFATokenCall) Do(f func(*models.User) (string, time.Time, error)) *MockmfaTokenIssuerCreateMFATokenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmfaTokenIssuerCreateMFATokenCall) DoAndReturn(f func(*models.User) (string, time.Time, error)) *MockmfaTokenIssuerCreateMFATokenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ParseMFAToken mocks base method.
+func (m *MockmfaTokenIssuer) ParseMFAToken(raw string) (*token.MFAClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseMFAToken", raw)
+	ret0, _ := ret[0].(*token.MFAClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseMFAToken indicates an expected call of ParseMFAToken.
+func (mr *MockmfaTokenIssuerMockRecorder) ParseMFAToken(raw any) *MockmfaTokenIssuerParseMFATokenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseMFAToken", reflect.TypeOf((*MockmfaTokenIssuer)(nil).ParseMFAToken), raw)
+	return &MockmfaTokenIssuerParseMFATokenCall{Call: call}
+}
+
+// MockmfaTokenIssuerParseMFATokenCall wrap *gomock.Call
+type MockmfaTokenIssuerParseMFATokenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmfaTokenIssuerParseMFATokenCall) Return(arg0 *token.MFAClaims, arg1 error) *MockmfaTokenIssuerParseMFATokenCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmfaTokenIssuerParseMFATokenCall) Do(f func(string) (*token.MFAClaims, error)) *MockmfaTokenIssuerParseMFATokenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmfaTokenIssuerParseMFATokenCall) DoAndReturn(f func(string) (*token.MFAClaims, error)) *MockmfaTokenIssuerParseMFATokenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockmfaVerifier is a mock of mfaVerifier interface.
+type MockmfaVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockmfaVerifierMockRecorder
+	isgomock struct{}
+}
+
+// MockmfaVerifierMockRecorder is the mock recorder for MockmfaVerifier.
+type MockmfaVerifierMockRecorder struct {
+	mock *MockmfaVerifier
+}
+
+// NewMockmfaVerifier creates a new mock instance.
+func NewMockmfaVerifier(ctrl *gomock.Controller) *MockmfaVerifier {
+	mock := &MockmfaVerifier{ctrl: ctrl}
+	mock.recorder = &MockmfaVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmfaVerifier) EXPECT() *MockmfaVerifierMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MockmfaVerifier) Verify(ctx context.Context, userID uint, code string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, userID, code)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockmfaVerifierMockRecorder) Verify(ctx, userID, code any) *MockmfaVerifierVerifyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockmfaVerifier)(nil).Verify), ctx, userID, code)
+	return &MockmfaVerifierVerifyCall{Call: call}
+}
+
+// MockmfaVerifierVerifyCall wrap *gomock.Call
+type MockmfaVerifierVerifyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmfaVerifierVerifyCall) Return(arg0 models.User, arg1 error) *MockmfaVerifierVerifyCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmfaVerifierVerifyCall) Do(f func(context.Context, uint, string) (models.User, error)) *MockmfaVerifierVerifyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmfaVerifierVerifyCall) DoAndReturn(f func(context.Context, uint, string) (models.User, error)) *MockmfaVerifierVerifyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,325 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"echo-app/internal/models"
+	"echo-app/internal/requests"
+	"echo-app/internal/server/handlers"
+	"echo-app/internal/services/mfa"
+	"echo-app/internal/services/refresh"
+	"echo-app/internal/services/token"
+	"echo-app/internal/services/user"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type loginHandlerMocks struct {
+	userAuthenticator *MockuserAuthenticator
+	accessTokens      *MockaccessTokenCreator
+	sessions          *MocksessionStarter
+	mfaTokens         *MockmfaTokenIssuer
+	mfaVerifier       *MockmfaVerifier
+}
+
+func newLoginHandler(t *testing.T) (*echo.Echo, *handlers.LoginHandler, loginHandlerMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mocks := loginHandlerMocks{
+		userAuthenticator: NewMockuserAuthenticator(ctrl),
+		accessTokens:      NewMockaccessTokenCreator(ctrl),
+		sessions:          NewMocksessionStarter(ctrl),
+		mfaTokens:         NewMockmfaTokenIssuer(ctrl),
+		mfaVerifier:       NewMockmfaVerifier(ctrl),
+	}
+
+	loginHandler := handlers.NewLoginHandler(
+		mocks.userAuthenticator,
+		mocks.accessTokens,
+		mocks.sessions,
+		mocks.mfaTokens,
+		mocks.mfaVerifier,
+	)
+	engine := echo.New()
+
+	engine.POST("/login", loginHandler.Login)
+	engine.POST("/login/mfa", loginHandler.LoginMFA)
+
+	return engine, loginHandler, mocks
+}
+
+func newLoginRequest(t *testing.T, email, password string) *http.Request {
+	t.Helper()
+
+	loginRequest := requests.LoginRequest{
+		BasicAuth: requests.BasicAuth{
+			Email:    email,
+			Password: password,
+		},
+	}
+
+	buffer := new(bytes.Buffer)
+	err := json.NewEncoder(buffer).Encode(loginRequest)
+	require.NoError(t, err)
+
+	request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/login", buffer)
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	return request
+}
+
+func TestLoginHandler_Login(t *testing.T) {
+	t.Run("It should return an error if credentials are empty", func(t *testing.T) {
+		engine, loginHandler, _ := newLoginHandler(t)
+
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(newLoginRequest(t, "example@email.com", ""), recorder)
+
+		err := loginHandler.Login(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+	})
+
+	t.Run("It should return an error if credentials are invalid", func(t *testing.T) {
+		engine, loginHandler, mocks := newLoginHandler(t)
+
+		mocks.userAuthenticator.
+			EXPECT().
+			Authenticate(gomock.Any(), "example@email.com", "wrong-password").
+			Return(models.User{}, user.ErrInvalidCredentials)
+
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(newLoginRequest(t, "example@email.com", "wrong-password"), recorder)
+
+		err := loginHandler.Login(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Result().StatusCode)
+
+		wantResponse := `{
+			"code": 401,
+			"error": "Invalid credentials"
+		}`
+
+		assert.JSONEq(t, wantResponse, recorder.Body.String())
+	})
+
+	t.Run("It should issue tokens for valid credentials", func(t *testing.T) {
+		engine, loginHandler, mocks := newLoginHandler(t)
+
+		authenticatedUser := models.User{Model: gorm.Model{ID: 5}, Email: "example@email.com"}
+		expiresAt := time.Unix(1700000000, 0)
+
+		mocks.userAuthenticator.
+			EXPECT().
+			Authenticate(gomock.Any(), "example@email.com", "some-password").
+			Return(authenticatedUser, nil)
+
+		sessionID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+
+		mocks.sessions.
+			EXPECT().
+			Start(gomock.Any(), uint(5), gomock.Any(), "", "").
+			Return(models.Session{ID: sessionID}, refresh.Token{Value: "refresh-token", ExpiresAt: expiresAt}, nil)
+
+		mocks.accessTokens.
+			EXPECT().
+			CreateAccessToken(&authenticatedUser, sessionID).
+			Return("access-token", expiresAt, nil)
+
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(newLoginRequest(t, "example@email.com", "some-password"), recorder)
+
+		err := loginHandler.Login(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+		wantResponse := `{
+			"accessToken": "access-token",
+			"refreshToken": "refresh-token",
+			"exp": 1700000000
+		}`
+
+		assert.JSONEq(t, wantResponse, recorder.Body.String())
+		assert.Len(t, recorder.Result().Cookies(), 2)
+	})
+
+	t.Run("It should ask for the second factor if the user has MFA enabled", func(t *testing.T) {
+		engine, loginHandler, mocks := newLoginHandler(t)
+
+		enabledAt := time.Unix(1600000000, 0)
+		authenticatedUser := models.User{
+			Model:         gorm.Model{ID: 5},
+			Email:         "example@email.com",
+			Password:      "hash",
+			TOTPEnabledAt: &enabledAt,
+		}
+
+		mocks.userAuthenticator.
+			EXPECT().
+			Authenticate(gomock.Any(), "example@email.com", "some-password").
+			Return(authenticatedUser, nil)
+
+		mocks.mfaTokens.
+			EXPECT().
+			CreateMFAToken(&authenticatedUser).
+			Return("mfa-token", time.Unix(1700000000, 0), nil)
+
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(newLoginRequest(t, "example@email.com", "some-password"), recorder)
+
+		err := loginHandler.Login(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusAccepted, recorder.Result().StatusCode)
+
+		wantResponse := `{
+			"mfaToken": "mfa-token",
+			"exp": 1700000000
+		}`
+
+		assert.JSONEq(t, wantResponse, recorder.Body.String())
+		assert.Empty(t, recorder.Result().Cookies())
+	})
+}
+
+func newMFALoginRequest(t *testing.T, mfaToken, code string) *http.Request {
+	t.Helper()
+
+	buffer := new(bytes.Buffer)
+	err := json.NewEncoder(buffer).Encode(requests.MFALoginRequest{MFAToken: mfaToken, Code: code})
+	require.NoError(t, err)
+
+	request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/login/mfa", buffer)
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	return request
+}
+
+func TestLoginHandler_LoginMFA(t *testing.T) {
+	t.Run("It should issue tokens for a valid code", func(t *testing.T) {
+		engine, loginHandler, mocks := newLoginHandler(t)
+
+		verifiedUser := models.User{Model: gorm.Model{ID: 5}, Email: "example@email.com"}
+		expiresAt := time.Unix(1700000000, 0)
+		sessionID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+
+		mocks.mfaTokens.
+			EXPECT().
+			ParseMFAToken("mfa-token").
+			Return(&token.MFAClaims{UserID: 5}, nil)
+
+		mocks.mfaVerifier.
+			EXPECT().
+			Verify(gomock.Any(), uint(5), "123456").
+			Return(verifiedUser, nil)
+
+		mocks.sessions.
+			EXPECT().
+			Start(gomock.Any(), uint(5), gomock.Any(), "", "").
+			Return(models.Session{ID: sessionID}, refresh.Token{Value: "refresh-token", ExpiresAt: expiresAt}, nil)
+
+		mocks.accessTokens.
+			EXPECT().
+			CreateAccessToken(&verifiedUser, sessionID).
+			Return("access-token", expiresAt, nil)
+
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(newMFALoginRequest(t, "mfa-token", "123456"), recorder)
+
+		err := loginHandler.LoginMFA(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+		wantResponse := `{
+			"accessToken": "access-token",
+			"refreshToken": "refresh-token",
+			"exp": 1700000000
+		}`
+
+		assert.JSONEq(t, wantResponse, recorder.Body.String())
+		assert.Len(t, recorder.Result().Cookies(), 2)
+	})
+
+	t.Run("It should return an error if the MFA token is invalid", func(t *testing.T) {
+		engine, loginHandler, mocks := newLoginHandler(t)
+
+		mocks.mfaTokens.
+			EXPECT().
+			ParseMFAToken("access-token").
+			Return(nil, token.ErrUnexpectedTokenType)
+
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(newMFALoginRequest(t, "access-token", "123456"), recorder)
+
+		err := loginHandler.LoginMFA(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Result().StatusCode)
+	})
+
+	t.Run("It should return an error if the code is invalid", func(t *testing.T) {
+		engine, loginHandler, mocks := newLoginHandler(t)
+
+		mocks.mfaTokens.
+			EXPECT().
+			ParseMFAToken("mfa-token").
+			Return(&token.MFAClaims{UserID: 5}, nil)
+
+		mocks.mfaVerifier.
+			EXPECT().
+			Verify(gomock.Any(), uint(5), "000000").
+			Return(models.User{}, mfa.ErrInvalidCode)
+
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(newMFALoginRequest(t, "mfa-token", "000000"), recorder)
+
+		err := loginHandler.LoginMFA(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Result().StatusCode)
+
+		wantResponse := `{
+			"code": 401,
+			"error": "Invalid code"
+		}`
+
+		assert.JSONEq(t, wantResponse, recorder.Body.String())
+	})
+
+	t.Run("It should return an error after too many invalid codes", func(t *testing.T) {
+		engine, loginHandler, mocks := newLoginHandler(t)
+
+		mocks.mfaTokens.
+			EXPECT().
+			ParseMFAToken("mfa-token").
+			Return(&token.MFAClaims{UserID: 5}, nil)
+
+		mocks.mfaVerifier.
+			EXPECT().
+			Verify(gomock.Any(), uint(5), "123456").
+			Return(models.User{}, mfa.ErrTooManyAttempts)
+
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(newMFALoginRequest(t, "mfa-token", "123456"), recorder)
+
+		err := loginHandler.LoginMFA(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Result().StatusCode)
+	})
+}
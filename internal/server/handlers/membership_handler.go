@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"echo-app/internal/models"
+	"echo-app/internal/requests"
+	"echo-app/internal/responses"
+	"echo-app/internal/services/membership"
+
+	safecast "github.com/ccoveille/go-safecast"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=membership_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type membershipService interface {
+	GetMembers(ctx context.Context, domainID uuid.UUID) ([]models.DomainMember, error)
+	AddMember(ctx context.Context, domainID uuid.UUID, email, role string) (models.DomainMember, error)
+	ChangeRole(ctx context.Context, domainID uuid.UUID, userID uint, role string) (models.DomainMember, error)
+	RemoveMember(ctx context.Context, domainID uuid.UUID, userID uint) error
+}
+
+type MembershipHandlers struct {
+	membershipService membershipService
+}
+
+func NewMembershipHandlers(membershipService membershipService) MembershipHandlers {
+	return MembershipHandlers{membershipService: membershipService}
+}
+
+// GetMembers godoc
+//
+//	@Summary		Get domain members
+//	@Description	Get the members of the domain with their roles, only domain admins are allowed
+//	@ID				domain-members-get
+//	@Tags			Domains Actions
+//	@Produce		json
+//	@Param			id	path		string	true	"Domain ID"
+//	@Success		200	{array}		responses.MemberResponse
+//	@Failure		400	{object}	responses.Error
+//	@Failure		401	{object}	responses.Error
+//	@Failure		403	{object}	responses.Error
+//	@Failure		404	{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/domains/{id}/members [get]
+func (m *MembershipHandlers) GetMembers(c echo.Context) error {
+	domainID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to parse domain id: "+err.Error())
+	}
+
+	members, err := m.membershipService.GetMembers(c.Request().Context(), domainID)
+	if errors.Is(err, models.ErrDomainNotFound) {
+		return responses.ErrorResponse(c, http.StatusNotFound, "Domain not found")
+	} else if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to get domain members")
+	}
+
+	return responses.Response(c, http.StatusOK, responses.NewMembersResponse(members))
+}
+
+// AddMember godoc
+//
+//	@Summary		Add domain member
+//	@Description	Invite a registered user to the domain by email, only domain admins are allowed
+//	@ID				domain-members-add
+//	@Tags			Domains Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Domain ID"
+//	@Param			params	body		requests.AddMemberRequest	true	"User email and role"
+//	@Success		201		{object}	responses.MemberResponse
+//	@Failure		400		{object}	responses.Error
+//	@Failure		401		{object}	responses.Error
+//	@Failure		403		{object}	responses.Error
+//	@Failure		404		{object}	responses.Error
+//	@Failure		409		{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/domains/{id}/members [post]
+func (m *MembershipHandlers) AddMember(c echo.Context) error {
+	domainID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to parse domain id: "+err.Error())
+	}
+
+	var addMemberRequest requests.AddMemberRequest
+	if err := c.Bind(&addMemberRequest); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to bind request: "+err.Error())
+	}
+
+	if err := addMemberRequest.Validate(); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Required fields are empty or not valid")
+	}
+
+	member, err := m.membershipService.AddMember(
+		c.Request().Context(),
+		domainID,
+		addMemberRequest.Email,
+		addMemberRequest.Role,
+	)
+	switch {
+	case errors.Is(err, models.ErrUserNotFound):
+		return responses.ErrorResponse(c, http.StatusNotFound, "User not found")
+	case errors.Is(err, models.ErrDomainNotFound):
+		return responses.ErrorResponse(c, http.StatusNotFound, "Domain not found")
+	case errors.Is(err, membership.ErrAlreadyMember):
+		return responses.ErrorResponse(c, http.StatusConflict, "User is already a domain member")
+	case err != nil:
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to add domain member")
+	}
+
+	return responses.Response(c, http.StatusCreated, responses.NewMemberResponse(member))
+}
+
+// ChangeMemberRole godoc
+//
+//	@Summary		Change domain member role
+//	@Description	Change the role of the domain member, only domain admins are allowed
+//	@ID				domain-members-change-role
+//	@Tags			Domains Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Domain ID"
+//	@Param			userId	path		int								true	"User ID"
+//	@Param			params	body		requests.ChangeMemberRoleRequest	true	"Role"
+//	@Success		200		{object}	responses.MemberResponse
+//	@Failure		400		{object}	responses.Error
+//	@Failure		401		{object}	responses.Error
+//	@Failure		403		{object}	responses.Error
+//	@Failure		404		{object}	responses.Error
+//	@Failure		409		{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/domains/{id}/members/{userId} [put]
+func (m *MembershipHandlers) ChangeMemberRole(c echo.Context) error {
+	domainID, userID, err := memberPathParams(c)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to parse path parameters: "+err.Error())
+	}
+
+	var changeMemberRoleRequest requests.ChangeMemberRoleRequest
+	if err := c.Bind(&changeMemberRoleRequest); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to bind request: "+err.Error())
+	}
+
+	if err := changeMemberRoleRequest.Validate(); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Required fields are empty or not valid")
+	}
+
+	member, err := m.membershipService.ChangeRole(c.Request().Context(), domainID, userID, changeMemberRoleRequest.Role)
+	if err != nil {
+		return membershipErrorResponse(c, err, "Failed to change domain member role")
+	}
+
+	return responses.Response(c, http.StatusOK, responses.NewMemberResponse(member))
+}
+
+// RemoveMember godoc
+//
+//	@Summary		Remove domain member
+//	@Description	Remove the user from the domain, only domain admins are allowed
+//	@ID				domain-members-remove
+//	@Tags			Domains Actions
+//	@Param			id		path	string	true	"Domain ID"
+//	@Param			userId	path	int		true	"User ID"
+//	@Success		204
+//	@Failure		400	{object}	responses.Error
+//	@Failure		401	{object}	responses.Error
+//	@Failure		403	{object}	responses.Error
+//	@Failure		404	{object}	responses.Error
+//	@Failure		409	{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/domains/{id}/members/{userId} [delete]
+func (m *MembershipHandlers) RemoveMember(c echo.Context) error {
+	domainID, userID, err := memberPathParams(c)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to parse path parameters: "+err.Error())
+	}
+
+	if err := m.membershipService.RemoveMember(c.Request().Context(), domainID, userID); err != nil {
+		return membershipErrorResponse(c, err, "Failed to remove domain member")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func memberPathParams(c echo.Context) (uuid.UUID, uint, error) {
+	domainID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, 0, err
+	}
+
+	parsedID, err := strconv.ParseUint(c.Param("userId"), 10, 64)
+	if err != nil {
+		return uuid.Nil, 0, err
+	}
+
+	userID, err := safecast.ToUint(parsedID)
+	if err != nil {
+		return uuid.Nil, 0, err
+	}
+
+	return domainID, userID, nil
+}
+
+func membershipErrorResponse(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, models.ErrDomainNotFound):
+		return responses.ErrorResponse(c, http.StatusNotFound, "Domain not found")
+	case errors.Is(err, models.ErrMemberNotFound):
+		return responses.ErrorResponse(c, http.StatusNotFound, "Domain member not found")
+	case errors.Is(err, membership.ErrLastAdmin):
+		return responses.ErrorResponse(c, http.StatusConflict, "Domain must keep at least one admin")
+	default:
+		return responses.ErrorResponse(c, http.StatusInternalServerError, message)
+	}
+}
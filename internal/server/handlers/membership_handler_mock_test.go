@@ -0,0 +1,198 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: membership_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=membership_handler.go -destination=membership_handler_mock_test.go -package=handlers_test -typed=true
+//
+
+// Package handlers_test is a generated GoMock package.
+package handlers_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "echo-app/internal/models"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockmembershipService is a mock of membershipService interface.
+type MockmembershipService struct {
+	ctrl     *gomock.Controller
+	recorder *MockmembershipServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockmembershipServiceMockRecorder is the mock recorder for MockmembershipService.
+type MockmembershipServiceMockRecorder struct {
+	mock *MockmembershipService
+}
+
+// NewMockmembershipService creates a new mock instance.
+func NewMockmembershipService(ctrl *gomock.Controller) *MockmembershipService {
+	mock := &MockmembershipService{ctrl: ctrl}
+	mock.recorder = &MockmembershipServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmembershipService) EXPECT() *MockmembershipServiceMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockmembershipService) AddMember(ctx context.Context, domainID uuid.UUID, email, role string) (models.DomainMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", ctx, domainID, email, role)
+	ret0, _ := ret[0].(models.DomainMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockmembershipServiceMockRecorder) AddMember(ctx, domainID, email, role any) *MockmembershipServiceAddMemberCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockmembershipService)(nil).AddMember), ctx, domainID, email, role)
+	return &MockmembershipServiceAddMemberCall{Call: call}
+}
+
+// MockmembershipServiceAddMemberCall wrap *gomock.Call
+type MockmembershipServiceAddMemberCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmembershipServiceAddMemberCall) Return(arg0 models.DomainMember, arg1 error) *MockmembershipServiceAddMemberCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmembershipServiceAddMemberCall) Do(f func(context.Context, uuid.UUID, string, string) (models.DomainMember, error)) *MockmembershipServiceAddMemberCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmembershipServiceAddMemberCall) DoAndReturn(f func(context.Context, uuid.UUID, string, string) (models.DomainMember, error)) *MockmembershipServiceAddMemberCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ChangeRole mocks base method.
+func (m *MockmembershipService) ChangeRole(ctx context.Context, domainID uuid.UUID, userID uint, role string) (models.DomainMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeRole", ctx, domainID, userID, role)
+	ret0, _ := ret[0].(models.DomainMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeRole indicates an expected call of ChangeRole.
+func (mr *MockmembershipServiceMockRecorder) ChangeRole(ctx, domainID, userID, role any) *MockmembershipServiceChangeRoleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeRole", reflect.TypeOf((*MockmembershipService)(nil).ChangeRole), ctx, domainID, userID, role)
+	return &MockmembershipServiceChangeRoleCall{Call: call}
+}
+
+// MockmembershipServiceChangeRoleCall wrap *gomock.Call
+type MockmembershipServiceChangeRoleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmembershipServiceChangeRoleCall) Return(arg0 models.DomainMember, arg1 error) *MockmembershipServiceChangeRoleCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmembershipServiceChangeRoleCall) Do(f func(context.Context, uuid.UUID, uint, string) (models.DomainMember, error)) *MockmembershipServiceChangeRoleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmembershipServiceChangeRoleCall) DoAndReturn(f func(context.Context, uuid.UUID, uint, string) (models.DomainMember, error)) *MockmembershipServiceChangeRoleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetMembers mocks base method.
+func (m *MockmembershipService) GetMembers(ctx context.Context, domainID uuid.UUID) ([]models.DomainMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, domainID)
+	ret0, _ := ret[0].([]models.DomainMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockmembershipServiceMockRecorder) GetMembers(ctx, domainID any) *MockmembershipServiceGetMembersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockmembershipService)(nil).GetMembers), ctx, domainID)
+	return &MockmembershipServiceGetMembersCall{Call: call}
+}
+
+// MockmembershipServiceGetMembersCall wrap *gomock.Call
+type MockmembershipServiceGetMembersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmembershipServiceGetMembersCall) Return(arg0 []models.DomainMember, arg1 error) *MockmembershipServiceGetMembersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmembershipServiceGetMembersCall) Do(f func(context.Context, uuid.UUID) ([]models.DomainMember, error)) *MockmembershipServiceGetMembersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmembershipServiceGetMembersCall) DoAndReturn(f func(context.Context, uuid.UUID) ([]models.DomainMember, error)) *MockmembershipServiceGetMembersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RemoveMember mocks base method.
+func (m *MockmembershipService) RemoveMember(ctx context.Context, domainID uuid.UUID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, domainID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockmembershipServiceMockRecorder) RemoveMember(ctx, domainID, userID any) *MockmembershipServiceRemoveMemberCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockmembershipService)(nil).RemoveMember), ctx, domainID, userID)
+	return &MockmembershipServiceRemoveMemberCall{Call: call}
+}
+
+// MockmembershipServiceRemoveMemberCall wrap *gomock.Call
+type MockmembershipServiceRemoveMemberCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmembershipServiceRemoveMemberCall) Return(arg0 error) *MockmembershipServiceRemoveMemberCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmembershipServiceRemoveMemberCall) Do(f func(context.Context, uuid.UUID, uint) error) *MockmembershipServiceRemoveMemberCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmembershipServiceRemoveMemberCall) DoAndReturn(f func(context.Context, uuid.UUID, uint) error) *MockmembershipServiceRemoveMemberCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"echo-app/internal/models"
+	"echo-app/internal/server/handlers"
+	"echo-app/internal/services/membership"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func newMembershipHandlers(t *testing.T) (*echo.Echo, handlers.MembershipHandlers, *MockmembershipService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	membershipService := NewMockmembershipService(ctrl)
+	membershipHandlers := handlers.NewMembershipHandlers(membershipService)
+	engine := echo.New()
+
+	return engine, membershipHandlers, membershipService
+}
+
+func TestMembershipHandlers_AddMember(t *testing.T) {
+	domainID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+
+	newRequest := func(t *testing.T, body string) *http.Request {
+		t.Helper()
+
+		request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/domains/"+domainID.String()+"/members", strings.NewReader(body))
+		request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		return request
+	}
+
+	t.Run("It should add the member", func(t *testing.T) {
+		engine, membershipHandlers, membershipService := newMembershipHandlers(t)
+
+		membershipService.
+			EXPECT().
+			AddMember(gomock.Any(), domainID, "example@email.com", "admin").
+			Return(models.DomainMember{User: models.User{Model: gorm.Model{ID: 2}}, Role: "admin"}, nil)
+
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(newRequest(t, `{"email":"example@email.com","role":"admin"}`), recorder)
+		c.SetParamNames("id")
+		c.SetParamValues(domainID.String())
+
+		err := membershipHandlers.AddMember(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, recorder.Result().StatusCode)
+	})
+
+	t.Run("It should reject an unknown role", func(t *testing.T) {
+		engine, membershipHandlers, _ := newMembershipHandlers(t)
+
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(newRequest(t, `{"email":"example@email.com","role":"owner"}`), recorder)
+		c.SetParamNames("id")
+		c.SetParamValues(domainID.String())
+
+		err := membershipHandlers.AddMember(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+	})
+}
+
+func TestMembershipHandlers_RemoveMember(t *testing.T) {
+	domainID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+
+	engine, membershipHandlers, membershipService := newMembershipHandlers(t)
+
+	membershipService.
+		EXPECT().
+		RemoveMember(gomock.Any(), domainID, uint(1)).
+		Return(membership.ErrLastAdmin)
+
+	request := httptest.NewRequestWithContext(t.Context(), http.MethodDelete, "/", http.NoBody)
+	recorder := httptest.NewRecorder()
+	c := engine.NewContext(request, recorder)
+	c.SetParamNames("id", "userId")
+	c.SetParamValues(domainID.String(), "1")
+
+	err := membershipHandlers.RemoveMember(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusConflict, recorder.Result().StatusCode)
+}
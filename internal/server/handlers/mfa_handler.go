@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"echo-app/internal/requests"
+	"echo-app/internal/responses"
+	"echo-app/internal/server/middleware"
+	"echo-app/internal/services/mfa"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mfa_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type mfaEnroller interface {
+	Enroll(ctx context.Context, userID uint) (mfa.Enrollment, error)
+	Confirm(ctx context.Context, userID uint, code string) ([]string, error)
+}
+
+type MFAHandlers struct {
+	mfaEnroller mfaEnroller
+}
+
+func NewMFAHandlers(mfaEnroller mfaEnroller) MFAHandlers {
+	return MFAHandlers{mfaEnroller: mfaEnroller}
+}
+
+// EnrollTOTP godoc
+//
+//	@Summary		Enroll TOTP
+//	@Description	Generate a TOTP secret of the current user for an authenticator app, it's enabled once a code confirms it.
+//	@Description	Only password accounts can enroll, the identity provider handles MFA of OIDC users.
+//	@ID				mfa-totp-enroll
+//	@Tags			Two-Factor Authentication
+//	@Produce		json
+//	@Success		200	{object}	responses.TOTPEnrollmentResponse
+//	@Failure		401	{object}	responses.Error
+//	@Failure		403	{object}	responses.Error
+//	@Failure		409	{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/mfa/totp [post]
+func (h *MFAHandlers) EnrollTOTP(c echo.Context) error {
+	user, err := middleware.CurrentUser(c)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	enrollment, err := h.mfaEnroller.Enroll(c.Request().Context(), user.ID)
+	if errors.Is(err, mfa.ErrMFANotAvailable) {
+		return responses.ErrorResponse(c, http.StatusForbidden, "Two-factor authentication is only available for password accounts")
+	} else if errors.Is(err, mfa.ErrMFAAlreadyEnabled) {
+		return responses.ErrorResponse(c, http.StatusConflict, "Two-factor authentication is already enabled")
+	} else if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to enroll TOTP")
+	}
+
+	return responses.Response(c, http.StatusOK, responses.TOTPEnrollmentResponse{
+		Secret: enrollment.Secret,
+		URI:    enrollment.URI,
+	})
+}
+
+// ConfirmTOTP godoc
+//
+//	@Summary		Confirm TOTP
+//	@Description	Enable two-factor authentication with a code of the enrolled TOTP secret.
+//	@Description	The response has the one-time recovery codes, they are only shown once.
+//	@ID				mfa-totp-confirm
+//	@Tags			Two-Factor Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.ConfirmTOTPRequest	true	"Code of the authenticator app"
+//	@Success		200		{object}	responses.RecoveryCodesResponse
+//	@Failure		400		{object}	responses.Error
+//	@Failure		401		{object}	responses.Error
+//	@Failure		403		{object}	responses.Error
+//	@Failure		409		{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/mfa/totp/confirm [post]
+func (h *MFAHandlers) ConfirmTOTP(c echo.Context) error {
+	user, err := middleware.CurrentUser(c)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	var confirmRequest requests.ConfirmTOTPRequest
+	if err := c.Bind(&confirmRequest); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to bind request: "+err.Error())
+	}
+
+	if err := confirmRequest.Validate(); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Required fields are empty or not valid")
+	}
+
+	recoveryCodes, err := h.mfaEnroller.Confirm(c.Request().Context(), user.ID, confirmRequest.Code)
+	switch {
+	case errors.Is(err, mfa.ErrInvalidCode):
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Invalid code")
+	case errors.Is(err, mfa.ErrMFANotEnrolled):
+		return responses.ErrorResponse(c, http.StatusConflict, "Enroll TOTP first")
+	case errors.Is(err, mfa.ErrMFAAlreadyEnabled):
+		return responses.ErrorResponse(c, http.StatusConflict, "Two-factor authentication is already enabled")
+	case errors.Is(err, mfa.ErrMFANotAvailable):
+		return responses.ErrorResponse(c, http.StatusForbidden, "Two-factor authentication is only available for password accounts")
+	case err != nil:
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to confirm TOTP")
+	}
+
+	return responses.Response(c, http.StatusOK, responses.RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
@@ -0,0 +1,120 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mfa_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=mfa_handler.go -destination=mfa_handler_mock_test.go -package=handlers_test -typed=true
+//
+
+// Package handlers_test is a generated GoMock package.
+package handlers_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	mfa "echo-app/internal/services/mfa"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockmfaEnroller is a mock of mfaEnroller interface.
+type MockmfaEnroller struct {
+	ctrl     *gomock.Controller
+	recorder *MockmfaEnrollerMockRecorder
+	isgomock struct{}
+}
+
+// MockmfaEnrollerMockRecorder is the mock recorder for MockmfaEnroller.
+type MockmfaEnrollerMockRecorder struct {
+	mock *MockmfaEnroller
+}
+
+// NewMockmfaEnroller creates a new mock instance.
+func NewMockmfaEnroller(ctrl *gomock.Controller) *MockmfaEnroller {
+	mock := &MockmfaEnroller{ctrl: ctrl}
+	mock.recorder = &MockmfaEnrollerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmfaEnroller) EXPECT() *MockmfaEnrollerMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockmfaEnroller) Confirm(ctx context.Context, userID uint, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, userID, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockmfaEnrollerMockRecorder) Confirm(ctx, userID, code any) *MockmfaEnrollerConfirmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockmfaEnroller)(nil).Confirm), ctx, userID, code)
+	return &MockmfaEnrollerConfirmCall{Call: call}
+}
+
+// MockmfaEnrollerConfirmCall wrap *gomock.Call
+type MockmfaEnrollerConfirmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmfaEnrollerConfirmCall) Return(arg0 []string, arg1 error) *MockmfaEnrollerConfirmCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmfaEnrollerConfirmCall) Do(f func(context.Context, uint, string) ([]string, error)) *MockmfaEnrollerConfirmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmfaEnrollerConfirmCall) DoAndReturn(f func(context.Context, uint, string) ([]string, error)) *MockmfaEnrollerConfirmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Enroll mocks base method.
+func (m *MockmfaEnroller) Enroll(ctx context.Context, userID uint) (mfa.Enrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enroll", ctx, userID)
+	ret0, _ := ret[0].(mfa.Enrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockmfaEnrollerMockRecorder) Enroll(ctx, userID any) *MockmfaEnrollerEnrollCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockmfaEnroller)(nil).Enroll), ctx, userID)
+	return &MockmfaEnrollerEnrollCall{Call: call}
+}
+
+// MockmfaEnrollerEnrollCall wrap *gomock.Call
+type MockmfaEnrollerEnrollCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmfaEnrollerEnrollCall) Return(arg0 mfa.Enrollment, arg1 error) *MockmfaEnrollerEnrollCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmfaEnrollerEnrollCall) Do(f func(context.Context, uint) (mfa.Enrollment, error)) *MockmfaEnrollerEnrollCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmfaEnrollerEnrollCall) DoAndReturn(f func(context.Context, uint) (mfa.Enrollment, error)) *MockmfaEnrollerEnrollCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
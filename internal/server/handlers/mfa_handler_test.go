@@ -0,0 +1,174 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"echo-app/internal/models"
+	"echo-app/internal/server/handlers"
+	"echo-app/internal/server/middleware"
+	"echo-app/internal/services/mfa"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func newMFAHandlers(t *testing.T) (*echo.Echo, handlers.MFAHandlers, *MockmfaEnroller) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mfaEnroller := NewMockmfaEnroller(ctrl)
+	mfaHandlers := handlers.NewMFAHandlers(mfaEnroller)
+	engine := echo.New()
+
+	return engine, mfaHandlers, mfaEnroller
+}
+
+// newMFAContext returns the context of a request of the authenticated user.
+func newMFAContext(t *testing.T, engine *echo.Echo, body string, recorder *httptest.ResponseRecorder) echo.Context {
+	t.Helper()
+
+	request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/mfa/totp", strings.NewReader(body))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	c := engine.NewContext(request, recorder)
+	middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+
+	return c
+}
+
+func TestMFAHandlers_EnrollTOTP(t *testing.T) {
+	t.Run("It should return the secret and its otpauth URI", func(t *testing.T) {
+		engine, mfaHandlers, mfaEnroller := newMFAHandlers(t)
+
+		mfaEnroller.
+			EXPECT().
+			Enroll(gomock.Any(), uint(7)).
+			Return(mfa.Enrollment{Secret: "JBSWY3DPEHPK3PXP", URI: "otpauth://totp/echo-app:john.doe@example.com"}, nil)
+
+		recorder := httptest.NewRecorder()
+
+		err := mfaHandlers.EnrollTOTP(newMFAContext(t, engine, "", recorder))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+		wantResponse := `{
+			"secret": "JBSWY3DPEHPK3PXP",
+			"uri": "otpauth://totp/echo-app:john.doe@example.com"
+		}`
+
+		assert.JSONEq(t, wantResponse, recorder.Body.String())
+	})
+
+	t.Run("It should not enroll an OIDC user", func(t *testing.T) {
+		engine, mfaHandlers, mfaEnroller := newMFAHandlers(t)
+
+		mfaEnroller.
+			EXPECT().
+			Enroll(gomock.Any(), uint(7)).
+			Return(mfa.Enrollment{}, mfa.ErrMFANotAvailable)
+
+		recorder := httptest.NewRecorder()
+
+		err := mfaHandlers.EnrollTOTP(newMFAContext(t, engine, "", recorder))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusForbidden, recorder.Result().StatusCode)
+	})
+
+	t.Run("It should not enroll a user with MFA enabled", func(t *testing.T) {
+		engine, mfaHandlers, mfaEnroller := newMFAHandlers(t)
+
+		mfaEnroller.
+			EXPECT().
+			Enroll(gomock.Any(), uint(7)).
+			Return(mfa.Enrollment{}, mfa.ErrMFAAlreadyEnabled)
+
+		recorder := httptest.NewRecorder()
+
+		err := mfaHandlers.EnrollTOTP(newMFAContext(t, engine, "", recorder))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusConflict, recorder.Result().StatusCode)
+	})
+}
+
+func TestMFAHandlers_ConfirmTOTP(t *testing.T) {
+	t.Run("It should return the recovery codes", func(t *testing.T) {
+		engine, mfaHandlers, mfaEnroller := newMFAHandlers(t)
+
+		mfaEnroller.
+			EXPECT().
+			Confirm(gomock.Any(), uint(7), "123456").
+			Return([]string{"abcde-fghij", "klmno-pqrst"}, nil)
+
+		recorder := httptest.NewRecorder()
+
+		err := mfaHandlers.ConfirmTOTP(newMFAContext(t, engine, `{"code": "123456"}`, recorder))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+		wantResponse := `{
+			"recoveryCodes": ["abcde-fghij", "klmno-pqrst"]
+		}`
+
+		assert.JSONEq(t, wantResponse, recorder.Body.String())
+	})
+
+	t.Run("It should return an error if the code is empty", func(t *testing.T) {
+		engine, mfaHandlers, _ := newMFAHandlers(t)
+
+		recorder := httptest.NewRecorder()
+
+		err := mfaHandlers.ConfirmTOTP(newMFAContext(t, engine, `{"code": ""}`, recorder))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+	})
+
+	t.Run("It should return an error if the code is invalid", func(t *testing.T) {
+		engine, mfaHandlers, mfaEnroller := newMFAHandlers(t)
+
+		mfaEnroller.
+			EXPECT().
+			Confirm(gomock.Any(), uint(7), "000000").
+			Return(nil, mfa.ErrInvalidCode)
+
+		recorder := httptest.NewRecorder()
+
+		err := mfaHandlers.ConfirmTOTP(newMFAContext(t, engine, `{"code": "000000"}`, recorder))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+
+		wantResponse := `{
+			"code": 400,
+			"error": "Invalid code"
+		}`
+
+		assert.JSONEq(t, wantResponse, recorder.Body.String())
+	})
+
+	t.Run("It should return an error if the user didn't enroll", func(t *testing.T) {
+		engine, mfaHandlers, mfaEnroller := newMFAHandlers(t)
+
+		mfaEnroller.
+			EXPECT().
+			Confirm(gomock.Any(), uint(7), "123456").
+			Return(nil, mfa.ErrMFANotEnrolled)
+
+		recorder := httptest.NewRecorder()
+
+		err := mfaHandlers.ConfirmTOTP(newMFAContext(t, engine, `{"code": "123456"}`, recorder))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusConflict, recorder.Result().StatusCode)
+	})
+}
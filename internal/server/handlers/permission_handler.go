@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"echo-app/internal/models"
+	"echo-app/internal/requests"
+	"echo-app/internal/responses"
+	"echo-app/internal/server/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=permission_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type permissionService interface {
+	BulkCheck(ctx context.Context, userID uint, checks []models.PermissionCheck) ([]models.PermissionCheckResult, error)
+}
+
+type PermissionHandlers struct {
+	permissionService permissionService
+}
+
+func NewPermissionHandlers(permissionService permissionService) PermissionHandlers {
+	return PermissionHandlers{permissionService: permissionService}
+}
+
+// CheckPermissions godoc
+//
+//	@Summary		Check permissions
+//	@Description	Check permissions of the current user on several entities at once, e.g. to show the edit buttons of a page of posts
+//	@ID				permissions-check
+//	@Tags			Permissions Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Domain-ID	header		string								false	"Domain the request works in, defaults to the domain of the user"
+//	@Param			params		body		requests.BulkPermissionCheckRequest	true	"Permissions to check"
+//	@Success		200			{array}		responses.PermissionCheckResponse
+//	@Failure		400			{object}	responses.Error
+//	@Failure		401			{object}	responses.Error
+//	@Failure		403			{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/permissions/check [post]
+func (p *PermissionHandlers) CheckPermissions(c echo.Context) error {
+	user, err := middleware.CurrentUser(c)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	var checkRequest requests.BulkPermissionCheckRequest
+	if err := c.Bind(&checkRequest); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to bind request: "+err.Error())
+	}
+
+	if err := checkRequest.Validate(); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Required fields are empty or not valid: "+err.Error())
+	}
+
+	checks := make([]models.PermissionCheck, 0, len(checkRequest.Checks))
+	for _, check := range checkRequest.Checks {
+		checks = append(checks, models.PermissionCheck{
+			EntityType: check.EntityType,
+			EntityID:   check.EntityID,
+			Permission: check.Permission,
+		})
+	}
+
+	results, err := p.permissionService.BulkCheck(c.Request().Context(), user.ID, checks)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to check permissions")
+	}
+
+	return responses.Response(c, http.StatusOK, responses.NewPermissionChecksResponse(results))
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: permission_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=permission_handler.go -destination=permission_handler_mock_test.go -package=handlers_test -typed=true
+//
+
+// Package handlers_test is a generated GoMock package.
+package handlers_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "echo-app/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockpermissionService is a mock of permissionService interface.
+type MockpermissionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockpermissionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockpermissionServiceMockRecorder is the mock recorder for MockpermissionService.
+type MockpermissionServiceMockRecorder struct {
+	mock *MockpermissionService
+}
+
+// NewMockpermissionService creates a new mock instance.
+func NewMockpermissionService(ctrl *gomock.Controller) *MockpermissionService {
+	mock := &MockpermissionService{ctrl: ctrl}
+	mock.recorder = &MockpermissionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpermissionService) EXPECT() *MockpermissionServiceMockRecorder {
+	return m.recorder
+}
+
+// BulkCheck mocks base method.
+func (m *MockpermissionService) BulkCheck(ctx context.Context, userID uint, checks []models.PermissionCheck) ([]models.PermissionCheckResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCheck", ctx, userID, checks)
+	ret0, _ := ret[0].([]models.PermissionCheckResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkCheck indicates an expected call of BulkCheck.
+func (mr *MockpermissionServiceMockRecorder) BulkCheck(ctx, userID, checks any) *MockpermissionServiceBulkCheckCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCheck", reflect.TypeOf((*MockpermissionService)(nil).BulkCheck), ctx, userID, checks)
+	return &MockpermissionServiceBulkCheckCall{Call: call}
+}
+
+// MockpermissionServiceBulkCheckCall wrap *gomock.Call
+type MockpermissionServiceBulkCheckCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpermissionServiceBulkCheckCall) Return(arg0 []models.PermissionCheckResult, arg1 error) *MockpermissionServiceBulkCheckCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpermissionServiceBulkCheckCall) Do(f func(context.Context, uint, []models.PermissionCheck) ([]models.PermissionCheckResult, error)) *MockpermissionServiceBulkCheckCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpermissionServiceBulkCheckCall) DoAndReturn(f func(context.Context, uint, []models.PermissionCheck) ([]models.PermissionCheckResult, error)) *MockpermissionServiceBulkCheckCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
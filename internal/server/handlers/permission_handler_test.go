@@ -0,0 +1,71 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"echo-app/internal/models"
+	"echo-app/internal/responses"
+	"echo-app/internal/server/handlers"
+	"echo-app/internal/server/middleware"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestPermissionHandlers_CheckPermissions(t *testing.T) {
+	newContext := func(t *testing.T, body string) (echo.Context, *httptest.ResponseRecorder) {
+		t.Helper()
+
+		request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/permissions/check", strings.NewReader(body))
+		request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		recorder := httptest.NewRecorder()
+		c := echo.New().NewContext(request, recorder)
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+
+		return c, recorder
+	}
+
+	t.Run("It should return the result of every check", func(t *testing.T) {
+		permissionService := NewMockpermissionService(gomock.NewController(t))
+		permissionHandlers := handlers.NewPermissionHandlers(permissionService)
+
+		check := models.PermissionCheck{EntityType: "post", EntityID: "3", Permission: "edit"}
+
+		permissionService.
+			EXPECT().
+			BulkCheck(gomock.Any(), uint(7), []models.PermissionCheck{check}).
+			Return([]models.PermissionCheckResult{{PermissionCheck: check, Allowed: true}}, nil)
+
+		c, recorder := newContext(t, `{"checks":[{"entityType":"post","entityId":"3","permission":"edit"}]}`)
+
+		err := permissionHandlers.CheckPermissions(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var body []responses.PermissionCheckResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+
+		assert.Equal(t, []responses.PermissionCheckResponse{
+			{EntityType: "post", EntityID: "3", Permission: "edit", Allowed: true},
+		}, body)
+	})
+
+	t.Run("It should reject unknown entity types", func(t *testing.T) {
+		permissionHandlers := handlers.NewPermissionHandlers(NewMockpermissionService(gomock.NewController(t)))
+
+		c, recorder := newContext(t, `{"checks":[{"entityType":"user","entityId":"3","permission":"edit"}]}`)
+
+		err := permissionHandlers.CheckPermissions(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"echo-app/internal/models"
+	"echo-app/internal/requests"
+	"echo-app/internal/responses"
+	"echo-app/internal/server/middleware"
+	"echo-app/internal/services/pat"
+
+	safecast "github.com/ccoveille/go-safecast"
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=personal_access_token_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type personalAccessTokenService interface {
+	Create(
+		ctx context.Context,
+		userID uint,
+		name string,
+		scopes []string,
+		expiresAt time.Time,
+	) (models.PersonalAccessToken, string, error)
+	List(ctx context.Context, userID uint) ([]models.PersonalAccessToken, error)
+	Revoke(ctx context.Context, userID, id uint) error
+}
+
+type PersonalAccessTokenHandlers struct {
+	tokenService personalAccessTokenService
+}
+
+func NewPersonalAccessTokenHandlers(tokenService personalAccessTokenService) PersonalAccessTokenHandlers {
+	return PersonalAccessTokenHandlers{tokenService: tokenService}
+}
+
+// CreateToken godoc
+//
+//	@Summary		Create personal access token
+//	@Description	Create a personal access token of the current user for API clients, the token is only shown in this response.
+//	@Description	Send it as "Authorization: Bearer pat_..." to use the endpoints its scopes allow.
+//	@ID				personal-access-tokens-create
+//	@Tags			Personal Access Tokens
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.CreatePersonalAccessTokenRequest	true	"Token name, scopes and expiry"
+//	@Success		201		{object}	responses.CreatedPersonalAccessTokenResponse
+//	@Failure		400		{object}	responses.Error
+//	@Failure		401		{object}	responses.Error
+//	@Failure		403		{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/personal-access-tokens [post]
+func (h *PersonalAccessTokenHandlers) CreateToken(c echo.Context) error {
+	user, err := middleware.CurrentUser(c)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	var createRequest requests.CreatePersonalAccessTokenRequest
+	if err := c.Bind(&createRequest); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to bind request: "+err.Error())
+	}
+
+	if err := createRequest.Validate(); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Required fields are empty or not valid")
+	}
+
+	token, value, err := h.tokenService.Create(
+		c.Request().Context(),
+		user.ID,
+		createRequest.Name,
+		createRequest.Scopes,
+		createRequest.ExpiresAt,
+	)
+	if errors.Is(err, pat.ErrInvalidExpiry) {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Expiry is in the past or too far in the future")
+	} else if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to create personal access token")
+	}
+
+	return responses.Response(c, http.StatusCreated, responses.CreatedPersonalAccessTokenResponse{
+		PersonalAccessTokenResponse: responses.NewPersonalAccessTokenResponse(token),
+		Token:                       value,
+	})
+}
+
+// GetTokens godoc
+//
+//	@Summary		Get personal access tokens
+//	@Description	Get the personal access tokens of the current user that aren't revoked, the newest first
+//	@ID				personal-access-tokens-get
+//	@Tags			Personal Access Tokens
+//	@Produce		json
+//	@Success		200	{array}		responses.PersonalAccessTokenResponse
+//	@Failure		401	{object}	responses.Error
+//	@Failure		403	{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/personal-access-tokens [get]
+func (h *PersonalAccessTokenHandlers) GetTokens(c echo.Context) error {
+	user, err := middleware.CurrentUser(c)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	tokens, err := h.tokenService.List(c.Request().Context(), user.ID)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to get personal access tokens")
+	}
+
+	return responses.Response(c, http.StatusOK, responses.NewPersonalAccessTokensResponse(tokens))
+}
+
+// RevokeToken godoc
+//
+//	@Summary		Revoke personal access token
+//	@Description	Revoke a personal access token of the current user
+//	@ID				personal-access-tokens-revoke
+//	@Tags			Personal Access Tokens
+//	@Param			id	path	int	true	"Personal access token ID"
+//	@Success		204
+//	@Failure		400	{object}	responses.Error
+//	@Failure		401	{object}	responses.Error
+//	@Failure		403	{object}	responses.Error
+//	@Failure		404	{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/personal-access-tokens/{id} [delete]
+func (h *PersonalAccessTokenHandlers) RevokeToken(c echo.Context) error {
+	user, err := middleware.CurrentUser(c)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	parsedID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to parse personal access token id: "+err.Error())
+	}
+
+	id, err := safecast.ToUint(parsedID)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to parse personal access token id: "+err.Error())
+	}
+
+	err = h.tokenService.Revoke(c.Request().Context(), user.ID, id)
+	if errors.Is(err, models.ErrPersonalAccessTokenNotFound) {
+		return responses.ErrorResponse(c, http.StatusNotFound, "Personal access token not found")
+	} else if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to revoke personal access token")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: personal_access_token_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=personal_access_token_handler.go -destination=personal_access_token_handler_mock_test.go -package=handlers_test -typed=true
+//
+
+// Package handlers_test is a generated GoMock package.
+package handlers_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "echo-app/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockpersonalAccessTokenService is a mock of personalAccessTokenService interface.
+type MockpersonalAccessTokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockpersonalAccessTokenServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockpersonalAccessTokenServiceMockRecorder is the mock recorder for MockpersonalAccessTokenService.
+type MockpersonalAccessTokenServiceMockRecorder struct {
+	mock *MockpersonalAccessTokenService
+}
+
+// NewMockpersonalAccessTokenService creates a new mock instance.
+func NewMockpersonalAccessTokenService(ctrl *gomock.Controller) *MockpersonalAccessTokenService {
+	mock := &MockpersonalAccessTokenService{ctrl: ctrl}
+	mock.recorder = &MockpersonalAccessTokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpersonalAccessTokenService) EXPECT() *MockpersonalAccessTokenServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockpersonalAccessTokenService) Create(ctx context.Context, userID uint, name string, scopes []string, expiresAt time.Time) (models.PersonalAccessToken, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, name, scopes, expiresAt)
+	ret0, _ := ret[0].(models.PersonalAccessToken)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockpersonalAccessTokenServiceMockRecorder) Create(ctx, userID, name, scopes, expiresAt any) *MockpersonalAccessTokenServiceCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockpersonalAccessTokenService)(nil).Create), ctx, userID, name, scopes, expiresAt)
+	return &MockpersonalAccessTokenServiceCreateCall{Call: call}
+}
+
+// MockpersonalAccessTokenServiceCreateCall wrap *gomock.Call
+type MockpersonalAccessTokenServiceCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpersonalAccessTokenServiceCreateCall) Return(arg0 models.PersonalAccessToken, arg1 string, arg2 error) *MockpersonalAccessTokenServiceCreateCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpersonalAccessTokenServiceCreateCall) Do(f func(context.Context, uint, string, []string, time.Time) (models.PersonalAccessToken, string, error)) *MockpersonalAccessTokenServiceCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpersonalAccessTokenServiceCreateCall) DoAndReturn(f func(context.Context, uint, string, []string, time.Time) (models.PersonalAccessToken, string, error)) *MockpersonalAccessTokenServiceCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// List mocks base method.
+func (m *MockpersonalAccessTokenService) List(ctx context.Context, userID uint) ([]models.PersonalAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID)
+	ret0, _ := ret[0].([]models.PersonalAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockpersonalAccessTokenServiceMockRecorder) List(ctx, userID any) *MockpersonalAccessTokenServiceListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockpersonalAccessTokenService)(nil).List), ctx, userID)
+	return &MockpersonalAccessTokenServiceListCall{Call: call}
+}
+
+// MockpersonalAccessTokenServiceListCall wrap *gomock.Call
+type MockpersonalAccessTokenServiceListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpersonalAccessTokenServiceListCall) Return(arg0 []models.PersonalAccessToken, arg1 error) *MockpersonalAccessTokenServiceListCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpersonalAccessTokenServiceListCall) Do(f func(context.Context, uint) ([]models.PersonalAccessToken, error)) *MockpersonalAccessTokenServiceListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpersonalAccessTokenServiceListCall) DoAndReturn(f func(context.Context, uint) ([]models.PersonalAccessToken, error)) *MockpersonalAccessTokenServiceListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MockpersonalAccessTokenService) Revoke(ctx context.Context, userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockpersonalAccessTokenServiceMockRecorder) Revoke(ctx, userID, id any) *MockpersonalAccessTokenServiceRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockpersonalAccessTokenService)(nil).Revoke), ctx, userID, id)
+	return &MockpersonalAccessTokenServiceRevokeCall{Call: call}
+}
+
+// MockpersonalAccessTokenServiceRevokeCall wrap *gomock.Call
+type MockpersonalAccessTokenServiceRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpersonalAccessTokenServiceRevokeCall) Return(arg0 error) *MockpersonalAccessTokenServiceRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpersonalAccessTokenServiceRevokeCall) Do(f func(context.Context, uint, uint) error) *MockpersonalAccessTokenServiceRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpersonalAccessTokenServiceRevokeCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockpersonalAccessTokenServiceRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
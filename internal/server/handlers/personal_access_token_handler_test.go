@@ -0,0 +1,211 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"echo-app/internal/models"
+	"echo-app/internal/server/handlers"
+	"echo-app/internal/server/middleware"
+	"echo-app/internal/services/pat"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func newPersonalAccessTokenHandlers(t *testing.T) (
+	*echo.Echo,
+	handlers.PersonalAccessTokenHandlers,
+	*MockpersonalAccessTokenService,
+) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	tokenService := NewMockpersonalAccessTokenService(ctrl)
+	tokenHandlers := handlers.NewPersonalAccessTokenHandlers(tokenService)
+	engine := echo.New()
+
+	return engine, tokenHandlers, tokenService
+}
+
+// newPersonalAccessTokenContext returns the context of a request authenticated as the user 7.
+func newPersonalAccessTokenContext(
+	t *testing.T,
+	engine *echo.Echo,
+	method, body string,
+	recorder *httptest.ResponseRecorder,
+) echo.Context {
+	t.Helper()
+
+	request := httptest.NewRequestWithContext(t.Context(), method, "/personal-access-tokens", strings.NewReader(body))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	c := engine.NewContext(request, recorder)
+	middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+
+	return c
+}
+
+func TestPersonalAccessTokenHandlers_CreateToken(t *testing.T) {
+	expiresAt := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	t.Run("It should create a token and show its value", func(t *testing.T) {
+		engine, tokenHandlers, tokenService := newPersonalAccessTokenHandlers(t)
+
+		tokenService.
+			EXPECT().
+			Create(gomock.Any(), uint(7), "ci", []string{"posts:read"}, expiresAt).
+			Return(models.PersonalAccessToken{
+				ID:        3,
+				UserID:    7,
+				Name:      "ci",
+				Scopes:    []string{"posts:read"},
+				ExpiresAt: expiresAt,
+				CreatedAt: createdAt,
+			}, "pat_value", nil)
+
+		recorder := httptest.NewRecorder()
+		body := `{"name": "ci", "scopes": ["posts:read"], "expiresAt": "2027-01-01T00:00:00Z"}`
+
+		err := tokenHandlers.CreateToken(newPersonalAccessTokenContext(t, engine, http.MethodPost, body, recorder))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, recorder.Result().StatusCode)
+
+		wantResponse := `{
+			"id": 3,
+			"name": "ci",
+			"scopes": ["posts:read"],
+			"expiresAt": "2027-01-01T00:00:00Z",
+			"lastUsedAt": null,
+			"createdAt": "2026-10-17T12:00:00Z",
+			"token": "pat_value"
+		}`
+
+		assert.JSONEq(t, wantResponse, recorder.Body.String())
+	})
+
+	requests := map[string]string{
+		"without a name":        `{"scopes": ["posts:read"], "expiresAt": "2027-01-01T00:00:00Z"}`,
+		"without scopes":        `{"name": "ci", "scopes": [], "expiresAt": "2027-01-01T00:00:00Z"}`,
+		"with an unknown scope": `{"name": "ci", "scopes": ["users:write"], "expiresAt": "2027-01-01T00:00:00Z"}`,
+		"without an expiry":     `{"name": "ci", "scopes": ["posts:read"]}`,
+	}
+
+	for name, body := range requests {
+		t.Run("It should reject a request "+name, func(t *testing.T) {
+			engine, tokenHandlers, _ := newPersonalAccessTokenHandlers(t)
+
+			recorder := httptest.NewRecorder()
+
+			err := tokenHandlers.CreateToken(newPersonalAccessTokenContext(t, engine, http.MethodPost, body, recorder))
+			require.NoError(t, err)
+
+			assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+		})
+	}
+
+	t.Run("It should reject an expiry the service doesn't allow", func(t *testing.T) {
+		engine, tokenHandlers, tokenService := newPersonalAccessTokenHandlers(t)
+
+		tokenService.
+			EXPECT().
+			Create(gomock.Any(), uint(7), "ci", []string{"posts:read"}, expiresAt).
+			Return(models.PersonalAccessToken{}, "", pat.ErrInvalidExpiry)
+
+		recorder := httptest.NewRecorder()
+		body := `{"name": "ci", "scopes": ["posts:read"], "expiresAt": "2027-01-01T00:00:00Z"}`
+
+		err := tokenHandlers.CreateToken(newPersonalAccessTokenContext(t, engine, http.MethodPost, body, recorder))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+	})
+}
+
+func TestPersonalAccessTokenHandlers_GetTokens(t *testing.T) {
+	engine, tokenHandlers, tokenService := newPersonalAccessTokenHandlers(t)
+
+	lastUsedAt := time.Date(2026, 10, 17, 13, 0, 0, 0, time.UTC)
+
+	tokenService.
+		EXPECT().
+		List(gomock.Any(), uint(7)).
+		Return([]models.PersonalAccessToken{
+			{
+				ID:         3,
+				Name:       "ci",
+				Scopes:     []string{"posts:read", "posts:write"},
+				ExpiresAt:  time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+				LastUsedAt: &lastUsedAt,
+				CreatedAt:  time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+			},
+		}, nil)
+
+	recorder := httptest.NewRecorder()
+
+	err := tokenHandlers.GetTokens(newPersonalAccessTokenContext(t, engine, http.MethodGet, "", recorder))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+	wantResponse := `[
+		{
+			"id": 3,
+			"name": "ci",
+			"scopes": ["posts:read", "posts:write"],
+			"expiresAt": "2027-01-01T00:00:00Z",
+			"lastUsedAt": "2026-10-17T13:00:00Z",
+			"createdAt": "2026-10-17T12:00:00Z"
+		}
+	]`
+
+	assert.JSONEq(t, wantResponse, recorder.Body.String())
+}
+
+func TestPersonalAccessTokenHandlers_RevokeToken(t *testing.T) {
+	t.Run("It should revoke the token", func(t *testing.T) {
+		engine, tokenHandlers, tokenService := newPersonalAccessTokenHandlers(t)
+
+		tokenService.
+			EXPECT().
+			Revoke(gomock.Any(), uint(7), uint(3)).
+			Return(nil)
+
+		recorder := httptest.NewRecorder()
+		c := newPersonalAccessTokenContext(t, engine, http.MethodDelete, "", recorder)
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+
+		err := tokenHandlers.RevokeToken(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Result().StatusCode)
+	})
+
+	t.Run("It should return not found for an unknown token", func(t *testing.T) {
+		engine, tokenHandlers, tokenService := newPersonalAccessTokenHandlers(t)
+
+		tokenService.
+			EXPECT().
+			Revoke(gomock.Any(), uint(7), uint(3)).
+			Return(models.ErrPersonalAccessTokenNotFound)
+
+		recorder := httptest.NewRecorder()
+		c := newPersonalAccessTokenContext(t, engine, http.MethodDelete, "", recorder)
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+
+		err := tokenHandlers.RevokeToken(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Result().StatusCode)
+	})
+}
@@ -8,14 +8,17 @@ import (
 	"echo-app/internal/models"
 	"echo-app/internal/requests"
 	"echo-app/internal/responses"
+	"echo-app/internal/server/middleware"
 
 	safecast "github.com/ccoveille/go-safecast"
 	"github.com/labstack/echo/v4"
 )
 
+//go:generate go tool mockgen -source=$GOFILE -destination=post_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
 type postService interface {
 	Create(ctx context.Context, post *models.Post) error
-	GetPosts(ctx context.Context) ([]models.Post, error)
+	GetPosts(ctx context.Context, userID uint) ([]models.Post, error)
 	GetPost(ctx context.Context, id uint) (models.Post, error)
 	Update(ctx context.Context, post *models.Post, updatePostRequest requests.UpdatePostRequest) error
 	Delete(ctx context.Context, post *models.Post) error
@@ -37,12 +40,20 @@ func NewPostHandlers(postService postService) PostHandlers {
 //	@Tags			Posts Actions
 //	@Accept			json
 //	@Produce		json
-//	@Param			params	body		requests.CreatePostRequest	true	"Post title and content"
-//	@Success		201		{object}	responses.Data
-//	@Failure		400		{object}	responses.Error
+//	@Param			X-Domain-ID	header		string						false	"Domain the request works in, defaults to the domain of the user"
+//	@Param			params		body		requests.CreatePostRequest	true	"Post title and content"
+//	@Success		201			{object}	responses.Data
+//	@Failure		400			{object}	responses.Error
+//	@Failure		401			{object}	responses.Error
+//	@Failure		403			{object}	responses.Error
 //	@Security		ApiKeyAuth
 //	@Router			/posts [post]
 func (p *PostHandlers) CreatePost(c echo.Context) error {
+	user, err := middleware.CurrentUser(c)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
 	var createPostRequest requests.CreatePostRequest
 	if err := c.Bind(&createPostRequest); err != nil {
 		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to bind request: "+err.Error())
@@ -52,11 +63,10 @@ func (p *PostHandlers) CreatePost(c echo.Context) error {
 		return responses.ErrorResponse(c, http.StatusBadRequest, "Required fields are empty")
 	}
 
-
 	post := &models.Post{
 		Title:   createPostRequest.Title,
 		Content: createPostRequest.Content,
-		UserID:  1,
+		UserID:  user.ID,
 	}
 
 	if err := p.postService.Create(c.Request().Context(), post); err != nil {
@@ -72,8 +82,11 @@ func (p *PostHandlers) CreatePost(c echo.Context) error {
 //	@Description	Delete post
 //	@ID				posts-delete
 //	@Tags			Posts Actions
-//	@Param			id	path		int	true	"Post ID"
-//	@Success		204	{object}	responses.Data
+//	@Param			X-Domain-ID	header	string	false	"Domain the request works in, defaults to the domain of the user"
+//	@Param			id			path	int		true	"Post ID"
+//	@Success		204
+//	@Failure		401	{object}	responses.Error
+//	@Failure		403	{object}	responses.Error
 //	@Failure		404	{object}	responses.Error
 //	@Security		ApiKeyAuth
 //	@Router			/posts/{id} [delete]
@@ -97,21 +110,29 @@ func (p *PostHandlers) DeletePost(c echo.Context) error {
 		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete post: "+err.Error())
 	}
 
-	return responses.MessageResponse(c, http.StatusNoContent, "Post deleted successfully")
+	return c.NoContent(http.StatusNoContent)
 }
 
 // GetPosts godoc
 //
 //	@Summary		Get posts
-//	@Description	Get the list of all posts
+//	@Description	Get the list of the posts of the domain the user can view
 //	@ID				posts-get
 //	@Tags			Posts Actions
 //	@Produce		json
-//	@Success		200	{array}	responses.PostResponse
+//	@Param			X-Domain-ID	header		string	false	"Domain the request works in, defaults to the domain of the user"
+//	@Success		200			{array}		responses.PostResponse
+//	@Failure		401			{object}	responses.Error
+//	@Failure		403			{object}	responses.Error
 //	@Security		ApiKeyAuth
 //	@Router			/posts [get]
 func (p *PostHandlers) GetPosts(c echo.Context) error {
-	posts, err := p.postService.GetPosts(c.Request().Context())
+	user, err := middleware.CurrentUser(c)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	posts, err := p.postService.GetPosts(c.Request().Context(), user.ID)
 	if err != nil {
 		return responses.ErrorResponse(c, http.StatusNotFound, "Failed to get all posts: "+err.Error())
 	}
@@ -128,11 +149,14 @@ func (p *PostHandlers) GetPosts(c echo.Context) error {
 //	@Tags			Posts Actions
 //	@Accept			json
 //	@Produce		json
-//	@Param			id		path		int							true	"Post ID"
-//	@Param			params	body		requests.UpdatePostRequest	true	"Post title and content"
-//	@Success		200		{object}	responses.Data
-//	@Failure		400		{object}	responses.Error
-//	@Failure		404		{object}	responses.Error
+//	@Param			X-Domain-ID	header		string						false	"Domain the request works in, defaults to the domain of the user"
+//	@Param			id			path		int							true	"Post ID"
+//	@Param			params		body		requests.UpdatePostRequest	true	"Post title and content"
+//	@Success		200			{object}	responses.Data
+//	@Failure		400			{object}	responses.Error
+//	@Failure		401			{object}	responses.Error
+//	@Failure		403			{object}	responses.Error
+//	@Failure		404			{object}	responses.Error
 //	@Security		ApiKeyAuth
 //	@Router			/posts/{id} [put]
 func (p *PostHandlers) UpdatePost(c echo.Context) error {
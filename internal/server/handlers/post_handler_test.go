@@ -0,0 +1,138 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"echo-app/internal/models"
+	"echo-app/internal/requests"
+	"echo-app/internal/server/handlers"
+	"echo-app/internal/server/middleware"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func newPostHandlers(t *testing.T) (*echo.Echo, handlers.PostHandlers, *MockpostService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	postService := NewMockpostService(ctrl)
+	postHandlers := handlers.NewPostHandlers(postService)
+	engine := echo.New()
+
+	return engine, postHandlers, postService
+}
+
+func newPostRequest(t *testing.T, method, target string) *http.Request {
+	t.Helper()
+
+	buffer := new(bytes.Buffer)
+	err := json.NewEncoder(buffer).Encode(requests.CreatePostRequest{
+		BasicPost: requests.BasicPost{Title: "title", Content: "content"},
+	})
+	require.NoError(t, err)
+
+	request := httptest.NewRequestWithContext(t.Context(), method, target, buffer)
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	return request
+}
+
+func TestPostHandlers_CreatePost(t *testing.T) {
+	t.Run("It should reject an unauthenticated request", func(t *testing.T) {
+		engine, postHandlers, _ := newPostHandlers(t)
+
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(newPostRequest(t, http.MethodPost, "/posts"), recorder)
+
+		err := postHandlers.CreatePost(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Result().StatusCode)
+	})
+
+	t.Run("It should create a post authored by the current user", func(t *testing.T) {
+		engine, postHandlers, postService := newPostHandlers(t)
+
+		postService.
+			EXPECT().
+			Create(gomock.Any(), &models.Post{Title: "title", Content: "content", UserID: 7}).
+			Return(nil)
+
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(newPostRequest(t, http.MethodPost, "/posts"), recorder)
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+
+		err := postHandlers.CreatePost(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, recorder.Result().StatusCode)
+	})
+}
+
+func TestPostHandlers_UpdatePost(t *testing.T) {
+	t.Run("It should update the post", func(t *testing.T) {
+		engine, postHandlers, postService := newPostHandlers(t)
+
+		post := models.Post{Model: gorm.Model{ID: 3}, UserID: 8}
+
+		postService.
+			EXPECT().
+			GetPost(gomock.Any(), uint(3)).
+			Return(post, nil)
+
+		postService.
+			EXPECT().
+			Update(gomock.Any(), &post, requests.UpdatePostRequest{
+				BasicPost: requests.BasicPost{Title: "title", Content: "content"},
+			}).
+			Return(nil)
+
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(newPostRequest(t, http.MethodPut, "/posts/3"), recorder)
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+
+		err := postHandlers.UpdatePost(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	})
+}
+
+func TestPostHandlers_DeletePost(t *testing.T) {
+	t.Run("It should delete the post", func(t *testing.T) {
+		engine, postHandlers, postService := newPostHandlers(t)
+
+		post := models.Post{Model: gorm.Model{ID: 3}, UserID: 7}
+
+		postService.
+			EXPECT().
+			GetPost(gomock.Any(), uint(3)).
+			Return(post, nil)
+
+		postService.
+			EXPECT().
+			Delete(gomock.Any(), &post).
+			Return(nil)
+
+		request := httptest.NewRequestWithContext(t.Context(), http.MethodDelete, "/posts/3", http.NoBody)
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(request, recorder)
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+
+		err := postHandlers.DeletePost(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Result().StatusCode)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"echo-app/internal/models"
 	"echo-app/internal/requests"
 	"echo-app/internal/responses"
+	"echo-app/internal/services/verification"
 
 	"github.com/labstack/echo/v4"
 )
@@ -19,18 +20,27 @@ type userRegisterer interface {
 	Register(ctx context.Context, request *requests.RegisterRequest) error
 }
 
+type emailVerifier interface {
+	Verify(ctx context.Context, token string) error
+	Resend(ctx context.Context, email string) error
+}
+
 type RegisterHandler struct {
 	userRegisterer userRegisterer
+	emailVerifier  emailVerifier
 }
 
-func NewRegisterHandler(userRegisterer userRegisterer) *RegisterHandler {
-	return &RegisterHandler{userRegisterer: userRegisterer}
+func NewRegisterHandler(userRegisterer userRegisterer, emailVerifier emailVerifier) *RegisterHandler {
+	return &RegisterHandler{
+		userRegisterer: userRegisterer,
+		emailVerifier:  emailVerifier,
+	}
 }
 
 // Register godoc
 //
 //	@Summary		Register
-//	@Description	New user registration
+//	@Description	New user registration. The account can't log in until the email is verified
 //	@ID				user-register
 //	@Tags			User Actions
 //	@Accept			json
@@ -62,3 +72,64 @@ func (h *RegisterHandler) Register(c echo.Context) error {
 
 	return responses.MessageResponse(c, http.StatusCreated, "User successfully created")
 }
+
+// VerifyEmail godoc
+//
+//	@Summary		Verify email
+//	@Description	Confirms the email address with the token sent on registration
+//	@ID				user-verify-email
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.VerifyEmailRequest	true	"Verification token"
+//	@Success		200		{object}	responses.Data
+//	@Failure		400		{object}	responses.Error
+//	@Router			/verify-email [post]
+func (h *RegisterHandler) VerifyEmail(c echo.Context) error {
+	verifyRequest := new(requests.VerifyEmailRequest)
+	if err := c.Bind(verifyRequest); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to bind request")
+	}
+
+	if err := verifyRequest.Validate(); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Required fields are empty or invalid")
+	}
+
+	err := h.emailVerifier.Verify(c.Request().Context(), verifyRequest.Token)
+	if errors.Is(err, verification.ErrInvalidToken) {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Invalid or expired verification token")
+	} else if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to verify email")
+	}
+
+	return responses.MessageResponse(c, http.StatusOK, "Email successfully verified")
+}
+
+// ResendVerification godoc
+//
+//	@Summary		Resend verification email
+//	@Description	Sends a new verification token. The response is the same for unknown or verified emails
+//	@ID				user-resend-verification
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.ResendVerificationRequest	true	"User's email"
+//	@Success		202		{object}	responses.Data
+//	@Failure		400		{object}	responses.Error
+//	@Router			/verify-email/resend [post]
+func (h *RegisterHandler) ResendVerification(c echo.Context) error {
+	resendRequest := new(requests.ResendVerificationRequest)
+	if err := c.Bind(resendRequest); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to bind request")
+	}
+
+	if err := resendRequest.Validate(); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Required fields are empty or invalid")
+	}
+
+	if err := h.emailVerifier.Resend(c.Request().Context(), resendRequest.Email); err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to send verification email")
+	}
+
+	return responses.MessageResponse(c, http.StatusAccepted, "Verification email sent if the account needs it")
+}
@@ -118,3 +118,103 @@ func (c *MockuserRegistererRegisterCall) DoAndReturn(f func(context.Context, *re
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// MockemailVerifier is a mock of emailVerifier interface.
+type MockemailVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockemailVerifierMockRecorder
+	isgomock struct{}
+}
+
+// MockemailVerifierMockRecorder is the mock recorder for MockemailVerifier.
+type MockemailVerifierMockRecorder struct {
+	mock *MockemailVerifier
+}
+
+// NewMockemailVerifier creates a new mock instance.
+func NewMockemailVerifier(ctrl *gomock.Controller) *MockemailVerifier {
+	mock := &MockemailVerifier{ctrl: ctrl}
+	mock.recorder = &MockemailVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockemailVerifier) EXPECT() *MockemailVerifierMockRecorder {
+	return m.recorder
+}
+
+// Resend mocks base method.
+func (m *MockemailVerifier) Resend(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resend", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resend indicates an expected call of Resend.
+func (mr *MockemailVerifierMockRecorder) Resend(ctx, email any) *MockemailVerifierResendCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resend", reflect.TypeOf((*MockemailVerifier)(nil).Resend), ctx, email)
+	return &MockemailVerifierResendCall{Call: call}
+}
+
+// MockemailVerifierResendCall wrap *gomock.Call
+type MockemailVerifierResendCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockemailVerifierResendCall) Return(arg0 error) *MockemailVerifierResendCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockemailVerifierResendCall) Do(f func(context.Context, string) error) *MockemailVerifierResendCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockemailVerifierResendCall) DoAndReturn(f func(context.Context, string) error) *MockemailVerifierResendCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Verify mocks base method.
+func (m *MockemailVerifier) Verify(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockemailVerifierMockRecorder) Verify(ctx, token any) *MockemailVerifierVerifyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockemailVerifier)(nil).Verify), ctx, token)
+	return &MockemailVerifierVerifyCall{Call: call}
+}
+
+// MockemailVerifierVerifyCall wrap *gomock.Call
+type MockemailVerifierVerifyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockemailVerifierVerifyCall) Return(arg0 error) *MockemailVerifierVerifyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockemailVerifierVerifyCall) Do(f func(context.Context, string) error) *MockemailVerifierVerifyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockemailVerifierVerifyCall) DoAndReturn(f func(context.Context, string) error) *MockemailVerifierVerifyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"echo-app/internal/models"
 	"echo-app/internal/requests"
 	"echo-app/internal/server/handlers"
+	"echo-app/internal/services/verification"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -20,21 +22,33 @@ import (
 func newRegisterHandler(t *testing.T) (*echo.Echo, *handlers.RegisterHandler, *MockuserRegisterer) {
 	t.Helper()
 
+	engine, registerHandler, userRegisterer, _ := newRegisterHandlerWithVerifier(t)
+
+	return engine, registerHandler, userRegisterer
+}
+
+func newRegisterHandlerWithVerifier(
+	t *testing.T,
+) (*echo.Echo, *handlers.RegisterHandler, *MockuserRegisterer, *MockemailVerifier) {
+	t.Helper()
+
 	ctrl := gomock.NewController(t)
 	userRegisterer := NewMockuserRegisterer(ctrl)
-	registerHandler := handlers.NewRegisterHandler(userRegisterer)
+	emailVerifier := NewMockemailVerifier(ctrl)
+	registerHandler := handlers.NewRegisterHandler(userRegisterer, emailVerifier)
 	engine := echo.New()
 
 	engine.POST("/register", registerHandler.Register)
+	engine.POST("/verify-email", registerHandler.VerifyEmail)
 
-	return engine, registerHandler, userRegisterer
+	return engine, registerHandler, userRegisterer, emailVerifier
 }
 
 func TestRegisterHandler_Register(t *testing.T) {
 	t.Run("It should return an error if failed to parse request", func(t *testing.T) {
 		engine, registerHandler, _ := newRegisterHandler(t)
 
-		request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/register", http.NoBody)
+		request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/register", strings.NewReader("{"))
 		request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 
 		recorder := httptest.NewRecorder()
@@ -92,7 +106,7 @@ func TestRegisterHandler_Register(t *testing.T) {
 
 		registerRequest := requests.RegisterRequest{
 			BasicAuth: requests.BasicAuth{
-				Email:    "example@email.com",
+				Email:    "example@example.com",
 				Password: "some-pass",
 			},
 			Name: "test name",
@@ -104,8 +118,8 @@ func TestRegisterHandler_Register(t *testing.T) {
 
 		userRegisterer.
 			EXPECT().
-			GetUserByEmail(gomock.Any(), "example@email.com").
-			Return(models.User{Email: "example@email.com"}, nil)
+			GetUserByEmail(gomock.Any(), "example@example.com").
+			Return(models.User{Email: "example@example.com"}, nil)
 
 		request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/register", buffer)
 		request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -131,7 +145,7 @@ func TestRegisterHandler_Register(t *testing.T) {
 
 		registerRequest := requests.RegisterRequest{
 			BasicAuth: requests.BasicAuth{
-				Email:    "example@email.com",
+				Email:    "example@example.com",
 				Password: "some-pass",
 			},
 			Name: "test name",
@@ -143,7 +157,7 @@ func TestRegisterHandler_Register(t *testing.T) {
 
 		userRegisterer.
 			EXPECT().
-			GetUserByEmail(gomock.Any(), "example@email.com").
+			GetUserByEmail(gomock.Any(), "example@example.com").
 			Return(models.User{}, models.ErrUserNotFound)
 
 		userRegisterer.
@@ -170,3 +184,66 @@ func TestRegisterHandler_Register(t *testing.T) {
 		assert.JSONEq(t, wantResponse, recorder.Body.String())
 	})
 }
+
+func TestRegisterHandler_VerifyEmail(t *testing.T) {
+	newVerifyRequest := func(t *testing.T, token string) *http.Request {
+		t.Helper()
+
+		buffer := new(bytes.Buffer)
+		err := json.NewEncoder(buffer).Encode(requests.VerifyEmailRequest{Token: token})
+		require.NoError(t, err)
+
+		request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/verify-email", buffer)
+		request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		return request
+	}
+
+	t.Run("It should return an error if the token is invalid", func(t *testing.T) {
+		engine, registerHandler, _, emailVerifier := newRegisterHandlerWithVerifier(t)
+
+		emailVerifier.
+			EXPECT().
+			Verify(gomock.Any(), "some-token").
+			Return(verification.ErrInvalidToken)
+
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(newVerifyRequest(t, "some-token"), recorder)
+
+		err := registerHandler.VerifyEmail(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+
+		wantResponse := `{
+			"code": 400,
+			"error": "Invalid or expired verification token"
+		}`
+
+		assert.JSONEq(t, wantResponse, recorder.Body.String())
+	})
+
+	t.Run("It should verify the email", func(t *testing.T) {
+		engine, registerHandler, _, emailVerifier := newRegisterHandlerWithVerifier(t)
+
+		emailVerifier.
+			EXPECT().
+			Verify(gomock.Any(), "some-token").
+			Return(nil)
+
+		recorder := httptest.NewRecorder()
+		c := engine.NewContext(newVerifyRequest(t, "some-token"), recorder)
+
+		err := registerHandler.VerifyEmail(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+		wantResponse := `{
+			"code": 200,
+			"message": "Email successfully verified"
+		}`
+
+		assert.JSONEq(t, wantResponse, recorder.Body.String())
+	})
+}
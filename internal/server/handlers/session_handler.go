@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"echo-app/internal/models"
+	"echo-app/internal/responses"
+	"echo-app/internal/server/middleware"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=session_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type sessionService interface {
+	List(ctx context.Context, userID uint) ([]models.Session, error)
+	Revoke(ctx context.Context, userID uint, id uuid.UUID) error
+	RevokeUser(ctx context.Context, userID uint) error
+}
+
+type SessionHandlers struct {
+	sessionService sessionService
+}
+
+func NewSessionHandlers(sessionService sessionService) SessionHandlers {
+	return SessionHandlers{sessionService: sessionService}
+}
+
+// GetSessions godoc
+//
+//	@Summary		Get sessions
+//	@Description	Get the active sessions of the current user, the last used first
+//	@ID				sessions-get
+//	@Tags			Sessions
+//	@Produce		json
+//	@Success		200	{array}		responses.SessionResponse
+//	@Failure		401	{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/sessions [get]
+func (h *SessionHandlers) GetSessions(c echo.Context) error {
+	user, err := middleware.CurrentUser(c)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	current, err := middleware.CurrentSession(c)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	sessions, err := h.sessionService.List(c.Request().Context(), user.ID)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to get sessions")
+	}
+
+	return responses.Response(c, http.StatusOK, responses.NewSessionsResponse(sessions, current.ID))
+}
+
+// RevokeSession godoc
+//
+//	@Summary		Revoke session
+//	@Description	Revoke a session of the current user, revoking the current session logs out
+//	@ID				sessions-revoke
+//	@Tags			Sessions
+//	@Param			id	path	string	true	"Session ID"
+//	@Success		204
+//	@Failure		400	{object}	responses.Error
+//	@Failure		401	{object}	responses.Error
+//	@Failure		404	{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/sessions/{id} [delete]
+func (h *SessionHandlers) RevokeSession(c echo.Context) error {
+	user, err := middleware.CurrentUser(c)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to parse session id: "+err.Error())
+	}
+
+	err = h.sessionService.Revoke(c.Request().Context(), user.ID, id)
+	if errors.Is(err, models.ErrSessionNotFound) {
+		return responses.ErrorResponse(c, http.StatusNotFound, "Session not found")
+	} else if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to revoke session")
+	}
+
+	if current, err := middleware.CurrentSession(c); err == nil && current.ID == id {
+		clearAuthCookies(c)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RevokeSessions godoc
+//
+//	@Summary		Revoke all sessions
+//	@Description	Revoke every session of the current user including the current one, which logs out everywhere
+//	@ID				sessions-revoke-all
+//	@Tags			Sessions
+//	@Success		204
+//	@Failure		401	{object}	responses.Error
+//	@Security		ApiKeyAuth
+//	@Router			/sessions [delete]
+func (h *SessionHandlers) RevokeSessions(c echo.Context) error {
+	user, err := middleware.CurrentUser(c)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+	}
+
+	if err := h.sessionService.RevokeUser(c.Request().Context(), user.ID); err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to revoke sessions")
+	}
+
+	clearAuthCookies(c)
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: session_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=session_handler.go -destination=session_handler_mock_test.go -package=handlers_test -typed=true
+//
+
+// Package handlers_test is a generated GoMock package.
+package handlers_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "echo-app/internal/models"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MocksessionService is a mock of sessionService interface.
+type MocksessionService struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MocksessionServiceMockRecorder is the mock recorder for MocksessionService.
+type MocksessionServiceMockRecorder struct {
+	mock *MocksessionService
+}
+
+// NewMocksessionService creates a new mock instance.
+func NewMocksessionService(ctrl *gomock.Controller) *MocksessionService {
+	mock := &MocksessionService{ctrl: ctrl}
+	mock.recorder = &MocksessionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionService) EXPECT() *MocksessionServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MocksessionService) List(ctx context.Context, userID uint) ([]models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID)
+	ret0, _ := ret[0].([]models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MocksessionServiceMockRecorder) List(ctx, userID any) *MocksessionServiceListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MocksessionService)(nil).List), ctx, userID)
+	return &MocksessionServiceListCall{Call: call}
+}
+
+// MocksessionServiceListCall wrap *gomock.Call
+type MocksessionServiceListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionServiceListCall) Return(arg0 []models.Session, arg1 error) *MocksessionServiceListCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionServiceListCall) Do(f func(context.Context, uint) ([]models.Session, error)) *MocksessionServiceListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionServiceListCall) DoAndReturn(f func(context.Context, uint) ([]models.Session, error)) *MocksessionServiceListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MocksessionService) Revoke(ctx context.Context, userID uint, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MocksessionServiceMockRecorder) Revoke(ctx, userID, id any) *MocksessionServiceRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MocksessionService)(nil).Revoke), ctx, userID, id)
+	return &MocksessionServiceRevokeCall{Call: call}
+}
+
+// MocksessionServiceRevokeCall wrap *gomock.Call
+type MocksessionServiceRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionServiceRevokeCall) Return(arg0 error) *MocksessionServiceRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionServiceRevokeCall) Do(f func(context.Context, uint, uuid.UUID) error) *MocksessionServiceRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionServiceRevokeCall) DoAndReturn(f func(context.Context, uint, uuid.UUID) error) *MocksessionServiceRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeUser mocks base method.
+func (m *MocksessionService) RevokeUser(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUser indicates an expected call of RevokeUser.
+func (mr *MocksessionServiceMockRecorder) RevokeUser(ctx, userID any) *MocksessionServiceRevokeUserCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUser", reflect.TypeOf((*MocksessionService)(nil).RevokeUser), ctx, userID)
+	return &MocksessionServiceRevokeUserCall{Call: call}
+}
+
+// MocksessionServiceRevokeUserCall wrap *gomock.Call
+type MocksessionServiceRevokeUserCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionServiceRevokeUserCall) Return(arg0 error) *MocksessionServiceRevokeUserCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionServiceRevokeUserCall) Do(f func(context.Context, uint) error) *MocksessionServiceRevokeUserCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionServiceRevokeUserCall) DoAndReturn(f func(context.Context, uint) error) *MocksessionServiceRevokeUserCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
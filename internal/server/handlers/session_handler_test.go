@@ -0,0 +1,170 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"echo-app/internal/models"
+	"echo-app/internal/server/handlers"
+	"echo-app/internal/server/middleware"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+var (
+	currentSessionID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	otherSessionID   = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+)
+
+func newSessionHandlers(t *testing.T) (*echo.Echo, handlers.SessionHandlers, *MocksessionService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	sessionService := NewMocksessionService(ctrl)
+	sessionHandlers := handlers.NewSessionHandlers(sessionService)
+	engine := echo.New()
+
+	return engine, sessionHandlers, sessionService
+}
+
+// newSessionContext returns the context of an authenticated request in the current session.
+func newSessionContext(t *testing.T, engine *echo.Echo, method string, recorder *httptest.ResponseRecorder) echo.Context {
+	t.Helper()
+
+	request := httptest.NewRequestWithContext(t.Context(), method, "/sessions", nil)
+
+	c := engine.NewContext(request, recorder)
+	middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+	middleware.SetCurrentSession(c, models.Session{ID: currentSessionID, UserID: 7})
+
+	return c
+}
+
+func TestSessionHandlers_GetSessions(t *testing.T) {
+	engine, sessionHandlers, sessionService := newSessionHandlers(t)
+
+	lastSeenAt := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	sessionService.
+		EXPECT().
+		List(gomock.Any(), uint(7)).
+		Return([]models.Session{
+			{ID: currentSessionID, Device: "Firefox on Linux", IP: "203.0.113.7", CreatedAt: lastSeenAt, LastSeenAt: lastSeenAt},
+			{ID: otherSessionID, Device: "curl", IP: "198.51.100.1", CreatedAt: lastSeenAt, LastSeenAt: lastSeenAt},
+		}, nil)
+
+	recorder := httptest.NewRecorder()
+
+	err := sessionHandlers.GetSessions(newSessionContext(t, engine, http.MethodGet, recorder))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+	wantResponse := `[
+		{
+			"id": "11111111-1111-1111-1111-111111111111",
+			"device": "Firefox on Linux",
+			"ip": "203.0.113.7",
+			"userAgent": "",
+			"createdAt": "2026-10-17T12:00:00Z",
+			"lastSeenAt": "2026-10-17T12:00:00Z",
+			"current": true
+		},
+		{
+			"id": "22222222-2222-2222-2222-222222222222",
+			"device": "curl",
+			"ip": "198.51.100.1",
+			"userAgent": "",
+			"createdAt": "2026-10-17T12:00:00Z",
+			"lastSeenAt": "2026-10-17T12:00:00Z",
+			"current": false
+		}
+	]`
+
+	assert.JSONEq(t, wantResponse, recorder.Body.String())
+}
+
+func TestSessionHandlers_RevokeSession(t *testing.T) {
+	t.Run("It should revoke another session and keep the cookies", func(t *testing.T) {
+		engine, sessionHandlers, sessionService := newSessionHandlers(t)
+
+		sessionService.
+			EXPECT().
+			Revoke(gomock.Any(), uint(7), otherSessionID).
+			Return(nil)
+
+		recorder := httptest.NewRecorder()
+		c := newSessionContext(t, engine, http.MethodDelete, recorder)
+		c.SetParamNames("id")
+		c.SetParamValues(otherSessionID.String())
+
+		err := sessionHandlers.RevokeSession(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Result().StatusCode)
+		assert.Empty(t, recorder.Result().Cookies())
+	})
+
+	t.Run("It should clear the cookies when revoking the current session", func(t *testing.T) {
+		engine, sessionHandlers, sessionService := newSessionHandlers(t)
+
+		sessionService.
+			EXPECT().
+			Revoke(gomock.Any(), uint(7), currentSessionID).
+			Return(nil)
+
+		recorder := httptest.NewRecorder()
+		c := newSessionContext(t, engine, http.MethodDelete, recorder)
+		c.SetParamNames("id")
+		c.SetParamValues(currentSessionID.String())
+
+		err := sessionHandlers.RevokeSession(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Result().StatusCode)
+		assert.Len(t, recorder.Result().Cookies(), 2)
+	})
+
+	t.Run("It should return not found for an unknown session", func(t *testing.T) {
+		engine, sessionHandlers, sessionService := newSessionHandlers(t)
+
+		sessionService.
+			EXPECT().
+			Revoke(gomock.Any(), uint(7), otherSessionID).
+			Return(models.ErrSessionNotFound)
+
+		recorder := httptest.NewRecorder()
+		c := newSessionContext(t, engine, http.MethodDelete, recorder)
+		c.SetParamNames("id")
+		c.SetParamValues(otherSessionID.String())
+
+		err := sessionHandlers.RevokeSession(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Result().StatusCode)
+	})
+}
+
+func TestSessionHandlers_RevokeSessions(t *testing.T) {
+	engine, sessionHandlers, sessionService := newSessionHandlers(t)
+
+	sessionService.
+		EXPECT().
+		RevokeUser(gomock.Any(), uint(7)).
+		Return(nil)
+
+	recorder := httptest.NewRecorder()
+
+	err := sessionHandlers.RevokeSessions(newSessionContext(t, engine, http.MethodDelete, recorder))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, recorder.Result().StatusCode)
+	assert.Len(t, recorder.Result().Cookies(), 2)
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"echo-app/internal/models"
+	"echo-app/internal/requests"
+	"echo-app/internal/responses"
+	"echo-app/internal/services/refresh"
+	"echo-app/internal/services/session"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type refreshTokenRotator interface {
+	Rotate(ctx context.Context, value string) (models.RefreshToken, refresh.Token, error)
+}
+
+type sessionValidator interface {
+	Validate(ctx context.Context, id uuid.UUID, userID uint, client session.Client) (models.Session, error)
+}
+
+type userByIDGetter interface {
+	GetByID(ctx context.Context, id uint) (models.User, error)
+}
+
+type TokenHandler struct {
+	accessTokens  accessTokenCreator
+	refreshTokens refreshTokenRotator
+	sessions      sessionValidator
+	users         userByIDGetter
+}
+
+func NewTokenHandler(
+	accessTokens accessTokenCreator,
+	refreshTokens refreshTokenRotator,
+	sessions sessionValidator,
+	users userByIDGetter,
+) *TokenHandler {
+	return &TokenHandler{
+		accessTokens:  accessTokens,
+		refreshTokens: refreshTokens,
+		sessions:      sessions,
+		users:         users,
+	}
+}
+
+// Refresh godoc
+//
+//	@Summary		Refresh access token
+//	@Description	Rotates the refresh token and issues a new access token.
+//	@Description	The refresh token is taken from the body or, if absent, from the refresh_token cookie.
+//	@ID				user-refresh
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.RefreshRequest	false	"Refresh token"
+//	@Success		200		{object}	responses.LoginResponse
+//	@Failure		400		{object}	responses.Error
+//	@Failure		401		{object}	responses.Error
+//	@Router			/refresh [post]
+func (h *TokenHandler) Refresh(c echo.Context) error {
+	var refreshRequest requests.RefreshRequest
+	if err := c.Bind(&refreshRequest); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Failed to bind request")
+	}
+
+	if refreshRequest.Token == "" {
+		if cookie, err := c.Cookie(refreshTokenCookie); err == nil {
+			refreshRequest.Token = cookie.Value
+		}
+	}
+
+	if err := refreshRequest.Validate(); err != nil {
+		return responses.ErrorResponse(c, http.StatusBadRequest, "Refresh token is required")
+	}
+
+	rotated, refreshToken, err := h.refreshTokens.Rotate(c.Request().Context(), refreshRequest.Token)
+	if err != nil {
+		clearAuthCookies(c)
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Invalid refresh token")
+	}
+
+	// The refresh token family has the ID of the session it was issued in
+	_, err = h.sessions.Validate(c.Request().Context(), rotated.FamilyID, rotated.UserID, sessionClient(c))
+	if errors.Is(err, session.ErrSessionRevoked) {
+		clearAuthCookies(c)
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "Session is revoked or expired")
+	} else if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to validate session")
+	}
+
+	user, err := h.users.GetByID(c.Request().Context(), rotated.UserID)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusUnauthorized, "User not found")
+	}
+
+	response, err := newLoginResponse(h.accessTokens, &user, rotated.FamilyID, refreshToken)
+	if err != nil {
+		return responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to create access token")
+	}
+
+	setAuthCookies(c, response, refreshToken)
+
+	return responses.Response(c, http.StatusOK, response)
+}
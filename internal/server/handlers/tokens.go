@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"echo-app/internal/models"
+	"echo-app/internal/responses"
+	"echo-app/internal/services/refresh"
+	"echo-app/internal/services/session"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=tokens_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+const (
+	accessTokenCookie  = "access_token"
+	refreshTokenCookie = "refresh_token"
+)
+
+type accessTokenCreator interface {
+	CreateAccessToken(user *models.User, sessionID uuid.UUID) (string, time.Time, error)
+}
+
+type sessionStarter interface {
+	Start(ctx context.Context, userID uint, client session.Client, oidcProvider, oidcSessionID string) (models.Session, refresh.Token, error)
+}
+
+// issueTokens starts a session of the user on the client of the request and issues the tokens of it.
+// Logins with OIDC pass the identity provider and its session ID, others empty ones.
+func issueTokens(
+	c echo.Context,
+	accessTokens accessTokenCreator,
+	sessions sessionStarter,
+	user *models.User,
+	oidcProvider, oidcSessionID string,
+) (*responses.LoginResponse, refresh.Token, error) {
+	userSession, refreshToken, err := sessions.Start(c.Request().Context(), user.ID, sessionClient(c), oidcProvider, oidcSessionID)
+	if err != nil {
+		return nil, refresh.Token{}, fmt.Errorf("start session: %w", err)
+	}
+
+	response, err := newLoginResponse(accessTokens, user, userSession.ID, refreshToken)
+	if err != nil {
+		return nil, refresh.Token{}, err
+	}
+
+	return response, refreshToken, nil
+}
+
+func newLoginResponse(
+	accessTokens accessTokenCreator,
+	user *models.User,
+	sessionID uuid.UUID,
+	refreshToken refresh.Token,
+) (*responses.LoginResponse, error) {
+	accessToken, expiresAt, err := accessTokens.CreateAccessToken(user, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("create access token: %w", err)
+	}
+
+	return responses.NewLoginResponse(accessToken, refreshToken.Value, expiresAt.Unix()), nil
+}
+
+// sessionClient returns the client of the request.
+func sessionClient(c echo.Context) session.Client {
+	return session.Client{IP: c.RealIP(), UserAgent: c.Request().UserAgent()}
+}
+
+// setAuthCookies stores the issued tokens in HttpOnly cookies for browser clients.
+func setAuthCookies(c echo.Context, response *responses.LoginResponse, refreshToken refresh.Token) {
+	c.SetCookie(&http.Cookie{
+		Name:     accessTokenCookie,
+		Value:    response.AccessToken,
+		Path:     "/",
+		Expires:  time.Unix(response.Exp, 0),
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	c.SetCookie(&http.Cookie{
+		Name:     refreshTokenCookie,
+		Value:    refreshToken.Value,
+		Path:     "/",
+		Expires:  refreshToken.ExpiresAt,
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+func clearAuthCookies(c echo.Context) {
+	for _, name := range []string{accessTokenCookie, refreshTokenCookie} {
+		clearCookie(c, name)
+	}
+}
+
+func clearCookie(c echo.Context, name string) {
+	c.SetCookie(&http.Cookie{
+		Name:     name,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+	})
+}
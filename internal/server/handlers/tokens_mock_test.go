@@ -0,0 +1,150 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: tokens.go
+//
+// Generated by this command:
+//
+//	mockgen -source=tokens.go -destination=tokens_mock_test.go -package=handlers_test -typed=true
+//
+
+// Package handlers_test is a generated GoMock package.
+package handlers_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "echo-app/internal/models"
+	refresh "echo-app/internal/services/refresh"
+	session "echo-app/internal/services/session"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockaccessTokenCreator is a mock of accessTokenCreator interface.
+type MockaccessTokenCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockaccessTokenCreatorMockRecorder
+	isgomock struct{}
+}
+
+// MockaccessTokenCreatorMockRecorder is the mock recorder for MockaccessTokenCreator.
+type MockaccessTokenCreatorMockRecorder struct {
+	mock *MockaccessTokenCreator
+}
+
+// NewMockaccessTokenCreator creates a new mock instance.
+func NewMockaccessTokenCreator(ctrl *gomock.Controller) *MockaccessTokenCreator {
+	mock := &MockaccessTokenCreator{ctrl: ctrl}
+	mock.recorder = &MockaccessTokenCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockaccessTokenCreator) EXPECT() *MockaccessTokenCreatorMockRecorder {
+	return m.recorder
+}
+
+// CreateAccessToken mocks base method.
+func (m *MockaccessTokenCreator) CreateAccessToken(user *models.User, sessionID uuid.UUID) (string, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccessToken", user, sessionID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAccessToken indicates an expected call of CreateAccessToken.
+func (mr *MockaccessTokenCreatorMockRecorder) CreateAccessToken(user, sessionID any) *MockaccessTokenCreatorCreateAccessTokenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessToken", reflect.TypeOf((*MockaccessTokenCreator)(nil).CreateAccessToken), user, sessionID)
+	return &MockaccessTokenCreatorCreateAccessTokenCall{Call: call}
+}
+
+// MockaccessTokenCreatorCreateAccessTokenCall wrap *gomock.Call
+type MockaccessTokenCreatorCreateAccessTokenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockaccessTokenCreatorCreateAccessTokenCall) Return(arg0 string, arg1 time.Time, arg2 error) *MockaccessTokenCreatorCreateAccessTokenCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockaccessTokenCreatorCreateAccessTokenCall) Do(f func(*models.User, uuid.UUID) (string, time.Time, error)) *MockaccessTokenCreatorCreateAccessTokenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockaccessTokenCreatorCreateAccessTokenCall) DoAndReturn(f func(*models.User, uuid.UUID) (string, time.Time, error)) *MockaccessTokenCreatorCreateAccessTokenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MocksessionStarter is a mock of sessionStarter interface.
+type MocksessionStarter struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionStarterMockRecorder
+	isgomock struct{}
+}
+
+// MocksessionStarterMockRecorder is the mock recorder for MocksessionStarter.
+type MocksessionStarterMockRecorder struct {
+	mock *MocksessionStarter
+}
+
+// NewMocksessionStarter creates a new mock instance.
+func NewMocksessionStarter(ctrl *gomock.Controller) *MocksessionStarter {
+	mock := &MocksessionStarter{ctrl: ctrl}
+	mock.recorder = &MocksessionStarterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionStarter) EXPECT() *MocksessionStarterMockRecorder {
+	return m.recorder
+}
+
+// Start mocks base method.
+func (m *MocksessionStarter) Start(ctx context.Context, userID uint, client session.Client, oidcProvider, oidcSessionID string) (models.Session, refresh.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, userID, client, oidcProvider, oidcSessionID)
+	ret0, _ := ret[0].(models.Session)
+	ret1, _ := ret[1].(refresh.Token)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Start indicates an expected call of Start.
+func (mr *MocksessionStarterMockRecorder) Start(ctx, userID, client, oidcProvider, oidcSessionID any) *MocksessionStarterStartCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MocksessionStarter)(nil).Start), ctx, userID, client, oidcProvider, oidcSessionID)
+	return &MocksessionStarterStartCall{Call: call}
+}
+
+// MocksessionStarterStartCall wrap *gomock.Call
+type MocksessionStarterStartCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionStarterStartCall) Return(arg0 models.Session, arg1 refresh.Token, arg2 error) *MocksessionStarterStartCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionStarterStartCall) Do(f func(context.Context, uint, session.Client, string, string) (models.Session, refresh.Token, error)) *MocksessionStarterStartCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionStarterStartCall) DoAndReturn(f func(context.Context, uint, session.Client, string, string) (models.Session, refresh.Token, error)) *MocksessionStarterStartCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
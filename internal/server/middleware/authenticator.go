@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"echo-app/internal/models"
+	"echo-app/internal/services/pat"
+	"echo-app/internal/services/session"
+	"echo-app/internal/services/token"
+
+	"github.com/google/uuid"
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	claimsContextKey              = "token_claims"
+	userContextKey                = "user"
+	sessionContextKey             = "session"
+	personalAccessTokenContextKey = "personal_access_token"
+)
+
+var ErrUnauthenticated = errors.New("request is not authenticated")
+
+type accessTokenParser interface {
+	ParseAccessToken(raw string) (*token.Claims, error)
+}
+
+type userGetter interface {
+	GetByID(ctx context.Context, id uint) (models.User, error)
+}
+
+type sessionValidator interface {
+	Validate(ctx context.Context, id uuid.UUID, userID uint, client session.Client) (models.Session, error)
+}
+
+type personalAccessTokenAuthenticator interface {
+	Authenticate(ctx context.Context, value string) (models.PersonalAccessToken, error)
+}
+
+// authenticator loads the user the access token was issued for, so handlers can work with models.User.
+type authenticator struct {
+	userGetter     userGetter
+	sessions       sessionValidator
+	personalTokens personalAccessTokenAuthenticator
+}
+
+// NewAuthenticator validates the access token from the Authorization header or the access_token cookie
+// together with the session it was issued in, and stores the authenticated user and the session in the context.
+// Personal access tokens are accepted as bearer tokens in the Authorization header too, they authenticate
+// the user without a session and only for the scopes of the token, see RequireScope.
+// Use CurrentUser, CurrentSession and CurrentPersonalAccessToken to get them in handlers.
+func NewAuthenticator(
+	tokens accessTokenParser,
+	userGetter userGetter,
+	sessions sessionValidator,
+	personalTokens personalAccessTokenAuthenticator,
+) echo.MiddlewareFunc {
+	jwtMiddleware := echojwt.WithConfig(echojwt.Config{
+		TokenLookup: "header:Authorization:Bearer ,cookie:access_token",
+		ContextKey:  claimsContextKey,
+		ParseTokenFunc: func(_ echo.Context, auth string) (any, error) {
+			return tokens.ParseAccessToken(auth)
+		},
+	})
+
+	middleware := authenticator{userGetter: userGetter, sessions: sessions, personalTokens: personalTokens}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withAccessToken := jwtMiddleware(middleware.handle(next))
+		withPersonalAccessToken := middleware.handlePersonalAccessToken(next)
+
+		return func(c echo.Context) error {
+			if pat.IsToken(bearerToken(c)) {
+				return withPersonalAccessToken(c)
+			}
+
+			return withAccessToken(c)
+		}
+	}
+}
+
+func (a authenticator) handle(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		claims, ok := c.Get(claimsContextKey).(*token.Claims)
+		if !ok {
+			return echo.NewHTTPError(http.StatusUnauthorized, "missing token claims")
+		}
+
+		sessionID, err := uuid.Parse(claims.SessionID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "missing session").SetInternal(err)
+		}
+
+		client := session.Client{IP: c.RealIP(), UserAgent: c.Request().UserAgent()}
+
+		userSession, err := a.sessions.Validate(c.Request().Context(), sessionID, claims.UserID, client)
+		if errors.Is(err, session.ErrSessionRevoked) {
+			return echo.NewHTTPError(http.StatusUnauthorized, "session is revoked or expired")
+		} else if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "failed to validate session").SetInternal(err)
+		}
+
+		user, err := a.userGetter.GetByID(c.Request().Context(), claims.UserID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "unknown user").SetInternal(err)
+		}
+
+		SetCurrentUser(c, user)
+		SetCurrentSession(c, userSession)
+
+		return next(c)
+	}
+}
+
+func (a authenticator) handlePersonalAccessToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		personalToken, err := a.personalTokens.Authenticate(c.Request().Context(), bearerToken(c))
+		if errors.Is(err, pat.ErrTokenInvalid) {
+			return echo.NewHTTPError(http.StatusUnauthorized, "personal access token is unknown, revoked or expired")
+		} else if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "failed to validate personal access token").SetInternal(err)
+		}
+
+		user, err := a.userGetter.GetByID(c.Request().Context(), personalToken.UserID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "unknown user").SetInternal(err)
+		}
+
+		SetCurrentUser(c, user)
+		SetCurrentPersonalAccessToken(c, personalToken)
+
+		return next(c)
+	}
+}
+
+// bearerToken returns the bearer token of the Authorization header, or an empty string if there is none.
+func bearerToken(c echo.Context) string {
+	value, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+	if !ok {
+		return ""
+	}
+
+	return value
+}
+
+// SetCurrentUser stores the authenticated user in the context.
+func SetCurrentUser(c echo.Context, user models.User) {
+	c.Set(userContextKey, user)
+}
+
+// CurrentUser returns the user authenticated by NewAuthenticator.
+func CurrentUser(c echo.Context) (models.User, error) {
+	user, ok := c.Get(userContextKey).(models.User)
+	if !ok {
+		return models.User{}, ErrUnauthenticated
+	}
+
+	return user, nil
+}
+
+// SetCurrentSession stores the session of the authenticated user in the context.
+func SetCurrentSession(c echo.Context, userSession models.Session) {
+	c.Set(sessionContextKey, userSession)
+}
+
+// CurrentSession returns the session authenticated by NewAuthenticator.
+func CurrentSession(c echo.Context) (models.Session, error) {
+	userSession, ok := c.Get(sessionContextKey).(models.Session)
+	if !ok {
+		return models.Session{}, ErrUnauthenticated
+	}
+
+	return userSession, nil
+}
+
+// SetCurrentPersonalAccessToken stores the personal access token the request is authenticated with in the context.
+func SetCurrentPersonalAccessToken(c echo.Context, personalToken models.PersonalAccessToken) {
+	c.Set(personalAccessTokenContextKey, personalToken)
+}
+
+// CurrentPersonalAccessToken returns the personal access token authenticated by NewAuthenticator.
+// It fails for requests authenticated with an access token of a session.
+func CurrentPersonalAccessToken(c echo.Context) (models.PersonalAccessToken, error) {
+	personalToken, ok := c.Get(personalAccessTokenContextKey).(models.PersonalAccessToken)
+	if !ok {
+		return models.PersonalAccessToken{}, ErrUnauthenticated
+	}
+
+	return personalToken, nil
+}
@@ -0,0 +1,207 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"echo-app/internal/models"
+	"echo-app/internal/permify"
+	"echo-app/internal/tenant"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// DomainHeader selects the domain a request works in, see PermissionGuard.ScopeToDomain.
+const DomainHeader = "X-Domain-ID"
+
+// CheckFunc checks a permission in the authorization service, e.g. permify.Client.Check.
+type CheckFunc func(ctx context.Context, request permify.CheckRequest) (bool, error)
+
+// EntityIDResolver resolves the ID of the entity the permission is checked on from the request.
+// It may return an *echo.HTTPError to control the response status.
+type EntityIDResolver func(c echo.Context) (string, error)
+
+// SnapTokenLookup returns the Permify snap token stored on the entity, or an empty string if there is none.
+type SnapTokenLookup func(ctx context.Context, entityID string) (string, error)
+
+// PermissionGuard declares Permify permission requirements on routes.
+// Checks on entity types with a snap token lookup are made at least as fresh as the last relationship change
+// of the entity, so changes made by the previous request are visible.
+type PermissionGuard struct {
+	check      CheckFunc
+	snapTokens map[string]SnapTokenLookup
+}
+
+func NewPermissionGuard(check CheckFunc, snapTokens map[string]SnapTokenLookup) PermissionGuard {
+	return PermissionGuard{check: check, snapTokens: snapTokens}
+}
+
+// Require allows the request only if the current user has the permission on the entity resolved from the request.
+// It has to run after NewAuthenticator.
+func (g PermissionGuard) Require(entityType string, resolveID EntityIDResolver, permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, err := CurrentUser(c)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+			}
+
+			entityID, err := resolveID(c)
+			if err != nil {
+				if httpErr := (*echo.HTTPError)(nil); errors.As(err, &httpErr) {
+					return httpErr
+				}
+
+				return echo.NewHTTPError(http.StatusBadRequest, "failed to resolve "+entityType).SetInternal(err)
+			}
+
+			if err := g.authorize(c, user, entityType, entityID, permission); err != nil {
+				return err
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// ScopeToDomain scopes the request to the domain selected with the DomainHeader, or to the domain of the user
+// when the header is missing, see tenant.WithDomain. The user needs the view permission on the domain.
+// It has to run after NewAuthenticator.
+func (g PermissionGuard) ScopeToDomain() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, err := CurrentUser(c)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+			}
+
+			var domainID uuid.UUID
+			if header := c.Request().Header.Get(DomainHeader); header != "" {
+				domainID, err = uuid.Parse(header)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, "invalid "+DomainHeader+" header")
+				}
+			} else if user.DomainID != nil {
+				domainID = *user.DomainID
+			} else {
+				return echo.NewHTTPError(http.StatusForbidden, "a domain is required, select one with the "+DomainHeader+" header")
+			}
+
+			if err := g.authorize(c, user, "domain", domainID.String(), "view"); err != nil {
+				return err
+			}
+
+			c.SetRequest(c.Request().WithContext(tenant.WithDomain(c.Request().Context(), domainID)))
+
+			return next(c)
+		}
+	}
+}
+
+// authorize returns the HTTP error to respond with unless the user has the permission on the entity.
+func (g PermissionGuard) authorize(c echo.Context, user models.User, entityType, entityID, permission string) error {
+	var (
+		snapToken string
+		err       error
+	)
+
+	if lookup, ok := g.snapTokens[entityType]; ok {
+		snapToken, err = lookup(c.Request().Context(), entityID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "failed to check permission").SetInternal(err)
+		}
+	}
+
+	allowed, err := g.check(c.Request().Context(), permify.CheckRequest{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Permission: permission,
+		UserID:     strconv.FormatUint(uint64(user.ID), 10),
+		SnapToken:  snapToken,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to check permission").SetInternal(err)
+	}
+
+	if !allowed {
+		return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("%s permission on %s is required", permission, entityType))
+	}
+
+	return nil
+}
+
+// PathParam resolves the entity ID from the path parameter.
+func PathParam(name string) EntityIDResolver {
+	return func(c echo.Context) (string, error) {
+		value := c.Param(name)
+		if value == "" {
+			return "", echo.NewHTTPError(http.StatusBadRequest, "missing path parameter "+name)
+		}
+
+		return value, nil
+	}
+}
+
+// CurrentDomain resolves the domain the request is scoped to, it has to run after PermissionGuard.ScopeToDomain.
+func CurrentDomain(c echo.Context) (string, error) {
+	domainID, err := tenant.DomainID(c.Request().Context())
+	if err != nil {
+		return "", echo.NewHTTPError(http.StatusForbidden, "a domain is required, select one with the "+DomainHeader+" header")
+	}
+
+	return domainID.String(), nil
+}
+
+// BodyField resolves the entity ID from a top level field of the JSON body.
+// The body is restored, so the handler can still bind it.
+func BodyField(field string) EntityIDResolver {
+	return func(c echo.Context) (string, error) {
+		request := c.Request()
+		if request.Body == nil {
+			return "", echo.NewHTTPError(http.StatusBadRequest, "missing request body")
+		}
+
+		rawBody, err := io.ReadAll(request.Body)
+		if err != nil {
+			return "", fmt.Errorf("read request body: %w", err)
+		}
+
+		request.Body = io.NopCloser(bytes.NewReader(rawBody))
+
+		var body map[string]any
+		if err := json.Unmarshal(rawBody, &body); err != nil {
+			return "", echo.NewHTTPError(http.StatusBadRequest, "request body is not a JSON object")
+		}
+
+		switch value := body[field].(type) {
+		case string:
+			if value != "" {
+				return value, nil
+			}
+		case float64:
+			return strconv.FormatFloat(value, 'f', -1, 64), nil
+		}
+
+		return "", echo.NewHTTPError(http.StatusBadRequest, "missing field "+field)
+	}
+}
+
+// Lookup resolves the entity ID with a lookup by the value of the path parameter,
+// e.g. the domain of a post by the post ID.
+func Lookup(param string, lookup func(ctx context.Context, value string) (string, error)) EntityIDResolver {
+	return func(c echo.Context) (string, error) {
+		value, err := PathParam(param)(c)
+		if err != nil {
+			return "", err
+		}
+
+		return lookup(c.Request().Context(), value)
+	}
+}
@@ -0,0 +1,277 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"echo-app/internal/models"
+	"echo-app/internal/permify"
+	"echo-app/internal/server/middleware"
+	"echo-app/internal/tenant"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newGuardedContext(t *testing.T, body string) echo.Context {
+	t.Helper()
+
+	request := httptest.NewRequestWithContext(t.Context(), http.MethodPut, "/posts/3", strings.NewReader(body))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	c := echo.New().NewContext(request, httptest.NewRecorder())
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+
+	return c
+}
+
+func ok(c echo.Context) error {
+	return c.NoContent(http.StatusNoContent)
+}
+
+func TestPermissionGuard_Require(t *testing.T) {
+	t.Run("It should check the permission of the current user on the resolved entity", func(t *testing.T) {
+		var got permify.CheckRequest
+		guard := middleware.NewPermissionGuard(func(_ context.Context, request permify.CheckRequest) (bool, error) {
+			got = request
+			return true, nil
+		}, nil)
+
+		c := newGuardedContext(t, "")
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+
+		err := guard.Require("post", middleware.PathParam("id"), "edit")(ok)(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, permify.CheckRequest{EntityType: "post", EntityID: "3", Permission: "edit", UserID: "7"}, got)
+	})
+
+	t.Run("It should deny the request", func(t *testing.T) {
+		guard := middleware.NewPermissionGuard(func(context.Context, permify.CheckRequest) (bool, error) {
+			return false, nil
+		}, nil)
+
+		c := newGuardedContext(t, "")
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+
+		err := guard.Require("post", middleware.PathParam("id"), "edit")(ok)(c)
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusForbidden, httpErr.Code)
+	})
+
+	t.Run("It should reject an unauthenticated request", func(t *testing.T) {
+		guard := middleware.NewPermissionGuard(func(context.Context, permify.CheckRequest) (bool, error) {
+			t.Fatal("permission must not be checked")
+			return false, nil
+		}, nil)
+
+		err := guard.Require("post", middleware.PathParam("id"), "edit")(ok)(newGuardedContext(t, ""))
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+	})
+
+	t.Run("It should fail when the check fails", func(t *testing.T) {
+		guard := middleware.NewPermissionGuard(func(context.Context, permify.CheckRequest) (bool, error) {
+			return false, errors.New("unavailable")
+		}, nil)
+
+		c := newGuardedContext(t, "")
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+
+		err := guard.Require("post", middleware.PathParam("id"), "edit")(ok)(c)
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusInternalServerError, httpErr.Code)
+	})
+}
+
+func TestPermissionGuard_RequireSnapToken(t *testing.T) {
+	var got permify.CheckRequest
+	guard := middleware.NewPermissionGuard(
+		func(_ context.Context, request permify.CheckRequest) (bool, error) {
+			got = request
+			return true, nil
+		},
+		map[string]middleware.SnapTokenLookup{
+			"post": func(_ context.Context, entityID string) (string, error) {
+				assert.Equal(t, "3", entityID)
+				return "snap-token", nil
+			},
+		},
+	)
+
+	c := newGuardedContext(t, "")
+	middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+
+	err := guard.Require("post", middleware.PathParam("id"), "edit")(ok)(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, "snap-token", got.SnapToken)
+}
+
+func TestPermissionGuard_MemoryAuthorizer(t *testing.T) {
+	schema, err := permify.Schema()
+	require.NoError(t, err)
+
+	authorizer, err := permify.NewMemoryAuthorizer(schema)
+	require.NoError(t, err)
+
+	_, err = authorizer.Write(t.Context(), permify.Tuple{EntityType: "post", EntityID: "3", Relation: "owner", SubjectType: "user", SubjectID: "7"})
+	require.NoError(t, err)
+
+	guard := middleware.NewPermissionGuard(authorizer.Check, nil)
+
+	t.Run("It should allow the post owner", func(t *testing.T) {
+		c := newGuardedContext(t, "")
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+
+		err := guard.Require("post", middleware.PathParam("id"), "edit")(ok)(c)
+		require.NoError(t, err)
+	})
+
+	t.Run("It should deny other users", func(t *testing.T) {
+		c := newGuardedContext(t, "")
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 8}})
+
+		err := guard.Require("post", middleware.PathParam("id"), "edit")(ok)(c)
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusForbidden, httpErr.Code)
+	})
+}
+
+func TestPermissionGuard_ScopeToDomain(t *testing.T) {
+	userDomainID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	otherDomainID := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+
+	newGuard := func(allowed bool, got *permify.CheckRequest) middleware.PermissionGuard {
+		return middleware.NewPermissionGuard(func(_ context.Context, request permify.CheckRequest) (bool, error) {
+			*got = request
+			return allowed, nil
+		}, nil)
+	}
+
+	scopedDomain := func(t *testing.T, domainID *uuid.UUID) echo.HandlerFunc {
+		t.Helper()
+
+		return func(c echo.Context) error {
+			got, err := tenant.DomainID(c.Request().Context())
+			require.NoError(t, err)
+
+			*domainID = got
+
+			return c.NoContent(http.StatusNoContent)
+		}
+	}
+
+	t.Run("It should scope the request to the domain of the user", func(t *testing.T) {
+		var got permify.CheckRequest
+		c := newGuardedContext(t, "")
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}, DomainID: &userDomainID})
+
+		var domainID uuid.UUID
+		err := newGuard(true, &got).ScopeToDomain()(scopedDomain(t, &domainID))(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, userDomainID, domainID)
+		assert.Equal(t, permify.CheckRequest{EntityType: "domain", EntityID: userDomainID.String(), Permission: "view", UserID: "7"}, got)
+	})
+
+	t.Run("It should scope the request to the selected domain", func(t *testing.T) {
+		var got permify.CheckRequest
+		c := newGuardedContext(t, "")
+		c.Request().Header.Set(middleware.DomainHeader, otherDomainID.String())
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}, DomainID: &userDomainID})
+
+		var domainID uuid.UUID
+		err := newGuard(true, &got).ScopeToDomain()(scopedDomain(t, &domainID))(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, otherDomainID, domainID)
+		assert.Equal(t, otherDomainID.String(), got.EntityID)
+	})
+
+	t.Run("It should deny a domain the user can't view", func(t *testing.T) {
+		var got permify.CheckRequest
+		c := newGuardedContext(t, "")
+		c.Request().Header.Set(middleware.DomainHeader, otherDomainID.String())
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+
+		err := newGuard(false, &got).ScopeToDomain()(ok)(c)
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusForbidden, httpErr.Code)
+	})
+
+	t.Run("It should deny a request without a domain", func(t *testing.T) {
+		var got permify.CheckRequest
+		c := newGuardedContext(t, "")
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+
+		err := newGuard(true, &got).ScopeToDomain()(ok)(c)
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusForbidden, httpErr.Code)
+		assert.Zero(t, got)
+	})
+}
+
+func TestBodyField(t *testing.T) {
+	t.Run("It should resolve the field and keep the body readable", func(t *testing.T) {
+		c := newGuardedContext(t, `{"domain_id":"domain-1"}`)
+
+		entityID, err := middleware.BodyField("domain_id")(c)
+		require.NoError(t, err)
+		assert.Equal(t, "domain-1", entityID)
+
+		var body struct {
+			DomainID string `json:"domain_id"`
+		}
+		require.NoError(t, c.Bind(&body))
+		assert.Equal(t, "domain-1", body.DomainID)
+	})
+
+	t.Run("It should reject a missing field", func(t *testing.T) {
+		_, err := middleware.BodyField("domain_id")(newGuardedContext(t, `{}`))
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+	})
+}
+
+func TestCurrentDomain(t *testing.T) {
+	t.Run("It should resolve the domain the request is scoped to", func(t *testing.T) {
+		domainID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+		c := newGuardedContext(t, "")
+		c.SetRequest(c.Request().WithContext(tenant.WithDomain(c.Request().Context(), domainID)))
+
+		entityID, err := middleware.CurrentDomain(c)
+		require.NoError(t, err)
+		assert.Equal(t, domainID.String(), entityID)
+	})
+
+	t.Run("It should deny a request without a domain", func(t *testing.T) {
+		_, err := middleware.CurrentDomain(newGuardedContext(t, ""))
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusForbidden, httpErr.Code)
+	})
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequireScope allows requests authenticated with a personal access token only if the token has the scope.
+// Requests of a login session have every scope. It has to run after NewAuthenticator.
+func RequireScope(scope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, err := CurrentUser(c); err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+			}
+
+			if token, err := CurrentPersonalAccessToken(c); err == nil && !token.HasScope(scope) {
+				return echo.NewHTTPError(http.StatusForbidden, "personal access token scope "+scope+" is required")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequireSession allows only requests of a login session, so personal access tokens can't manage
+// the sessions and tokens of the user. It has to run after NewAuthenticator.
+func RequireSession() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, err := CurrentSession(c); err != nil {
+				return echo.NewHTTPError(http.StatusForbidden, "a login session is required")
+			}
+
+			return next(c)
+		}
+	}
+}
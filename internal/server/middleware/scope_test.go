@@ -0,0 +1,70 @@
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+
+	"echo-app/internal/models"
+	"echo-app/internal/server/middleware"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestRequireScope(t *testing.T) {
+	t.Run("It should allow a request of a session", func(t *testing.T) {
+		c := newGuardedContext(t, "")
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+		middleware.SetCurrentSession(c, models.Session{UserID: 7})
+
+		err := middleware.RequireScope(models.ScopePostsWrite)(ok)(c)
+		require.NoError(t, err)
+	})
+
+	t.Run("It should allow a personal access token with the scope", func(t *testing.T) {
+		c := newGuardedContext(t, "")
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+		middleware.SetCurrentPersonalAccessToken(c, models.PersonalAccessToken{
+			Scopes: []string{models.ScopePostsRead, models.ScopePostsWrite},
+		})
+
+		err := middleware.RequireScope(models.ScopePostsWrite)(ok)(c)
+		require.NoError(t, err)
+	})
+
+	t.Run("It should deny a personal access token without the scope", func(t *testing.T) {
+		c := newGuardedContext(t, "")
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+		middleware.SetCurrentPersonalAccessToken(c, models.PersonalAccessToken{Scopes: []string{models.ScopePostsRead}})
+
+		err := middleware.RequireScope(models.ScopePostsWrite)(ok)(c)
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusForbidden, httpErr.Code)
+	})
+}
+
+func TestRequireSession(t *testing.T) {
+	t.Run("It should allow a request of a session", func(t *testing.T) {
+		c := newGuardedContext(t, "")
+		middleware.SetCurrentSession(c, models.Session{UserID: 7})
+
+		err := middleware.RequireSession()(ok)(c)
+		require.NoError(t, err)
+	})
+
+	t.Run("It should deny a request of a personal access token", func(t *testing.T) {
+		c := newGuardedContext(t, "")
+		middleware.SetCurrentUser(c, models.User{Model: gorm.Model{ID: 7}})
+		middleware.SetCurrentPersonalAccessToken(c, models.PersonalAccessToken{Scopes: []string{models.ScopePostsRead}})
+
+		err := middleware.RequireSession()(ok)(c)
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusForbidden, httpErr.Code)
+	})
+}
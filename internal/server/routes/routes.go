@@ -1,32 +1,126 @@
 package routes
 
 import (
+	"echo-app/internal/mailer"
+	"echo-app/internal/models"
+	"echo-app/internal/permify"
 	"echo-app/internal/repositories"
 	s "echo-app/internal/server"
 	"echo-app/internal/server/handlers"
 	"echo-app/internal/server/middleware"
+	"echo-app/internal/services/domain"
+	"echo-app/internal/services/logout"
+	"echo-app/internal/services/membership"
+	"echo-app/internal/services/mfa"
+	"echo-app/internal/services/outbox"
+	"echo-app/internal/services/pat"
+	"echo-app/internal/services/permission"
 	"echo-app/internal/services/post"
+	"echo-app/internal/services/refresh"
+	"echo-app/internal/services/session"
+	"echo-app/internal/services/token"
 	"echo-app/internal/services/user"
+	"echo-app/internal/services/verification"
 	"echo-app/internal/slogx"
-	"log/slog"
+	"fmt"
 
-	echojwt "github.com/labstack/echo-jwt/v4"
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
-func ConfigureRoutes(tracer slogx.TraceStarter, server *s.Server) {
+func ConfigureRoutes(
+	tracer slogx.TraceStarter,
+	server *s.Server,
+	authorizer permify.Authorizer,
+	relationships *outbox.Service,
+	sessionStore session.Store,
+) error {
+	tokenService, err := token.NewService(server.Config.JWT)
+	if err != nil {
+		return fmt.Errorf("new token service: %w", err)
+	}
+
+	mail, err := mailer.New(server.Config.Mail)
+	if err != nil {
+		return fmt.Errorf("new mailer: %w", err)
+	}
+
 	userRepository := repositories.NewUserRepository(server.DB)
-	userService := user.NewService(userRepository)
+	verificationService := verification.NewService(userRepository, tokenService, mail, server.Config.Mail)
+	transactor := repositories.NewTransactor(server.DB)
+	domainRepository := repositories.NewDomainRepository(server.DB)
+
+	groupMappings := make(membership.GroupMappings, len(server.Config.Auth.Providers))
+	for _, provider := range server.Config.Auth.Providers {
+		groupMapping, err := membership.ParseGroupMapping(provider.GroupMapping)
+		if err != nil {
+			return fmt.Errorf("parse OIDC group mapping of %s: %w", provider.Name, err)
+		}
+
+		groupMappings[provider.Name] = groupMapping
+	}
+
+	membershipService := membership.NewService(
+		domainRepository,
+		userRepository,
+		authorizer,
+		transactor,
+		relationships,
+		groupMappings,
+	)
+
+	userService := user.NewService(
+		userRepository,
+		repositories.NewUserIdentityRepository(server.DB),
+		verificationService,
+		transactor,
+		relationships,
+		membershipService,
+	)
+
+	registerHandler := handlers.NewRegisterHandler(userService, verificationService)
 
 	postRepository := repositories.NewPostRepository(server.DB)
-	postService := post.NewService(postRepository)
+	postService := post.NewService(postRepository, domainRepository, transactor, relationships, authorizer)
 
 	postHandler := handlers.NewPostHandlers(postService)
 
-	authHandler, err := handlers.NewAuthHandler(server, userService, userRepository, &server.Config.Auth)
+	domainService := domain.NewService(domainRepository, transactor, relationships, authorizer.LookupEntity)
+
+	domainHandler := handlers.NewDomainHandlers(domainService)
+
+	membershipHandler := handlers.NewMembershipHandlers(membershipService)
+
+	refreshTokenRepository := repositories.NewRefreshTokenRepository(server.DB)
+	refreshService, err := refresh.NewService(refreshTokenRepository, transactor, server.Config.Refresh)
+	if err != nil {
+		return fmt.Errorf("new refresh service: %w", err)
+	}
+
+	sessionService := session.NewService(sessionStore, refreshService, server.Config.Session)
+
+	tokenHandler := handlers.NewTokenHandler(tokenService, refreshService, sessionService, userRepository)
+	mfaService := mfa.NewService(userRepository, repositories.NewMFARecoveryCodeRepository(server.DB), transactor, server.Config.MFA)
+	mfaHandler := handlers.NewMFAHandlers(mfaService)
+
+	loginHandler := handlers.NewLoginHandler(userService, tokenService, sessionService, tokenService, mfaService)
+	sessionHandler := handlers.NewSessionHandlers(sessionService)
 
+	personalTokenService, err := pat.NewService(repositories.NewPersonalAccessTokenRepository(server.DB), server.Config.PAT)
 	if err != nil {
-		slog.Error("auth init error")
+		return fmt.Errorf("new personal access token service: %w", err)
+	}
+	personalTokenHandler := handlers.NewPersonalAccessTokenHandlers(personalTokenService)
+
+	authHandler, err := handlers.NewAuthHandler(
+		server,
+		userService,
+		tokenService,
+		sessionService,
+		logout.NewService(userRepository, sessionService),
+		&server.Config.Auth,
+	)
+	if err != nil {
+		return fmt.Errorf("new auth handler: %w", err)
 	}
 
 	server.Echo.Use(middleware.NewRequestLogger(tracer))
@@ -35,20 +129,87 @@ func ConfigureRoutes(tracer slogx.TraceStarter, server *s.Server) {
 
 	r := server.Echo.Group("", middleware.NewRequestDebugger())
 
+	// The OIDC routes without a provider use the default one
 	r.GET("/login", authHandler.InitiateLogin)
+	r.GET("/login/:provider", authHandler.InitiateLogin)
+	r.POST("/login", loginHandler.Login)
+	r.POST("/login/mfa", loginHandler.LoginMFA)
 	r.GET("/callback", authHandler.HandleCallback)
+	r.GET("/callback/:provider", authHandler.HandleCallback)
 	r.POST("/logout", authHandler.HandleLogout)
+	r.POST("/backchannel-logout", authHandler.BackChannelLogout)
+	r.POST("/backchannel-logout/:provider", authHandler.BackChannelLogout)
+	r.POST("/refresh", tokenHandler.Refresh)
 
-	// Protected routes with JWT middleware
+	r.POST("/register", registerHandler.Register)
+	r.POST("/verify-email", registerHandler.VerifyEmail)
+	r.POST("/verify-email/resend", registerHandler.ResendVerification)
+
+	// Protected routes with JWT middleware, personal access tokens only reach the routes their scopes allow
 	protected := r.Group("")
-	protected.Use(echojwt.WithConfig(echojwt.Config{
-		SigningKey:  []byte(server.Config.Auth.OIDCClientSecret),
-		TokenLookup: "header:Authorization,cookie:access_token",
-		ContextKey:  "user",
-	}))
-
-	r.GET("/posts", postHandler.GetPosts)
-	r.POST("/posts", postHandler.CreatePost)
-	r.DELETE("/posts/:id", postHandler.DeletePost)
-	r.PUT("/posts/:id", postHandler.UpdatePost)
+	protected.Use(middleware.NewAuthenticator(tokenService, userRepository, sessionService, personalTokenService))
+
+	// Personal access tokens can't manage the sessions and tokens, or they could create tokens with more scopes
+	sessions := protected.Group("/sessions", middleware.RequireSession())
+	sessions.GET("", sessionHandler.GetSessions)
+	sessions.DELETE("", sessionHandler.RevokeSessions)
+	sessions.DELETE("/:id", sessionHandler.RevokeSession)
+
+	personalTokens := protected.Group("/personal-access-tokens", middleware.RequireSession())
+	personalTokens.GET("", personalTokenHandler.GetTokens)
+	personalTokens.POST("", personalTokenHandler.CreateToken)
+	personalTokens.DELETE("/:id", personalTokenHandler.RevokeToken)
+
+	twoFactor := protected.Group("/mfa", middleware.RequireSession())
+	twoFactor.POST("/totp", mfaHandler.EnrollTOTP)
+	twoFactor.POST("/totp/confirm", mfaHandler.ConfirmTOTP)
+
+	readPosts := middleware.RequireScope(models.ScopePostsRead)
+	writePosts := middleware.RequireScope(models.ScopePostsWrite)
+	readDomains := middleware.RequireScope(models.ScopeDomainsRead)
+	writeDomains := middleware.RequireScope(models.ScopeDomainsWrite)
+
+	// Permission requirements are checked in Permify, so the handlers only deal with the request itself
+	guard := middleware.NewPermissionGuard(authorizer.Check, map[string]middleware.SnapTokenLookup{
+		"post":   postService.SnapToken,
+		"domain": domainService.SnapToken,
+	})
+
+	// Posts only exist inside of a domain, the post repository refuses to work without one
+	posts := protected.Group("/posts", guard.ScopeToDomain())
+	posts.GET("", postHandler.GetPosts, readPosts)
+	posts.POST("", postHandler.CreatePost, writePosts, guard.Require("domain", middleware.CurrentDomain, "create_post"))
+	posts.DELETE("/:id", postHandler.DeletePost, writePosts, guard.Require("post", middleware.PathParam("id"), "delete"))
+	posts.PUT("/:id", postHandler.UpdatePost, writePosts, guard.Require("post", middleware.PathParam("id"), "edit"))
+
+	protected.GET("/domains", domainHandler.GetDomains, readDomains)
+	protected.POST("/domains", domainHandler.CreateDomain, writeDomains)
+	protected.GET("/domains/:id", domainHandler.GetDomain, readDomains, guard.Require("domain", middleware.PathParam("id"), "view"))
+	protected.PUT("/domains/:id", domainHandler.RenameDomain, writeDomains, guard.Require("domain", middleware.PathParam("id"), "edit"))
+	protected.DELETE("/domains/:id", domainHandler.DeleteDomain, writeDomains, guard.Require("domain", middleware.PathParam("id"), "edit"))
+
+	bulkLimit, err := permify.BulkLimit(server.Config.Permify)
+	if err != nil {
+		return fmt.Errorf("get permify bulk limit: %w", err)
+	}
+
+	permissionService := permission.NewService(authorizer, map[string]permission.SnapTokenLookup{
+		"post":   postService.SnapToken,
+		"domain": domainService.SnapToken,
+	}, bulkLimit)
+
+	permissionHandler := handlers.NewPermissionHandlers(permissionService)
+
+	// Checks on posts need the domain scope to find the snap tokens of the posts.
+	// They only tell the user about their own permissions, so personal access tokens need no scope for them.
+	protected.POST("/permissions/check", permissionHandler.CheckPermissions, guard.ScopeToDomain())
+
+	// Only domain admins can manage the members
+	members := protected.Group("/domains/:id/members", guard.Require("domain", middleware.PathParam("id"), "edit"))
+	members.GET("", membershipHandler.GetMembers, readDomains)
+	members.POST("", membershipHandler.AddMember, writeDomains)
+	members.PUT("/:userId", membershipHandler.ChangeMemberRole, writeDomains)
+	members.DELETE("/:userId", membershipHandler.RemoveMember, writeDomains)
+
+	return nil
 }
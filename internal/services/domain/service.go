@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"echo-app/internal/models"
+	"echo-app/internal/permify"
+
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type domainRepository interface {
+	Create(ctx context.Context, domain *models.Domain) error
+	GetByID(ctx context.Context, id uuid.UUID) (models.Domain, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Domain, error)
+	Update(ctx context.Context, domain *models.Domain) error
+	Delete(ctx context.Context, domain *models.Domain) error
+	GetPostIDs(ctx context.Context, id uuid.UUID) ([]uint, error)
+}
+
+type transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type relationshipOutbox interface {
+	Write(ctx context.Context, tuples ...permify.Tuple) ([]uint, error)
+	DeleteEntity(ctx context.Context, entityType, entityID string) (uint, error)
+	Flush(ctx context.Context, ids []uint)
+}
+
+// EntityLookup returns the IDs of the entities of the type the user has the permission on, e.g. permify.Client.LookupEntity.
+type EntityLookup func(ctx context.Context, entityType, permission, userID, snapToken string) ([]string, error)
+
+type Service struct {
+	domainRepository domainRepository
+	transactor       transactor
+	relationships    relationshipOutbox
+	lookupEntity     EntityLookup
+}
+
+func NewService(
+	domainRepository domainRepository,
+	transactor transactor,
+	relationships relationshipOutbox,
+	lookupEntity EntityLookup,
+) Service {
+	return Service{
+		domainRepository: domainRepository,
+		transactor:       transactor,
+		relationships:    relationships,
+		lookupEntity:     lookupEntity,
+	}
+}
+
+// Create stores the domain and makes the creator its admin.
+func (s Service) Create(ctx context.Context, domain *models.Domain, creatorID uint) error {
+	var entryIDs []uint
+
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.domainRepository.Create(ctx, domain); err != nil {
+			return fmt.Errorf("create domain in repository: %w", err)
+		}
+
+		var err error
+
+		entryIDs, err = s.relationships.Write(ctx, permify.Tuple{
+			EntityType:  "domain",
+			EntityID:    domain.ID.String(),
+			Relation:    "admin",
+			SubjectType: "user",
+			SubjectID:   strconv.FormatUint(uint64(creatorID), 10),
+		})
+		if err != nil {
+			return fmt.Errorf("write domain admin: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.relationships.Flush(ctx, entryIDs)
+
+	return nil
+}
+
+// GetDomains returns the domains the user can view.
+func (s Service) GetDomains(ctx context.Context, userID uint) ([]models.Domain, error) {
+	entityIDs, err := s.lookupEntity(ctx, "domain", "view", strconv.FormatUint(uint64(userID), 10), "")
+	if err != nil {
+		return nil, fmt.Errorf("lookup viewable domains: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(entityIDs))
+	for _, entityID := range entityIDs {
+		id, err := uuid.Parse(entityID)
+		if err != nil {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	domains, err := s.domainRepository.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get domains from repository: %w", err)
+	}
+
+	return domains, nil
+}
+
+func (s Service) GetDomain(ctx context.Context, id uuid.UUID) (models.Domain, error) {
+	domain, err := s.domainRepository.GetByID(ctx, id)
+	if err != nil {
+		return models.Domain{}, fmt.Errorf("get domain from repository: %w", err)
+	}
+
+	return domain, nil
+}
+
+func (s Service) Rename(ctx context.Context, domain *models.Domain, name string) error {
+	domain.Name = name
+
+	if err := s.domainRepository.Update(ctx, domain); err != nil {
+		return fmt.Errorf("update domain in repository: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes the domain together with its Permify relationships. The database deletes the posts of
+// the domain with it, so the relationships of the posts are deleted too.
+func (s Service) Delete(ctx context.Context, domain *models.Domain) error {
+	var entryIDs []uint
+
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		postIDs, err := s.domainRepository.GetPostIDs(ctx, domain.ID)
+		if err != nil {
+			return fmt.Errorf("get domain post ids from repository: %w", err)
+		}
+
+		if err := s.domainRepository.Delete(ctx, domain); err != nil {
+			return fmt.Errorf("delete domain in repository: %w", err)
+		}
+
+		entryID, err := s.relationships.DeleteEntity(ctx, "domain", domain.ID.String())
+		if err != nil {
+			return fmt.Errorf("delete domain relationships: %w", err)
+		}
+
+		entryIDs = append(entryIDs, entryID)
+
+		for _, postID := range postIDs {
+			entryID, err := s.relationships.DeleteEntity(ctx, "post", strconv.FormatUint(uint64(postID), 10))
+			if err != nil {
+				return fmt.Errorf("delete post relationships: %w", err)
+			}
+
+			entryIDs = append(entryIDs, entryID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.relationships.Flush(ctx, entryIDs)
+
+	return nil
+}
+
+// SnapToken returns the Permify snap token of the domain, it's empty for unknown domains.
+func (s Service) SnapToken(ctx context.Context, id string) (string, error) {
+	domainID, err := uuid.Parse(id)
+	if err != nil {
+		return "", nil
+	}
+
+	domain, err := s.domainRepository.GetByID(ctx, domainID)
+	if errors.Is(err, models.ErrDomainNotFound) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("get domain from repository: %w", err)
+	}
+
+	return domain.SnapToken, nil
+}
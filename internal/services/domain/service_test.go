@@ -0,0 +1,130 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"echo-app/internal/models"
+	"echo-app/internal/permify"
+	"echo-app/internal/services/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type transactorStub struct{}
+
+func (transactorStub) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	domainRepository := NewMockdomainRepository(ctrl)
+	relationships := NewMockrelationshipOutbox(ctrl)
+	domainService := domain.NewService(domainRepository, transactorStub{}, relationships, nil)
+
+	domainID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+
+	domainRepository.
+		EXPECT().
+		Create(gomock.Any(), &models.Domain{Name: "Acme"}).
+		DoAndReturn(func(_ context.Context, domain *models.Domain) error {
+			domain.ID = domainID
+			return nil
+		})
+
+	relationships.
+		EXPECT().
+		Write(gomock.Any(), permify.Tuple{
+			EntityType:  "domain",
+			EntityID:    domainID.String(),
+			Relation:    "admin",
+			SubjectType: "user",
+			SubjectID:   "7",
+		}).
+		Return([]uint{1}, nil)
+
+	relationships.
+		EXPECT().
+		Flush(gomock.Any(), []uint{1})
+
+	err := domainService.Create(t.Context(), &models.Domain{Name: "Acme"}, 7)
+	require.NoError(t, err)
+}
+
+func TestService_GetDomains(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	domainRepository := NewMockdomainRepository(ctrl)
+
+	domainID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	wantDomains := []models.Domain{{ID: domainID, Name: "Acme"}}
+
+	domainService := domain.NewService(
+		domainRepository,
+		transactorStub{},
+		NewMockrelationshipOutbox(ctrl),
+		func(_ context.Context, entityType, permission, userID, snapToken string) ([]string, error) {
+			assert.Equal(t, "domain", entityType)
+			assert.Equal(t, "view", permission)
+			assert.Equal(t, "7", userID)
+			assert.Empty(t, snapToken)
+
+			return []string{domainID.String()}, nil
+		},
+	)
+
+	domainRepository.
+		EXPECT().
+		GetByIDs(gomock.Any(), []uuid.UUID{domainID}).
+		Return(wantDomains, nil)
+
+	gotDomains, err := domainService.GetDomains(t.Context(), 7)
+	require.NoError(t, err)
+
+	assert.Equal(t, wantDomains, gotDomains)
+}
+
+func TestService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	domainRepository := NewMockdomainRepository(ctrl)
+	relationships := NewMockrelationshipOutbox(ctrl)
+	domainService := domain.NewService(domainRepository, transactorStub{}, relationships, nil)
+
+	wantDomain := &models.Domain{ID: uuid.MustParse("11111111-1111-1111-1111-111111111111"), Name: "Acme"}
+
+	domainRepository.
+		EXPECT().
+		GetPostIDs(gomock.Any(), wantDomain.ID).
+		Return([]uint{4, 9}, nil)
+
+	domainRepository.
+		EXPECT().
+		Delete(gomock.Any(), wantDomain).
+		Return(nil)
+
+	relationships.
+		EXPECT().
+		DeleteEntity(gomock.Any(), "domain", wantDomain.ID.String()).
+		Return(uint(1), nil)
+
+	// The posts are deleted by the database together with the domain
+	relationships.
+		EXPECT().
+		DeleteEntity(gomock.Any(), "post", "4").
+		Return(uint(2), nil)
+
+	relationships.
+		EXPECT().
+		DeleteEntity(gomock.Any(), "post", "9").
+		Return(uint(3), nil)
+
+	relationships.
+		EXPECT().
+		Flush(gomock.Any(), []uint{1, 2, 3})
+
+	err := domainService.Delete(t.Context(), wantDomain)
+	require.NoError(t, err)
+}
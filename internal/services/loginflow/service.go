@@ -0,0 +1,221 @@
+package loginflow
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"echo-app/internal/config"
+
+	"golang.org/x/oauth2"
+)
+
+// TTL is how long the user has to complete the login at the identity provider.
+const TTL = 10 * time.Minute
+
+var (
+	ErrMissingSecret      = errors.New("OIDC cookie secret is not configured")
+	ErrReturnToNotAllowed = errors.New("return_to is not allowed")
+	ErrInvalidFlow        = errors.New("invalid login flow")
+	ErrFlowExpired        = errors.New("login flow expired")
+)
+
+// Flow is the state of an OIDC authorization code flow between the login and the callback.
+type Flow struct {
+	Provider     string    `json:"provider"`
+	State        string    `json:"state"`
+	CodeVerifier string    `json:"codeVerifier"`
+	Nonce        string    `json:"nonce"`
+	ReturnTo     string    `json:"returnTo"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+}
+
+// Service starts the login flows and keeps them in encrypted cookie values, so the callback
+// can verify the state, the PKCE code verifier and the nonce without server side storage.
+type Service struct {
+	aead            cipher.AEAD
+	defaultReturnTo string
+	allowedOrigins  []string
+	now             func() time.Time
+}
+
+func NewService(cfg config.Auth) (*Service, error) {
+	if cfg.OIDCCookieSecret == "" {
+		return nil, ErrMissingSecret
+	}
+
+	key := sha256.Sum256([]byte(cfg.OIDCCookieSecret))
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("new cookie cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("new cookie cipher: %w", err)
+	}
+
+	allowedOrigins := make([]string, 0, len(cfg.OIDCReturnToAllowlist)+1)
+	for _, allowed := range append([]string{cfg.OIDCSuccessRedirect}, cfg.OIDCReturnToAllowlist...) {
+		if origin, ok := origin(allowed); ok {
+			allowedOrigins = append(allowedOrigins, origin)
+		}
+	}
+
+	return &Service{
+		aead:            aead,
+		defaultReturnTo: cfg.OIDCSuccessRedirect,
+		allowedOrigins:  allowedOrigins,
+		now:             time.Now,
+	}, nil
+}
+
+// Start starts a login flow at the identity provider returning to returnTo, or to the success redirect when it's empty.
+// It returns the flow together with the sealed cookie value of it.
+func (s *Service) Start(provider, returnTo string) (Flow, string, error) {
+	if returnTo == "" {
+		returnTo = s.defaultReturnTo
+	} else if !s.allowed(returnTo) {
+		return Flow{}, "", ErrReturnToNotAllowed
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return Flow{}, "", err
+	}
+
+	nonce, err := randomString()
+	if err != nil {
+		return Flow{}, "", err
+	}
+
+	flow := Flow{
+		Provider:     provider,
+		State:        state,
+		CodeVerifier: oauth2.GenerateVerifier(),
+		Nonce:        nonce,
+		ReturnTo:     returnTo,
+		ExpiresAt:    s.now().Add(TTL),
+	}
+
+	sealed, err := s.seal(flow)
+	if err != nil {
+		return Flow{}, "", err
+	}
+
+	return flow, sealed, nil
+}
+
+// Resume opens the sealed flow of the callback and checks it belongs to the identity provider and the state it returned.
+func (s *Service) Resume(sealed, provider, state string) (Flow, error) {
+	flow, err := s.open(sealed)
+	if err != nil {
+		return Flow{}, err
+	}
+
+	if flow.Provider != provider {
+		return Flow{}, fmt.Errorf("%w: provider mismatch", ErrInvalidFlow)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(flow.State), []byte(state)) != 1 {
+		return Flow{}, fmt.Errorf("%w: state mismatch", ErrInvalidFlow)
+	}
+
+	if !s.now().Before(flow.ExpiresAt) {
+		return Flow{}, ErrFlowExpired
+	}
+
+	return flow, nil
+}
+
+// VerifyNonce checks the nonce of the ID token is the one of the flow.
+func (f Flow) VerifyNonce(nonce string) error {
+	if subtle.ConstantTimeCompare([]byte(f.Nonce), []byte(nonce)) != 1 {
+		return fmt.Errorf("%w: nonce mismatch", ErrInvalidFlow)
+	}
+
+	return nil
+}
+
+// allowed reports whether the return_to URL is an absolute URL on one of the allowed origins.
+func (s *Service) allowed(returnTo string) bool {
+	returnToOrigin, ok := origin(returnTo)
+	if !ok {
+		return false
+	}
+
+	for _, allowed := range s.allowedOrigins {
+		if allowed == returnToOrigin {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Service) seal(flow Flow) (string, error) {
+	plaintext, err := json.Marshal(flow)
+	if err != nil {
+		return "", fmt.Errorf("marshal login flow: %w", err)
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generate cookie nonce: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(s.aead.Seal(nonce, nonce, plaintext, nil)), nil
+}
+
+func (s *Service) open(sealed string) (Flow, error) {
+	ciphertext, err := base64.RawURLEncoding.DecodeString(sealed)
+	if err != nil || len(ciphertext) < s.aead.NonceSize() {
+		return Flow{}, ErrInvalidFlow
+	}
+
+	nonceSize := s.aead.NonceSize()
+	plaintext, err := s.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], nil)
+	if err != nil {
+		return Flow{}, ErrInvalidFlow
+	}
+
+	var flow Flow
+	if err := json.Unmarshal(plaintext, &flow); err != nil {
+		return Flow{}, ErrInvalidFlow
+	}
+
+	return flow, nil
+}
+
+// origin returns the scheme and host of an absolute http(s) URL.
+func origin(rawURL string) (string, bool) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.Host == "" || parsedURL.User != nil {
+		return "", false
+	}
+
+	if parsedURL.Scheme != "https" && parsedURL.Scheme != "http" {
+		return "", false
+	}
+
+	return parsedURL.Scheme + "://" + strings.ToLower(parsedURL.Host), true
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate random string: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
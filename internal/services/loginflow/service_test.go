@@ -0,0 +1,108 @@
+package loginflow_test
+
+import (
+	"testing"
+
+	"echo-app/internal/config"
+	"echo-app/internal/services/loginflow"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newService(t *testing.T, secret string) *loginflow.Service {
+	t.Helper()
+
+	loginFlows, err := loginflow.NewService(config.Auth{
+		OIDCCookieSecret:      secret,
+		OIDCSuccessRedirect:   "http://localhost:3000/",
+		OIDCReturnToAllowlist: []string{"https://app.example.com"},
+	})
+	require.NoError(t, err)
+
+	return loginFlows
+}
+
+func TestNewService(t *testing.T) {
+	_, err := loginflow.NewService(config.Auth{})
+	assert.ErrorIs(t, err, loginflow.ErrMissingSecret)
+}
+
+func TestService_Start(t *testing.T) {
+	loginFlows := newService(t, "secret")
+
+	t.Run("It should return to the success redirect by default", func(t *testing.T) {
+		flow, sealed, err := loginFlows.Start("default", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, "default", flow.Provider)
+		assert.Equal(t, "http://localhost:3000/", flow.ReturnTo)
+		assert.NotEmpty(t, flow.State)
+		assert.NotEmpty(t, flow.CodeVerifier)
+		assert.NotEmpty(t, flow.Nonce)
+		assert.NotContains(t, sealed, flow.CodeVerifier)
+	})
+
+	for _, returnTo := range []string{"https://app.example.com/posts?page=2", "http://LOCALHOST:3000/settings"} {
+		t.Run("It should allow "+returnTo, func(t *testing.T) {
+			flow, _, err := loginFlows.Start("default", returnTo)
+			require.NoError(t, err)
+
+			assert.Equal(t, returnTo, flow.ReturnTo)
+		})
+	}
+
+	for _, returnTo := range []string{
+		"https://evil.example.com/",
+		"http://app.example.com/",
+		"https://app.example.com.evil.com/",
+		"https://user@app.example.com/",
+		"//app.example.com/",
+		"/posts",
+		"javascript:alert(1)",
+	} {
+		t.Run("It should reject "+returnTo, func(t *testing.T) {
+			_, _, err := loginFlows.Start("default", returnTo)
+			assert.ErrorIs(t, err, loginflow.ErrReturnToNotAllowed)
+		})
+	}
+}
+
+func TestService_Resume(t *testing.T) {
+	loginFlows := newService(t, "secret")
+
+	flow, sealed, err := loginFlows.Start("default", "")
+	require.NoError(t, err)
+
+	t.Run("It should resume the flow of the state", func(t *testing.T) {
+		gotFlow, err := loginFlows.Resume(sealed, "default", flow.State)
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.CodeVerifier, gotFlow.CodeVerifier)
+		assert.NoError(t, gotFlow.VerifyNonce(flow.Nonce))
+		assert.ErrorIs(t, gotFlow.VerifyNonce("other-nonce"), loginflow.ErrInvalidFlow)
+	})
+
+	t.Run("It should reject another state", func(t *testing.T) {
+		_, err := loginFlows.Resume(sealed, "default", "other-state")
+		assert.ErrorIs(t, err, loginflow.ErrInvalidFlow)
+	})
+
+	t.Run("It should reject another provider", func(t *testing.T) {
+		_, err := loginFlows.Resume(sealed, "google", flow.State)
+		assert.ErrorIs(t, err, loginflow.ErrInvalidFlow)
+	})
+
+	t.Run("It should reject a tampered cookie", func(t *testing.T) {
+		tampered := []byte(sealed)
+		tampered[len(tampered)/2] ^= 1
+
+		_, err := loginFlows.Resume(string(tampered), "default", flow.State)
+		assert.ErrorIs(t, err, loginflow.ErrInvalidFlow)
+	})
+
+	t.Run("It should reject a cookie sealed with another secret", func(t *testing.T) {
+		_, err := newService(t, "other-secret").Resume(sealed, "default", flow.State)
+		assert.ErrorIs(t, err, loginflow.ErrInvalidFlow)
+	})
+}
@@ -0,0 +1,73 @@
+package logout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"echo-app/internal/models"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+// BackChannelLogoutEvent is the event of the logout tokens, see https://openid.net/specs/openid-connect-backchannel-1_0.html.
+const BackChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+var ErrInvalidLogoutToken = errors.New("invalid logout token")
+
+type userRepository interface {
+	GetUserByIdentity(ctx context.Context, provider, subject string) (models.User, error)
+}
+
+type sessionRevoker interface {
+	RevokeUser(ctx context.Context, userID uint) error
+	RevokeOIDCSession(ctx context.Context, provider, sessionID string) error
+}
+
+type Service struct {
+	userRepository userRepository
+	sessions       sessionRevoker
+}
+
+func NewService(userRepository userRepository, sessions sessionRevoker) Service {
+	return Service{userRepository: userRepository, sessions: sessions}
+}
+
+// BackChannelLogout revokes the sessions ended by the named identity provider. The signature, issuer, audience and
+// expiry of the logout token have to be verified already. A logout token with a session ID revokes the sessions started
+// with it, one with the subject only revokes every session of the user. The access tokens of the revoked sessions
+// are rejected from then on, as the authenticator validates the session of every access token.
+func (s Service) BackChannelLogout(ctx context.Context, provider string, claims models.LogoutTokenClaims) error {
+	if _, ok := claims.Events[BackChannelLogoutEvent]; !ok {
+		return fmt.Errorf("%w: missing back-channel logout event", ErrInvalidLogoutToken)
+	}
+
+	if claims.Nonce != "" {
+		return fmt.Errorf("%w: nonce is not allowed", ErrInvalidLogoutToken)
+	}
+
+	if claims.SID != "" {
+		if err := s.sessions.RevokeOIDCSession(ctx, provider, claims.SID); err != nil {
+			return fmt.Errorf("revoke OIDC session: %w", err)
+		}
+
+		return nil
+	}
+
+	if claims.Sub == "" {
+		return fmt.Errorf("%w: missing sub and sid", ErrInvalidLogoutToken)
+	}
+
+	user, err := s.userRepository.GetUserByIdentity(ctx, provider, claims.Sub)
+	if errors.Is(err, models.ErrUserNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("get user by identity from repository: %w", err)
+	}
+
+	if err := s.sessions.RevokeUser(ctx, user.ID); err != nil {
+		return fmt.Errorf("revoke user sessions: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=logout_test -typed=true
+//
+
+// Package logout_test is a generated GoMock package.
+package logout_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "echo-app/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockuserRepository is a mock of userRepository interface.
+type MockuserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockuserRepositoryMockRecorder is the mock recorder for MockuserRepository.
+type MockuserRepositoryMockRecorder struct {
+	mock *MockuserRepository
+}
+
+// NewMockuserRepository creates a new mock instance.
+func NewMockuserRepository(ctrl *gomock.Controller) *MockuserRepository {
+	mock := &MockuserRepository{ctrl: ctrl}
+	mock.recorder = &MockuserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserRepository) EXPECT() *MockuserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetUserByIdentity mocks base method.
+func (m *MockuserRepository) GetUserByIdentity(ctx context.Context, provider, subject string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByIdentity", ctx, provider, subject)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByIdentity indicates an expected call of GetUserByIdentity.
+func (mr *MockuserRepositoryMockRecorder) GetUserByIdentity(ctx, provider, subject any) *MockuserRepositoryGetUserByIdentityCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByIdentity", reflect.TypeOf((*MockuserRepository)(nil).GetUserByIdentity), ctx, provider, subject)
+	return &MockuserRepositoryGetUserByIdentityCall{Call: call}
+}
+
+// MockuserRepositoryGetUserByIdentityCall wrap *gomock.Call
+type MockuserRepositoryGetUserByIdentityCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRepositoryGetUserByIdentityCall) Return(arg0 models.User, arg1 error) *MockuserRepositoryGetUserByIdentityCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRepositoryGetUserByIdentityCall) Do(f func(context.Context, string, string) (models.User, error)) *MockuserRepositoryGetUserByIdentityCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRepositoryGetUserByIdentityCall) DoAndReturn(f func(context.Context, string, string) (models.User, error)) *MockuserRepositoryGetUserByIdentityCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MocksessionRevoker is a mock of sessionRevoker interface.
+type MocksessionRevoker struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionRevokerMockRecorder
+	isgomock struct{}
+}
+
+// MocksessionRevokerMockRecorder is the mock recorder for MocksessionRevoker.
+type MocksessionRevokerMockRecorder struct {
+	mock *MocksessionRevoker
+}
+
+// NewMocksessionRevoker creates a new mock instance.
+func NewMocksessionRevoker(ctrl *gomock.Controller) *MocksessionRevoker {
+	mock := &MocksessionRevoker{ctrl: ctrl}
+	mock.recorder = &MocksessionRevokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionRevoker) EXPECT() *MocksessionRevokerMockRecorder {
+	return m.recorder
+}
+
+// RevokeOIDCSession mocks base method.
+func (m *MocksessionRevoker) RevokeOIDCSession(ctx context.Context, provider, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOIDCSession", ctx, provider, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOIDCSession indicates an expected call of RevokeOIDCSession.
+func (mr *MocksessionRevokerMockRecorder) RevokeOIDCSession(ctx, provider, sessionID any) *MocksessionRevokerRevokeOIDCSessionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOIDCSession", reflect.TypeOf((*MocksessionRevoker)(nil).RevokeOIDCSession), ctx, provider, sessionID)
+	return &MocksessionRevokerRevokeOIDCSessionCall{Call: call}
+}
+
+// MocksessionRevokerRevokeOIDCSessionCall wrap *gomock.Call
+type MocksessionRevokerRevokeOIDCSessionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionRevokerRevokeOIDCSessionCall) Return(arg0 error) *MocksessionRevokerRevokeOIDCSessionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionRevokerRevokeOIDCSessionCall) Do(f func(context.Context, string, string) error) *MocksessionRevokerRevokeOIDCSessionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionRevokerRevokeOIDCSessionCall) DoAndReturn(f func(context.Context, string, string) error) *MocksessionRevokerRevokeOIDCSessionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeUser mocks base method.
+func (m *MocksessionRevoker) RevokeUser(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUser indicates an expected call of RevokeUser.
+func (mr *MocksessionRevokerMockRecorder) RevokeUser(ctx, userID any) *MocksessionRevokerRevokeUserCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUser", reflect.TypeOf((*MocksessionRevoker)(nil).RevokeUser), ctx, userID)
+	return &MocksessionRevokerRevokeUserCall{Call: call}
+}
+
+// MocksessionRevokerRevokeUserCall wrap *gomock.Call
+type MocksessionRevokerRevokeUserCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionRevokerRevokeUserCall) Return(arg0 error) *MocksessionRevokerRevokeUserCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionRevokerRevokeUserCall) Do(f func(context.Context, uint) error) *MocksessionRevokerRevokeUserCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionRevokerRevokeUserCall) DoAndReturn(f func(context.Context, uint) error) *MocksessionRevokerRevokeUserCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
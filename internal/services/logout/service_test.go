@@ -0,0 +1,89 @@
+package logout_test
+
+import (
+	"testing"
+
+	"echo-app/internal/models"
+	"echo-app/internal/services/logout"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func newService(t *testing.T) (logout.Service, *MockuserRepository, *MocksessionRevoker) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	userRepository := NewMockuserRepository(ctrl)
+	sessions := NewMocksessionRevoker(ctrl)
+
+	return logout.NewService(userRepository, sessions), userRepository, sessions
+}
+
+func logoutClaims(sub, sid string) models.LogoutTokenClaims {
+	return models.LogoutTokenClaims{
+		Sub:    sub,
+		SID:    sid,
+		Events: map[string]any{logout.BackChannelLogoutEvent: map[string]any{}},
+	}
+}
+
+func TestService_BackChannelLogout(t *testing.T) {
+	t.Run("It should revoke the sessions of the session ID", func(t *testing.T) {
+		logoutService, _, sessions := newService(t)
+
+		sessions.
+			EXPECT().
+			RevokeOIDCSession(gomock.Any(), "google", "oidc-session").
+			Return(nil)
+
+		err := logoutService.BackChannelLogout(t.Context(), "google", logoutClaims("subject", "oidc-session"))
+		require.NoError(t, err)
+	})
+
+	t.Run("It should revoke every session of the subject", func(t *testing.T) {
+		logoutService, userRepository, sessions := newService(t)
+
+		userRepository.
+			EXPECT().
+			GetUserByIdentity(gomock.Any(), "google", "subject").
+			Return(models.User{Model: gorm.Model{ID: 7}}, nil)
+
+		sessions.
+			EXPECT().
+			RevokeUser(gomock.Any(), uint(7)).
+			Return(nil)
+
+		err := logoutService.BackChannelLogout(t.Context(), "google", logoutClaims("subject", ""))
+		require.NoError(t, err)
+	})
+
+	t.Run("It should accept an unknown subject", func(t *testing.T) {
+		logoutService, userRepository, _ := newService(t)
+
+		userRepository.
+			EXPECT().
+			GetUserByIdentity(gomock.Any(), "google", "subject").
+			Return(models.User{}, models.ErrUserNotFound)
+
+		err := logoutService.BackChannelLogout(t.Context(), "google", logoutClaims("subject", ""))
+		require.NoError(t, err)
+	})
+
+	t.Run("It should reject invalid logout tokens", func(t *testing.T) {
+		withNonce := logoutClaims("subject", "")
+		withNonce.Nonce = "nonce"
+
+		withoutEvent := logoutClaims("subject", "")
+		withoutEvent.Events = nil
+
+		for _, claims := range []models.LogoutTokenClaims{withNonce, withoutEvent, logoutClaims("", "")} {
+			logoutService, _, _ := newService(t)
+
+			err := logoutService.BackChannelLogout(t.Context(), "google", claims)
+			assert.ErrorIs(t, err, logout.ErrInvalidLogoutToken)
+		}
+	})
+}
@@ -0,0 +1,161 @@
+package membership
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"echo-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidGroupMapping = errors.New("invalid OIDC group mapping")
+
+// GroupGrant is the role an identity provider group grants in a domain.
+type GroupGrant struct {
+	DomainID uuid.UUID
+	Role     string
+}
+
+// GroupMapping maps identity provider groups to the domain roles they grant.
+type GroupMapping map[string][]GroupGrant
+
+// GroupMappings are the group mappings of the identity providers by provider name. Groups of different
+// providers are unrelated, even with the same name.
+type GroupMappings map[string]GroupMapping
+
+// ParseGroupMapping parses a comma separated list of group=domain:role grants, e.g.
+// "acme-admins=11111111-1111-1111-1111-111111111111:admin,acme=11111111-1111-1111-1111-111111111111:member".
+// A group is repeated to grant roles in several domains.
+func ParseGroupMapping(value string) (GroupMapping, error) {
+	mapping := make(GroupMapping)
+
+	for entry := range strings.SplitSeq(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		group, grant, ok := strings.Cut(entry, "=")
+		if !ok || group == "" {
+			return nil, fmt.Errorf("%w: %q is not a group=domain:role grant", ErrInvalidGroupMapping, entry)
+		}
+
+		rawDomainID, role, ok := strings.Cut(grant, ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not a group=domain:role grant", ErrInvalidGroupMapping, entry)
+		}
+
+		domainID, err := uuid.Parse(rawDomainID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid domain of %q: %w", ErrInvalidGroupMapping, entry, err)
+		}
+
+		if _, ok := roleRanks[role]; !ok {
+			return nil, fmt.Errorf("%w: unknown role of %q", ErrInvalidGroupMapping, entry)
+		}
+
+		mapping[group] = append(mapping[group], GroupGrant{DomainID: domainID, Role: role})
+	}
+
+	return mapping, nil
+}
+
+// domains returns the domains the mapping manages, ordered by ID.
+func (m GroupMapping) domains() []uuid.UUID {
+	var domainIDs []uuid.UUID
+	for _, grants := range m {
+		for _, grant := range grants {
+			if !slices.Contains(domainIDs, grant.DomainID) {
+				domainIDs = append(domainIDs, grant.DomainID)
+			}
+		}
+	}
+
+	slices.SortFunc(domainIDs, func(a, b uuid.UUID) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	return domainIDs
+}
+
+// SyncGroups makes the roles of the user in the domains of the group mapping of the identity provider match
+// the groups the provider sent. The user gets the most privileged role their groups grant in a domain and is removed
+// from the domains none of their groups grant a role in. The identity provider is the source of truth for these domains,
+// so the roles changed by the domain admins are overwritten on the next login. The last admin of a domain keeps
+// the admin role though. Other domains are left alone, logins with a provider without a group mapping don't change any role.
+func (s Service) SyncGroups(ctx context.Context, provider string, userID uint, groups []string) error {
+	groupMapping := s.groupMappings[provider]
+	if len(groupMapping) == 0 {
+		return nil
+	}
+
+	wantRoles := make(map[uuid.UUID]string)
+	for _, group := range groups {
+		for _, grant := range groupMapping[group] {
+			if roleRanks[grant.Role] > roleRanks[wantRoles[grant.DomainID]] {
+				wantRoles[grant.DomainID] = grant.Role
+			}
+		}
+	}
+
+	for _, domainID := range groupMapping.domains() {
+		err := s.syncRole(ctx, domainID, userID, wantRoles[domainID])
+		switch {
+		case errors.Is(err, models.ErrDomainNotFound):
+			slog.WarnContext(ctx, "OIDC group mapping refers to a missing domain", "domain", domainID.String())
+		case errors.Is(err, ErrLastAdmin):
+			slog.WarnContext(ctx, "OIDC groups don't demote the last domain admin", "domain", domainID.String(), "user", userID)
+		case err != nil:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncRole gives the user the role in the domain, an empty role removes them from the domain. The role is checked
+// and recorded under the domain lock like the changes of the domain admins, so the last admin keeps the admin role.
+func (s Service) syncRole(ctx context.Context, domainID uuid.UUID, userID uint, wantRole string) error {
+	var entryIDs []uint
+
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		roles, err := s.lockedRoles(ctx, domainID)
+		if err != nil {
+			return err
+		}
+
+		role := roles[userID]
+		if role == wantRole {
+			return nil
+		}
+
+		if role == RoleAdmin && isLastAdmin(roles) {
+			return ErrLastAdmin
+		}
+
+		if wantRole != "" {
+			entryIDs, err = s.writeRole(ctx, domainID, userID, wantRole)
+			return err
+		}
+
+		entryID, err := s.outbox.DeleteSubject(ctx, "domain", domainID.String(), "user", strconv.FormatUint(uint64(userID), 10))
+		entryIDs = []uint{entryID}
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("sync domain membership: %w", err)
+	}
+
+	if len(entryIDs) > 0 {
+		s.outbox.Flush(ctx, entryIDs)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package membership_test
+
+import (
+	"testing"
+
+	"echo-app/internal/models"
+	"echo-app/internal/permify"
+	"echo-app/internal/services/membership"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var otherDomainID = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+
+func TestParseGroupMapping(t *testing.T) {
+	t.Run("It should parse the grants of the groups", func(t *testing.T) {
+		mapping, err := membership.ParseGroupMapping(
+			"acme-admins=" + domainID.String() + ":admin, acme=" + domainID.String() + ":member,acme=" + otherDomainID.String() + ":viewer",
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, membership.GroupMapping{
+			"acme-admins": {{DomainID: domainID, Role: "admin"}},
+			"acme":        {{DomainID: domainID, Role: "member"}, {DomainID: otherDomainID, Role: "viewer"}},
+		}, mapping)
+	})
+
+	t.Run("It should accept an empty mapping", func(t *testing.T) {
+		mapping, err := membership.ParseGroupMapping("")
+		require.NoError(t, err)
+
+		assert.Empty(t, mapping)
+	})
+
+	for _, value := range []string{"acme", "acme=" + domainID.String(), "acme=domain:member", "acme=" + domainID.String() + ":owner"} {
+		t.Run("It should reject "+value, func(t *testing.T) {
+			_, err := membership.ParseGroupMapping(value)
+			assert.ErrorIs(t, err, membership.ErrInvalidGroupMapping)
+		})
+	}
+}
+
+func TestService_SyncGroups(t *testing.T) {
+	groupMapping := membership.GroupMapping{
+		"acme-admins": {{DomainID: domainID, Role: "admin"}},
+		"acme":        {{DomainID: domainID, Role: "member"}},
+	}
+
+	expectRole := func(mocks membershipMocks, userID, role string, pending ...models.OutboxEntry) {
+		expectPending(mocks, pending...)
+
+		mocks.outbox.
+			EXPECT().
+			DeleteSubject(gomock.Any(), "domain", domainID.String(), "user", userID).
+			Return(uint(1), nil)
+
+		mocks.outbox.
+			EXPECT().
+			Write(gomock.Any(), permify.Tuple{EntityType: "domain", EntityID: domainID.String(), Relation: role, SubjectType: "user", SubjectID: userID}).
+			Return([]uint{2}, nil)
+
+		mocks.outbox.
+			EXPECT().
+			Flush(gomock.Any(), []uint{1, 2})
+	}
+
+	t.Run("It should add the user to the domain of their group", func(t *testing.T) {
+		membershipService, mocks := newServiceWithGroups(t, groupMapping, domainTuple("admin", "1"))
+		expectRole(mocks, "3", "member")
+
+		err := membershipService.SyncGroups(t.Context(), "corp", 3, []string{"acme", "unmapped"})
+		require.NoError(t, err)
+	})
+
+	t.Run("It should grant the most privileged role of the groups", func(t *testing.T) {
+		membershipService, mocks := newServiceWithGroups(t, groupMapping, domainTuple("admin", "1"), domainTuple("member", "2"))
+		expectRole(mocks, "2", "admin")
+
+		err := membershipService.SyncGroups(t.Context(), "corp", 2, []string{"acme", "acme-admins"})
+		require.NoError(t, err)
+	})
+
+	t.Run("It should remove the user from the domain without their group", func(t *testing.T) {
+		membershipService, mocks := newServiceWithGroups(t, groupMapping, domainTuple("admin", "1"), domainTuple("member", "2"))
+
+		expectPending(mocks)
+
+		mocks.outbox.
+			EXPECT().
+			DeleteSubject(gomock.Any(), "domain", domainID.String(), "user", "2").
+			Return(uint(1), nil)
+
+		mocks.outbox.
+			EXPECT().
+			Flush(gomock.Any(), []uint{1})
+
+		err := membershipService.SyncGroups(t.Context(), "corp", 2, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("It should leave a matching role alone", func(t *testing.T) {
+		membershipService, mocks := newServiceWithGroups(t, groupMapping, domainTuple("admin", "1"))
+
+		expectPending(mocks)
+
+		err := membershipService.SyncGroups(t.Context(), "corp", 1, []string{"acme-admins"})
+		require.NoError(t, err)
+	})
+
+	t.Run("It should ignore the groups of another provider", func(t *testing.T) {
+		membershipService, _ := newServiceWithGroups(t, groupMapping, domainTuple("admin", "1"), domainTuple("member", "2"))
+
+		err := membershipService.SyncGroups(t.Context(), "partner", 3, []string{"acme-admins"})
+		require.NoError(t, err)
+
+		// A provider without a mapping doesn't send the groups, the memberships of the user stay
+		err = membershipService.SyncGroups(t.Context(), "partner", 2, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("It should skip a missing domain", func(t *testing.T) {
+		membershipService, mocks := newServiceWithGroups(t, membership.GroupMapping{
+			"globex": {{DomainID: otherDomainID, Role: "member"}},
+		})
+
+		mocks.domainRepository.
+			EXPECT().
+			GetForUpdate(gomock.Any(), otherDomainID).
+			Return(models.Domain{}, models.ErrDomainNotFound)
+
+		err := membershipService.SyncGroups(t.Context(), "corp", 1, []string{"globex"})
+		require.NoError(t, err)
+	})
+
+	t.Run("It should not demote the last admin", func(t *testing.T) {
+		membershipService, mocks := newServiceWithGroups(t, groupMapping, domainTuple("admin", "1"), domainTuple("member", "2"))
+
+		expectPending(mocks)
+
+		err := membershipService.SyncGroups(t.Context(), "corp", 1, []string{"acme"})
+		require.NoError(t, err)
+	})
+
+	t.Run("It should demote an admin added concurrently that isn't dispatched yet", func(t *testing.T) {
+		// Permify has a single admin, the promotion of the other admin is only recorded in the outbox
+		membershipService, mocks := newServiceWithGroups(t, groupMapping, domainTuple("admin", "1"), domainTuple("member", "2"))
+		expectRole(mocks, "1", "member", models.OutboxEntry{
+			Operation: models.OutboxWrite, EntityType: "domain", EntityID: domainID.String(), Relation: "admin",
+			SubjectType: "user", SubjectID: "2",
+		})
+
+		err := membershipService.SyncGroups(t.Context(), "corp", 1, []string{"acme"})
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,347 @@
+package membership
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"echo-app/internal/models"
+	"echo-app/internal/permify"
+
+	safecast "github.com/ccoveille/go-safecast"
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+// Roles of the domain members, they are the relations of the domain entity in the Permify schema.
+const (
+	RoleViewer = "viewer"
+	RoleMember = "member"
+	RoleAdmin  = "admin"
+)
+
+// roleRanks orders the roles from the least to the most privileged one.
+var roleRanks = map[string]int{RoleViewer: 1, RoleMember: 2, RoleAdmin: 3}
+
+var (
+	ErrAlreadyMember = errors.New("user is already a domain member")
+	ErrLastAdmin     = errors.New("domain must keep at least one admin")
+)
+
+type domainRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (models.Domain, error)
+	GetForUpdate(ctx context.Context, id uuid.UUID) (models.Domain, error)
+}
+
+type userRepository interface {
+	GetByID(ctx context.Context, id uint) (models.User, error)
+	GetByIDs(ctx context.Context, ids []uint) ([]models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (models.User, error)
+}
+
+type relationshipReader interface {
+	Read(ctx context.Context, filter permify.Tuple, snapToken string) ([]permify.Tuple, error)
+}
+
+type transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type relationshipOutbox interface {
+	Write(ctx context.Context, tuples ...permify.Tuple) ([]uint, error)
+	DeleteSubject(ctx context.Context, entityType, entityID, subjectType, subjectID string) (uint, error)
+	Pending(ctx context.Context, entityType, entityID string) ([]models.OutboxEntry, error)
+	Flush(ctx context.Context, ids []uint)
+}
+
+// Service manages the members of domains. Memberships are kept in Permify only.
+type Service struct {
+	domainRepository domainRepository
+	userRepository   userRepository
+	relationships    relationshipReader
+	transactor       transactor
+	outbox           relationshipOutbox
+	groupMappings    GroupMappings
+}
+
+func NewService(
+	domainRepository domainRepository,
+	userRepository userRepository,
+	relationships relationshipReader,
+	transactor transactor,
+	outbox relationshipOutbox,
+	groupMappings GroupMappings,
+) Service {
+	return Service{
+		domainRepository: domainRepository,
+		userRepository:   userRepository,
+		relationships:    relationships,
+		transactor:       transactor,
+		outbox:           outbox,
+		groupMappings:    groupMappings,
+	}
+}
+
+// GetMembers returns the members of the domain ordered by user ID.
+func (s Service) GetMembers(ctx context.Context, domainID uuid.UUID) ([]models.DomainMember, error) {
+	roles, err := s.roles(ctx, domainID)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uint, 0, len(roles))
+	for userID := range roles {
+		userIDs = append(userIDs, userID)
+	}
+
+	users, err := s.userRepository.GetByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get members from repository: %w", err)
+	}
+
+	members := make([]models.DomainMember, 0, len(users))
+	for _, user := range users {
+		members = append(members, models.DomainMember{User: user, Role: roles[user.ID]})
+	}
+
+	return members, nil
+}
+
+// AddMember adds the user with the email to the domain.
+func (s Service) AddMember(ctx context.Context, domainID uuid.UUID, email, role string) (models.DomainMember, error) {
+	user, err := s.userRepository.GetUserByEmail(ctx, email)
+	if err != nil {
+		return models.DomainMember{}, fmt.Errorf("get user by email from repository: %w", err)
+	}
+
+	var entryIDs []uint
+
+	// The domain stays locked until the membership is recorded, so concurrent additions of the user are checked one after the other
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		roles, err := s.lockedRoles(ctx, domainID)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := roles[user.ID]; ok {
+			return ErrAlreadyMember
+		}
+
+		entryIDs, err = s.writeRole(ctx, domainID, user.ID, role)
+
+		return err
+	})
+	if err != nil {
+		return models.DomainMember{}, fmt.Errorf("add domain membership: %w", err)
+	}
+
+	s.outbox.Flush(ctx, entryIDs)
+
+	return models.DomainMember{User: user, Role: role}, nil
+}
+
+// ChangeRole changes the role of the domain member.
+func (s Service) ChangeRole(ctx context.Context, domainID uuid.UUID, userID uint, role string) (models.DomainMember, error) {
+	var (
+		user     models.User
+		entryIDs []uint
+	)
+
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if user, err = s.member(ctx, domainID, userID, role); err != nil {
+			return err
+		}
+
+		entryIDs, err = s.writeRole(ctx, domainID, userID, role)
+
+		return err
+	})
+	if err != nil {
+		return models.DomainMember{}, fmt.Errorf("change domain membership: %w", err)
+	}
+
+	s.outbox.Flush(ctx, entryIDs)
+
+	return models.DomainMember{User: user, Role: role}, nil
+}
+
+// RemoveMember removes the user from the domain.
+func (s Service) RemoveMember(ctx context.Context, domainID uuid.UUID, userID uint) error {
+	var entryID uint
+
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if _, err := s.member(ctx, domainID, userID, ""); err != nil {
+			return err
+		}
+
+		var err error
+		entryID, err = s.outbox.DeleteSubject(ctx, "domain", domainID.String(), "user", strconv.FormatUint(uint64(userID), 10))
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("delete domain membership: %w", err)
+	}
+
+	s.outbox.Flush(ctx, []uint{entryID})
+
+	return nil
+}
+
+// member returns the domain member who is going to get the new role, an empty role removes them from the domain.
+// The last admin of the domain can't lose the admin role. It must run in the transaction recording the change:
+// the domain stays locked until the end of it, so concurrent changes of the members are checked one after the other.
+func (s Service) member(ctx context.Context, domainID uuid.UUID, userID uint, newRole string) (models.User, error) {
+	roles, err := s.lockedRoles(ctx, domainID)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	role, ok := roles[userID]
+	if !ok {
+		return models.User{}, models.ErrMemberNotFound
+	}
+
+	if role == RoleAdmin && newRole != RoleAdmin && isLastAdmin(roles) {
+		return models.User{}, ErrLastAdmin
+	}
+
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return models.User{}, fmt.Errorf("get member from repository: %w", err)
+	}
+
+	return user, nil
+}
+
+// isLastAdmin reports whether the domain has a single admin.
+func isLastAdmin(roles map[uint]string) bool {
+	admins := 0
+	for _, role := range roles {
+		if role == RoleAdmin {
+			admins++
+		}
+	}
+
+	return admins == 1
+}
+
+// writeRole records the replacement of the relations of the user to the domain in the transaction carried by ctx
+// and returns the IDs of the outbox entries.
+func (s Service) writeRole(ctx context.Context, domainID uuid.UUID, userID uint, role string) ([]uint, error) {
+	subjectID := strconv.FormatUint(uint64(userID), 10)
+
+	deleteID, err := s.outbox.DeleteSubject(ctx, "domain", domainID.String(), "user", subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	writeIDs, err := s.outbox.Write(ctx, permify.Tuple{
+		EntityType:  "domain",
+		EntityID:    domainID.String(),
+		Relation:    role,
+		SubjectType: "user",
+		SubjectID:   subjectID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]uint{deleteID}, writeIDs...), nil
+}
+
+// roles reads the roles of the domain members from Permify.
+func (s Service) roles(ctx context.Context, domainID uuid.UUID) (map[uint]string, error) {
+	domain, err := s.domainRepository.GetByID(ctx, domainID)
+	if err != nil {
+		return nil, fmt.Errorf("get domain from repository: %w", err)
+	}
+
+	return s.readRoles(ctx, domain)
+}
+
+// lockedRoles locks the domain and returns the roles of its members including the changes recorded in the outbox
+// that aren't dispatched to Permify yet. The dispatcher marks entries processed together with the snap token of the domain
+// under the same lock, so none can drop out of the pending ones in between. An entry already applied to Permify
+// but still pending is applied once more on top, which doesn't change the roles.
+func (s Service) lockedRoles(ctx context.Context, domainID uuid.UUID) (map[uint]string, error) {
+	domain, err := s.domainRepository.GetForUpdate(ctx, domainID)
+	if err != nil {
+		return nil, fmt.Errorf("lock domain in repository: %w", err)
+	}
+
+	roles, err := s.readRoles(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.outbox.Pending(ctx, "domain", domainID.String())
+	if err != nil {
+		return nil, fmt.Errorf("get pending domain relationships: %w", err)
+	}
+
+	for _, entry := range entries {
+		switch {
+		case entry.Operation == models.OutboxDelete && entry.SubjectType == "":
+			clear(roles)
+		case entry.SubjectType != "user":
+			continue
+		case entry.Operation == models.OutboxDelete:
+			if userID, ok := parseUserID(entry.SubjectID); ok {
+				delete(roles, userID)
+			}
+		case entry.Operation == models.OutboxWrite:
+			if userID, ok := parseUserID(entry.SubjectID); ok {
+				grantRole(roles, userID, entry.Relation)
+			}
+		}
+	}
+
+	return roles, nil
+}
+
+// readRoles reads the roles of the domain members from Permify, a member with several roles gets the most privileged one.
+func (s Service) readRoles(ctx context.Context, domain models.Domain) (map[uint]string, error) {
+	tuples, err := s.relationships.Read(ctx, permify.Tuple{
+		EntityType:  "domain",
+		EntityID:    domain.ID.String(),
+		SubjectType: "user",
+	}, domain.SnapToken)
+	if err != nil {
+		return nil, fmt.Errorf("read domain relationships: %w", err)
+	}
+
+	roles := make(map[uint]string)
+	for _, tuple := range tuples {
+		if userID, ok := parseUserID(tuple.SubjectID); ok {
+			grantRole(roles, userID, tuple.Relation)
+		}
+	}
+
+	return roles, nil
+}
+
+// grantRole gives the user the role unless they already have a more privileged one, other relations are ignored.
+func grantRole(roles map[uint]string, userID uint, role string) {
+	rank, ok := roleRanks[role]
+	if ok && rank > roleRanks[roles[userID]] {
+		roles[userID] = role
+	}
+}
+
+func parseUserID(subjectID string) (uint, bool) {
+	parsedID, err := strconv.ParseUint(subjectID, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	userID, err := safecast.ToUint(parsedID)
+	if err != nil {
+		return 0, false
+	}
+
+	return userID, true
+}
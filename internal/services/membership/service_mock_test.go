@@ -0,0 +1,570 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=membership_test -typed=true
+//
+
+// Package membership_test is a generated GoMock package.
+package membership_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "echo-app/internal/models"
+	permify "echo-app/internal/permify"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockdomainRepository is a mock of domainRepository interface.
+type MockdomainRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockdomainRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockdomainRepositoryMockRecorder is the mock recorder for MockdomainRepository.
+type MockdomainRepositoryMockRecorder struct {
+	mock *MockdomainRepository
+}
+
+// NewMockdomainRepository creates a new mock instance.
+func NewMockdomainRepository(ctrl *gomock.Controller) *MockdomainRepository {
+	mock := &MockdomainRepository{ctrl: ctrl}
+	mock.recorder = &MockdomainRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdomainRepository) EXPECT() *MockdomainRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockdomainRepository) GetByID(ctx context.Context, id uuid.UUID) (models.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockdomainRepositoryMockRecorder) GetByID(ctx, id any) *MockdomainRepositoryGetByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockdomainRepository)(nil).GetByID), ctx, id)
+	return &MockdomainRepositoryGetByIDCall{Call: call}
+}
+
+// MockdomainRepositoryGetByIDCall wrap *gomock.Call
+type MockdomainRepositoryGetByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockdomainRepositoryGetByIDCall) Return(arg0 models.Domain, arg1 error) *MockdomainRepositoryGetByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockdomainRepositoryGetByIDCall) Do(f func(context.Context, uuid.UUID) (models.Domain, error)) *MockdomainRepositoryGetByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockdomainRepositoryGetByIDCall) DoAndReturn(f func(context.Context, uuid.UUID) (models.Domain, error)) *MockdomainRepositoryGetByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetForUpdate mocks base method.
+func (m *MockdomainRepository) GetForUpdate(ctx context.Context, id uuid.UUID) (models.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForUpdate", ctx, id)
+	ret0, _ := ret[0].(models.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForUpdate indicates an expected call of GetForUpdate.
+func (mr *MockdomainRepositoryMockRecorder) GetForUpdate(ctx, id any) *MockdomainRepositoryGetForUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForUpdate", reflect.TypeOf((*MockdomainRepository)(nil).GetForUpdate), ctx, id)
+	return &MockdomainRepositoryGetForUpdateCall{Call: call}
+}
+
+// MockdomainRepositoryGetForUpdateCall wrap *gomock.Call
+type MockdomainRepositoryGetForUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockdomainRepositoryGetForUpdateCall) Return(arg0 models.Domain, arg1 error) *MockdomainRepositoryGetForUpdateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockdomainRepositoryGetForUpdateCall) Do(f func(context.Context, uuid.UUID) (models.Domain, error)) *MockdomainRepositoryGetForUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockdomainRepositoryGetForUpdateCall) DoAndReturn(f func(context.Context, uuid.UUID) (models.Domain, error)) *MockdomainRepositoryGetForUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockuserRepository is a mock of userRepository interface.
+type MockuserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockuserRepositoryMockRecorder is the mock recorder for MockuserRepository.
+type MockuserRepositoryMockRecorder struct {
+	mock *MockuserRepository
+}
+
+// NewMockuserRepository creates a new mock instance.
+func NewMockuserRepository(ctrl *gomock.Controller) *MockuserRepository {
+	mock := &MockuserRepository{ctrl: ctrl}
+	mock.recorder = &MockuserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserRepository) EXPECT() *MockuserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockuserRepository) GetByID(ctx context.Context, id uint) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockuserRepositoryMockRecorder) GetByID(ctx, id any) *MockuserRepositoryGetByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockuserRepository)(nil).GetByID), ctx, id)
+	return &MockuserRepositoryGetByIDCall{Call: call}
+}
+
+// MockuserRepositoryGetByIDCall wrap *gomock.Call
+type MockuserRepositoryGetByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRepositoryGetByIDCall) Return(arg0 models.User, arg1 error) *MockuserRepositoryGetByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRepositoryGetByIDCall) Do(f func(context.Context, uint) (models.User, error)) *MockuserRepositoryGetByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRepositoryGetByIDCall) DoAndReturn(f func(context.Context, uint) (models.User, error)) *MockuserRepositoryGetByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByIDs mocks base method.
+func (m *MockuserRepository) GetByIDs(ctx context.Context, ids []uint) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockuserRepositoryMockRecorder) GetByIDs(ctx, ids any) *MockuserRepositoryGetByIDsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockuserRepository)(nil).GetByIDs), ctx, ids)
+	return &MockuserRepositoryGetByIDsCall{Call: call}
+}
+
+// MockuserRepositoryGetByIDsCall wrap *gomock.Call
+type MockuserRepositoryGetByIDsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRepositoryGetByIDsCall) Return(arg0 []models.User, arg1 error) *MockuserRepositoryGetByIDsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRepositoryGetByIDsCall) Do(f func(context.Context, []uint) ([]models.User, error)) *MockuserRepositoryGetByIDsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRepositoryGetByIDsCall) DoAndReturn(f func(context.Context, []uint) ([]models.User, error)) *MockuserRepositoryGetByIDsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockuserRepository) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockuserRepositoryMockRecorder) GetUserByEmail(ctx, email any) *MockuserRepositoryGetUserByEmailCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockuserRepository)(nil).GetUserByEmail), ctx, email)
+	return &MockuserRepositoryGetUserByEmailCall{Call: call}
+}
+
+// MockuserRepositoryGetUserByEmailCall wrap *gomock.Call
+type MockuserRepositoryGetUserByEmailCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRepositoryGetUserByEmailCall) Return(arg0 models.User, arg1 error) *MockuserRepositoryGetUserByEmailCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRepositoryGetUserByEmailCall) Do(f func(context.Context, string) (models.User, error)) *MockuserRepositoryGetUserByEmailCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRepositoryGetUserByEmailCall) DoAndReturn(f func(context.Context, string) (models.User, error)) *MockuserRepositoryGetUserByEmailCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockrelationshipReader is a mock of relationshipReader interface.
+type MockrelationshipReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockrelationshipReaderMockRecorder
+	isgomock struct{}
+}
+
+// MockrelationshipReaderMockRecorder is the mock recorder for MockrelationshipReader.
+type MockrelationshipReaderMockRecorder struct {
+	mock *MockrelationshipReader
+}
+
+// NewMockrelationshipReader creates a new mock instance.
+func NewMockrelationshipReader(ctrl *gomock.Controller) *MockrelationshipReader {
+	mock := &MockrelationshipReader{ctrl: ctrl}
+	mock.recorder = &MockrelationshipReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrelationshipReader) EXPECT() *MockrelationshipReaderMockRecorder {
+	return m.recorder
+}
+
+// Read mocks base method.
+func (m *MockrelationshipReader) Read(ctx context.Context, filter permify.Tuple, snapToken string) ([]permify.Tuple, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, filter, snapToken)
+	ret0, _ := ret[0].([]permify.Tuple)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockrelationshipReaderMockRecorder) Read(ctx, filter, snapToken any) *MockrelationshipReaderReadCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockrelationshipReader)(nil).Read), ctx, filter, snapToken)
+	return &MockrelationshipReaderReadCall{Call: call}
+}
+
+// MockrelationshipReaderReadCall wrap *gomock.Call
+type MockrelationshipReaderReadCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrelationshipReaderReadCall) Return(arg0 []permify.Tuple, arg1 error) *MockrelationshipReaderReadCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrelationshipReaderReadCall) Do(f func(context.Context, permify.Tuple, string) ([]permify.Tuple, error)) *MockrelationshipReaderReadCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrelationshipReaderReadCall) DoAndReturn(f func(context.Context, permify.Tuple, string) ([]permify.Tuple, error)) *MockrelationshipReaderReadCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Mocktransactor is a mock of transactor interface.
+type Mocktransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactorMockRecorder
+	isgomock struct{}
+}
+
+// MocktransactorMockRecorder is the mock recorder for Mocktransactor.
+type MocktransactorMockRecorder struct {
+	mock *Mocktransactor
+}
+
+// NewMocktransactor creates a new mock instance.
+func NewMocktransactor(ctrl *gomock.Controller) *Mocktransactor {
+	mock := &Mocktransactor{ctrl: ctrl}
+	mock.recorder = &MocktransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocktransactor) EXPECT() *MocktransactorMockRecorder {
+	return m.recorder
+}
+
+// Transaction mocks base method.
+func (m *Mocktransactor) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MocktransactorMockRecorder) Transaction(ctx, fn any) *MocktransactorTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*Mocktransactor)(nil).Transaction), ctx, fn)
+	return &MocktransactorTransactionCall{Call: call}
+}
+
+// MocktransactorTransactionCall wrap *gomock.Call
+type MocktransactorTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktransactorTransactionCall) Return(arg0 error) *MocktransactorTransactionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktransactorTransactionCall) Do(f func(context.Context, func(context.Context) error) error) *MocktransactorTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktransactorTransactionCall) DoAndReturn(f func(context.Context, func(context.Context) error) error) *MocktransactorTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockrelationshipOutbox is a mock of relationshipOutbox interface.
+type MockrelationshipOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockrelationshipOutboxMockRecorder
+	isgomock struct{}
+}
+
+// MockrelationshipOutboxMockRecorder is the mock recorder for MockrelationshipOutbox.
+type MockrelationshipOutboxMockRecorder struct {
+	mock *MockrelationshipOutbox
+}
+
+// NewMockrelationshipOutbox creates a new mock instance.
+func NewMockrelationshipOutbox(ctrl *gomock.Controller) *MockrelationshipOutbox {
+	mock := &MockrelationshipOutbox{ctrl: ctrl}
+	mock.recorder = &MockrelationshipOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrelationshipOutbox) EXPECT() *MockrelationshipOutboxMockRecorder {
+	return m.recorder
+}
+
+// DeleteSubject mocks base method.
+func (m *MockrelationshipOutbox) DeleteSubject(ctx context.Context, entityType, entityID, subjectType, subjectID string) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubject", ctx, entityType, entityID, subjectType, subjectID)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSubject indicates an expected call of DeleteSubject.
+func (mr *MockrelationshipOutboxMockRecorder) DeleteSubject(ctx, entityType, entityID, subjectType, subjectID any) *MockrelationshipOutboxDeleteSubjectCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubject", reflect.TypeOf((*MockrelationshipOutbox)(nil).DeleteSubject), ctx, entityType, entityID, subjectType, subjectID)
+	return &MockrelationshipOutboxDeleteSubjectCall{Call: call}
+}
+
+// MockrelationshipOutboxDeleteSubjectCall wrap *gomock.Call
+type MockrelationshipOutboxDeleteSubjectCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrelationshipOutboxDeleteSubjectCall) Return(arg0 uint, arg1 error) *MockrelationshipOutboxDeleteSubjectCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrelationshipOutboxDeleteSubjectCall) Do(f func(context.Context, string, string, string, string) (uint, error)) *MockrelationshipOutboxDeleteSubjectCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrelationshipOutboxDeleteSubjectCall) DoAndReturn(f func(context.Context, string, string, string, string) (uint, error)) *MockrelationshipOutboxDeleteSubjectCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Flush mocks base method.
+func (m *MockrelationshipOutbox) Flush(ctx context.Context, ids []uint) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Flush", ctx, ids)
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockrelationshipOutboxMockRecorder) Flush(ctx, ids any) *MockrelationshipOutboxFlushCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockrelationshipOutbox)(nil).Flush), ctx, ids)
+	return &MockrelationshipOutboxFlushCall{Call: call}
+}
+
+// MockrelationshipOutboxFlushCall wrap *gomock.Call
+type MockrelationshipOutboxFlushCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrelationshipOutboxFlushCall) Return() *MockrelationshipOutboxFlushCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrelationshipOutboxFlushCall) Do(f func(context.Context, []uint)) *MockrelationshipOutboxFlushCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrelationshipOutboxFlushCall) DoAndReturn(f func(context.Context, []uint)) *MockrelationshipOutboxFlushCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Pending mocks base method.
+func (m *MockrelationshipOutbox) Pending(ctx context.Context, entityType, entityID string) ([]models.OutboxEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pending", ctx, entityType, entityID)
+	ret0, _ := ret[0].([]models.OutboxEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pending indicates an expected call of Pending.
+func (mr *MockrelationshipOutboxMockRecorder) Pending(ctx, entityType, entityID any) *MockrelationshipOutboxPendingCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockrelationshipOutbox)(nil).Pending), ctx, entityType, entityID)
+	return &MockrelationshipOutboxPendingCall{Call: call}
+}
+
+// MockrelationshipOutboxPendingCall wrap *gomock.Call
+type MockrelationshipOutboxPendingCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrelationshipOutboxPendingCall) Return(arg0 []models.OutboxEntry, arg1 error) *MockrelationshipOutboxPendingCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrelationshipOutboxPendingCall) Do(f func(context.Context, string, string) ([]models.OutboxEntry, error)) *MockrelationshipOutboxPendingCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrelationshipOutboxPendingCall) DoAndReturn(f func(context.Context, string, string) ([]models.OutboxEntry, error)) *MockrelationshipOutboxPendingCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Write mocks base method.
+func (m *MockrelationshipOutbox) Write(ctx context.Context, tuples ...permify.Tuple) ([]uint, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range tuples {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Write", varargs...)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockrelationshipOutboxMockRecorder) Write(ctx any, tuples ...any) *MockrelationshipOutboxWriteCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, tuples...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockrelationshipOutbox)(nil).Write), varargs...)
+	return &MockrelationshipOutboxWriteCall{Call: call}
+}
+
+// MockrelationshipOutboxWriteCall wrap *gomock.Call
+type MockrelationshipOutboxWriteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrelationshipOutboxWriteCall) Return(arg0 []uint, arg1 error) *MockrelationshipOutboxWriteCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrelationshipOutboxWriteCall) Do(f func(context.Context, ...permify.Tuple) ([]uint, error)) *MockrelationshipOutboxWriteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrelationshipOutboxWriteCall) DoAndReturn(f func(context.Context, ...permify.Tuple) ([]uint, error)) *MockrelationshipOutboxWriteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,298 @@
+package membership_test
+
+import (
+	"context"
+	"testing"
+
+	"echo-app/internal/models"
+	"echo-app/internal/permify"
+	"echo-app/internal/services/membership"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type transactorStub struct{}
+
+func (transactorStub) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type membershipMocks struct {
+	domainRepository *MockdomainRepository
+	userRepository   *MockuserRepository
+	relationships    *MockrelationshipReader
+	outbox           *MockrelationshipOutbox
+}
+
+var domainID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+
+func newService(t *testing.T, tuples ...permify.Tuple) (membership.Service, membershipMocks) {
+	t.Helper()
+
+	return newServiceWithGroups(t, nil, tuples...)
+}
+
+func newServiceWithGroups(t *testing.T, groupMapping membership.GroupMapping, tuples ...permify.Tuple) (membership.Service, membershipMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mocks := membershipMocks{
+		domainRepository: NewMockdomainRepository(ctrl),
+		userRepository:   NewMockuserRepository(ctrl),
+		relationships:    NewMockrelationshipReader(ctrl),
+		outbox:           NewMockrelationshipOutbox(ctrl),
+	}
+
+	mocks.domainRepository.
+		EXPECT().
+		GetByID(gomock.Any(), domainID).
+		Return(models.Domain{ID: domainID, SnapToken: "snap-token"}, nil).
+		AnyTimes()
+
+	mocks.domainRepository.
+		EXPECT().
+		GetForUpdate(gomock.Any(), domainID).
+		Return(models.Domain{ID: domainID, SnapToken: "snap-token"}, nil).
+		AnyTimes()
+
+	mocks.relationships.
+		EXPECT().
+		Read(gomock.Any(), permify.Tuple{EntityType: "domain", EntityID: domainID.String(), SubjectType: "user"}, "snap-token").
+		Return(tuples, nil).
+		AnyTimes()
+
+	membershipService := membership.NewService(
+		mocks.domainRepository,
+		mocks.userRepository,
+		mocks.relationships,
+		transactorStub{},
+		mocks.outbox,
+		membership.GroupMappings{"corp": groupMapping},
+	)
+
+	return membershipService, mocks
+}
+
+func domainTuple(relation, userID string) permify.Tuple {
+	return permify.Tuple{
+		EntityType:  "domain",
+		EntityID:    domainID.String(),
+		Relation:    relation,
+		SubjectType: "user",
+		SubjectID:   userID,
+	}
+}
+
+func TestService_GetMembers(t *testing.T) {
+	membershipService, mocks := newService(t,
+		domainTuple("admin", "1"),
+		domainTuple("viewer", "2"),
+		domainTuple("member", "2"),
+		domainTuple("viewer", "3"),
+	)
+
+	users := []models.User{{Model: gorm.Model{ID: 1}}, {Model: gorm.Model{ID: 2}}, {Model: gorm.Model{ID: 3}}}
+
+	mocks.userRepository.
+		EXPECT().
+		GetByIDs(gomock.Any(), gomock.InAnyOrder([]uint{1, 2, 3})).
+		Return(users, nil)
+
+	members, err := membershipService.GetMembers(t.Context(), domainID)
+	require.NoError(t, err)
+
+	assert.Equal(t, []models.DomainMember{
+		{User: users[0], Role: "admin"},
+		{User: users[1], Role: "member"},
+		{User: users[2], Role: "viewer"},
+	}, members)
+}
+
+func TestService_AddMember(t *testing.T) {
+	t.Run("It should add the user to the domain", func(t *testing.T) {
+		membershipService, mocks := newService(t, domainTuple("admin", "1"))
+
+		user := models.User{Model: gorm.Model{ID: 2}, Email: "example@email.com"}
+
+		mocks.userRepository.
+			EXPECT().
+			GetUserByEmail(gomock.Any(), "example@email.com").
+			Return(user, nil)
+
+		expectPending(mocks)
+
+		gomock.InOrder(
+			mocks.outbox.
+				EXPECT().
+				DeleteSubject(gomock.Any(), "domain", domainID.String(), "user", "2").
+				Return(uint(1), nil),
+			mocks.outbox.
+				EXPECT().
+				Write(gomock.Any(), domainTuple("member", "2")).
+				Return([]uint{2}, nil),
+			mocks.outbox.
+				EXPECT().
+				Flush(gomock.Any(), []uint{1, 2}),
+		)
+
+		member, err := membershipService.AddMember(t.Context(), domainID, "example@email.com", "member")
+		require.NoError(t, err)
+
+		assert.Equal(t, models.DomainMember{User: user, Role: "member"}, member)
+	})
+
+	t.Run("It should reject an existing member", func(t *testing.T) {
+		membershipService, mocks := newService(t, domainTuple("admin", "1"), domainTuple("member", "2"))
+
+		mocks.userRepository.
+			EXPECT().
+			GetUserByEmail(gomock.Any(), "example@email.com").
+			Return(models.User{Model: gorm.Model{ID: 2}}, nil)
+
+		expectPending(mocks)
+
+		_, err := membershipService.AddMember(t.Context(), domainID, "example@email.com", "admin")
+		assert.ErrorIs(t, err, membership.ErrAlreadyMember)
+	})
+
+	t.Run("It should reject a member added concurrently that isn't dispatched yet", func(t *testing.T) {
+		// Permify doesn't know the user yet, the addition is only recorded in the outbox
+		membershipService, mocks := newService(t, domainTuple("admin", "1"))
+
+		mocks.userRepository.
+			EXPECT().
+			GetUserByEmail(gomock.Any(), "example@email.com").
+			Return(models.User{Model: gorm.Model{ID: 2}}, nil)
+
+		expectPending(mocks,
+			models.OutboxEntry{
+				Operation: models.OutboxDelete, EntityType: "domain", EntityID: domainID.String(), SubjectType: "user", SubjectID: "2",
+			},
+			models.OutboxEntry{
+				Operation: models.OutboxWrite, EntityType: "domain", EntityID: domainID.String(), Relation: "viewer",
+				SubjectType: "user", SubjectID: "2",
+			},
+		)
+
+		_, err := membershipService.AddMember(t.Context(), domainID, "example@email.com", "admin")
+		assert.ErrorIs(t, err, membership.ErrAlreadyMember)
+	})
+}
+
+// expectPending makes the outbox return the entries as the undispatched changes of the domain.
+func expectPending(mocks membershipMocks, entries ...models.OutboxEntry) {
+	mocks.outbox.
+		EXPECT().
+		Pending(gomock.Any(), "domain", domainID.String()).
+		Return(entries, nil)
+}
+
+func TestService_ChangeRole(t *testing.T) {
+	t.Run("It should change the role of the member", func(t *testing.T) {
+		membershipService, mocks := newService(t, domainTuple("admin", "1"), domainTuple("admin", "2"))
+
+		expectPending(mocks)
+
+		user := models.User{Model: gorm.Model{ID: 1}}
+
+		mocks.userRepository.
+			EXPECT().
+			GetByID(gomock.Any(), uint(1)).
+			Return(user, nil)
+
+		gomock.InOrder(
+			mocks.outbox.
+				EXPECT().
+				DeleteSubject(gomock.Any(), "domain", domainID.String(), "user", "1").
+				Return(uint(1), nil),
+			mocks.outbox.
+				EXPECT().
+				Write(gomock.Any(), domainTuple("member", "1")).
+				Return([]uint{2}, nil),
+			mocks.outbox.
+				EXPECT().
+				Flush(gomock.Any(), []uint{1, 2}),
+		)
+
+		member, err := membershipService.ChangeRole(t.Context(), domainID, 1, "member")
+		require.NoError(t, err)
+
+		assert.Equal(t, models.DomainMember{User: user, Role: "member"}, member)
+	})
+
+	t.Run("It should not demote the last admin", func(t *testing.T) {
+		membershipService, mocks := newService(t, domainTuple("admin", "1"), domainTuple("member", "2"))
+
+		expectPending(mocks)
+
+		_, err := membershipService.ChangeRole(t.Context(), domainID, 1, "member")
+		assert.ErrorIs(t, err, membership.ErrLastAdmin)
+	})
+
+	t.Run("It should count the admins demoted by a concurrent change that isn't dispatched yet", func(t *testing.T) {
+		// Permify still has two admins, the demotion of the other admin is only recorded in the outbox
+		membershipService, mocks := newService(t, domainTuple("admin", "1"), domainTuple("admin", "2"))
+
+		expectPending(mocks,
+			models.OutboxEntry{
+				Operation: models.OutboxDelete, EntityType: "domain", EntityID: domainID.String(), SubjectType: "user", SubjectID: "2",
+			},
+			models.OutboxEntry{
+				Operation: models.OutboxWrite, EntityType: "domain", EntityID: domainID.String(), Relation: "member",
+				SubjectType: "user", SubjectID: "2",
+			},
+		)
+
+		_, err := membershipService.ChangeRole(t.Context(), domainID, 1, "member")
+		assert.ErrorIs(t, err, membership.ErrLastAdmin)
+	})
+
+	t.Run("It should reject an unknown member", func(t *testing.T) {
+		membershipService, mocks := newService(t, domainTuple("admin", "1"))
+
+		expectPending(mocks)
+
+		_, err := membershipService.ChangeRole(t.Context(), domainID, 3, "admin")
+		assert.ErrorIs(t, err, models.ErrMemberNotFound)
+	})
+}
+
+func TestService_RemoveMember(t *testing.T) {
+	t.Run("It should remove the member", func(t *testing.T) {
+		membershipService, mocks := newService(t, domainTuple("admin", "1"), domainTuple("member", "2"))
+
+		expectPending(mocks)
+
+		mocks.userRepository.
+			EXPECT().
+			GetByID(gomock.Any(), uint(2)).
+			Return(models.User{Model: gorm.Model{ID: 2}}, nil)
+
+		mocks.outbox.
+			EXPECT().
+			DeleteSubject(gomock.Any(), "domain", domainID.String(), "user", "2").
+			Return(uint(1), nil)
+
+		mocks.outbox.
+			EXPECT().
+			Flush(gomock.Any(), []uint{1})
+
+		err := membershipService.RemoveMember(t.Context(), domainID, 2)
+		require.NoError(t, err)
+	})
+
+	t.Run("It should not remove the last admin when another one is removed concurrently", func(t *testing.T) {
+		membershipService, mocks := newService(t, domainTuple("admin", "1"), domainTuple("admin", "2"))
+
+		expectPending(mocks, models.OutboxEntry{
+			Operation: models.OutboxDelete, EntityType: "domain", EntityID: domainID.String(), SubjectType: "user", SubjectID: "1",
+		})
+
+		err := membershipService.RemoveMember(t.Context(), domainID, 2)
+		assert.ErrorIs(t, err, membership.ErrLastAdmin)
+	})
+}
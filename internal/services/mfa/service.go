@@ -0,0 +1,291 @@
+package mfa
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"echo-app/internal/config"
+	"echo-app/internal/models"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+const (
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+
+	// maxFailedAttempts invalid codes in a row lock the second step of the login for lockoutDuration.
+	maxFailedAttempts = 5
+	lockoutDuration   = 15 * time.Minute
+)
+
+var (
+	// ErrMFANotAvailable is returned for OIDC users, their identity provider handles MFA.
+	ErrMFANotAvailable   = errors.New("two-factor authentication is only available for password accounts")
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnrolled    = errors.New("two-factor authentication is not enrolled")
+	ErrInvalidCode       = errors.New("invalid two-factor authentication code")
+	ErrTooManyAttempts   = errors.New("too many invalid two-factor authentication codes")
+)
+
+type userRepository interface {
+	GetByID(ctx context.Context, id uint) (models.User, error)
+	Update(ctx context.Context, user *models.User) error
+	UseTOTPStep(ctx context.Context, id uint, step int64) (bool, error)
+	RecordMFAFailure(ctx context.Context, id uint, maxAttempts int, lockedUntil time.Time) (bool, error)
+	ResetMFAFailures(ctx context.Context, id uint) error
+}
+
+type recoveryCodeRepository interface {
+	Replace(ctx context.Context, userID uint, codes []models.MFARecoveryCode) error
+	ListUnused(ctx context.Context, userID uint) ([]models.MFARecoveryCode, error)
+	MarkUsed(ctx context.Context, id uint, usedAt time.Time) (bool, error)
+}
+
+type transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Enrollment is the TOTP secret of a user who hasn't confirmed it with a code yet.
+type Enrollment struct {
+	Secret string
+	// URI is the otpauth URI of the secret, shown as a QR code to authenticator apps.
+	URI string
+}
+
+// Service manages the TOTP two-factor authentication of password accounts.
+type Service struct {
+	userRepository         userRepository
+	recoveryCodeRepository recoveryCodeRepository
+	transactor             transactor
+	issuer                 string
+	now                    func() time.Time
+}
+
+func NewService(
+	userRepository userRepository,
+	recoveryCodeRepository recoveryCodeRepository,
+	transactor transactor,
+	cfg config.MFA,
+) *Service {
+	return &Service{
+		userRepository:         userRepository,
+		recoveryCodeRepository: recoveryCodeRepository,
+		transactor:             transactor,
+		issuer:                 cfg.Issuer,
+		now:                    time.Now,
+	}
+}
+
+// Enroll generates a new TOTP secret of the user. It's only enabled once Confirm accepted a code of it,
+// enrolling again before that replaces the secret.
+func (s *Service) Enroll(ctx context.Context, userID uint) (Enrollment, error) {
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return Enrollment{}, fmt.Errorf("get user by id from repository: %w", err)
+	}
+
+	if user.Password == "" {
+		return Enrollment{}, ErrMFANotAvailable
+	}
+
+	if user.TOTPEnabledAt != nil {
+		return Enrollment{}, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return Enrollment{}, err
+	}
+
+	user.TOTPSecret = secret
+
+	if err := s.userRepository.Update(ctx, &user); err != nil {
+		return Enrollment{}, fmt.Errorf("update user in repository: %w", err)
+	}
+
+	return Enrollment{Secret: secret, URI: otpauthURI(s.issuer, user.Email, secret)}, nil
+}
+
+// Confirm enables the enrolled TOTP secret of the user if the code is valid
+// and returns the recovery codes, which can't be read again later.
+func (s *Service) Confirm(ctx context.Context, userID uint, code string) ([]string, error) {
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user by id from repository: %w", err)
+	}
+
+	if user.Password == "" {
+		return nil, ErrMFANotAvailable
+	}
+
+	if user.TOTPEnabledAt != nil {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	if user.TOTPSecret == "" {
+		return nil, ErrMFANotEnrolled
+	}
+
+	now := s.now()
+
+	step, ok := validateTOTP(user.TOTPSecret, normalizeCode(code), now, user.TOTPLastStep)
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+
+	codes, hashedCodes, err := newRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.TOTPEnabledAt = &now
+	user.TOTPLastStep = step
+
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.Update(ctx, &user); err != nil {
+			return fmt.Errorf("update user in repository: %w", err)
+		}
+
+		if err := s.recoveryCodeRepository.Replace(ctx, userID, hashedCodes); err != nil {
+			return fmt.Errorf("replace recovery codes in repository: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("enable TOTP: %w", err)
+	}
+
+	return codes, nil
+}
+
+// Verify checks the second factor of the user's login, a TOTP code or an unused recovery code.
+// After maxFailedAttempts invalid codes in a row every code is rejected with ErrTooManyAttempts for a while.
+// The counters are updated in place, so concurrent logins can't lose a failure or use the same code twice.
+func (s *Service) Verify(ctx context.Context, userID uint, code string) (models.User, error) {
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return models.User{}, fmt.Errorf("get user by id from repository: %w", err)
+	}
+
+	if !user.MFAEnabled() {
+		return models.User{}, ErrMFANotEnrolled
+	}
+
+	now := s.now()
+
+	if user.MFALockedUntil != nil && now.Before(*user.MFALockedUntil) {
+		return models.User{}, ErrTooManyAttempts
+	}
+
+	code = normalizeCode(code)
+
+	var valid bool
+	if len(code) == totpDigits {
+		valid, err = s.useTOTPCode(ctx, &user, code, now)
+	} else {
+		valid, err = s.useRecoveryCode(ctx, userID, code, now)
+	}
+
+	if err != nil {
+		return models.User{}, err
+	}
+
+	if !valid {
+		locked, err := s.userRepository.RecordMFAFailure(ctx, userID, maxFailedAttempts, now.Add(lockoutDuration))
+		if err != nil {
+			return models.User{}, fmt.Errorf("record failed attempt in repository: %w", err)
+		}
+
+		if locked {
+			return models.User{}, ErrTooManyAttempts
+		}
+
+		return models.User{}, ErrInvalidCode
+	}
+
+	if err := s.userRepository.ResetMFAFailures(ctx, userID); err != nil {
+		return models.User{}, fmt.Errorf("reset failed attempts in repository: %w", err)
+	}
+
+	user.MFAFailedAttempts = 0
+	user.MFALockedUntil = nil
+
+	return user, nil
+}
+
+// useTOTPCode records the time step of the code if it's valid, it's rejected if a login already used the step.
+func (s *Service) useTOTPCode(ctx context.Context, user *models.User, code string, now time.Time) (bool, error) {
+	step, ok := validateTOTP(user.TOTPSecret, code, now, user.TOTPLastStep)
+	if !ok {
+		return false, nil
+	}
+
+	used, err := s.userRepository.UseTOTPStep(ctx, user.ID, step)
+	if err != nil {
+		return false, fmt.Errorf("use TOTP step in repository: %w", err)
+	}
+
+	if used {
+		user.TOTPLastStep = step
+	}
+
+	return used, nil
+}
+
+// useRecoveryCode marks the unused recovery code of the user matching the code as used.
+func (s *Service) useRecoveryCode(ctx context.Context, userID uint, code string, now time.Time) (bool, error) {
+	codes, err := s.recoveryCodeRepository.ListUnused(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("list unused recovery codes from repository: %w", err)
+	}
+
+	for _, recoveryCode := range codes {
+		if bcrypt.CompareHashAndPassword([]byte(recoveryCode.CodeHash), []byte(code)) != nil {
+			continue
+		}
+
+		marked, err := s.recoveryCodeRepository.MarkUsed(ctx, recoveryCode.ID, now)
+		if err != nil {
+			return false, fmt.Errorf("mark recovery code as used: %w", err)
+		}
+
+		return marked, nil
+	}
+
+	return false, nil
+}
+
+// newRecoveryCodes returns the recovery codes formatted for the user together with their hashes to store.
+func newRecoveryCodes(userID uint) ([]string, []models.MFARecoveryCode, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashedCodes := make([]models.MFARecoveryCode, 0, recoveryCodeCount)
+
+	for range recoveryCodeCount {
+		// 10 base32 characters carry 50 random bits
+		code := strings.ToLower(rand.Text()[:recoveryCodeLength])
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, nil, fmt.Errorf("hash recovery code: %w", err)
+		}
+
+		half := recoveryCodeLength / 2
+		codes = append(codes, code[:half]+"-"+code[half:])
+		hashedCodes = append(hashedCodes, models.MFARecoveryCode{UserID: userID, CodeHash: string(hash)})
+	}
+
+	return codes, hashedCodes, nil
+}
+
+// normalizeCode accepts codes typed with spaces, dashes or in upper case.
+func normalizeCode(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(code))
+}
@@ -0,0 +1,438 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=mfa_test -typed=true
+//
+
+// Package mfa_test is a generated GoMock package.
+package mfa_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "echo-app/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockuserRepository is a mock of userRepository interface.
+type MockuserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockuserRepositoryMockRecorder is the mock recorder for MockuserRepository.
+type MockuserRepositoryMockRecorder struct {
+	mock *MockuserRepository
+}
+
+// NewMockuserRepository creates a new mock instance.
+func NewMockuserRepository(ctrl *gomock.Controller) *MockuserRepository {
+	mock := &MockuserRepository{ctrl: ctrl}
+	mock.recorder = &MockuserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserRepository) EXPECT() *MockuserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockuserRepository) GetByID(ctx context.Context, id uint) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockuserRepositoryMockRecorder) GetByID(ctx, id any) *MockuserRepositoryGetByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockuserRepository)(nil).GetByID), ctx, id)
+	return &MockuserRepositoryGetByIDCall{Call: call}
+}
+
+// MockuserRepositoryGetByIDCall wrap *gomock.Call
+type MockuserRepositoryGetByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRepositoryGetByIDCall) Return(arg0 models.User, arg1 error) *MockuserRepositoryGetByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRepositoryGetByIDCall) Do(f func(context.Context, uint) (models.User, error)) *MockuserRepositoryGetByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRepositoryGetByIDCall) DoAndReturn(f func(context.Context, uint) (models.User, error)) *MockuserRepositoryGetByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RecordMFAFailure mocks base method.
+func (m *MockuserRepository) RecordMFAFailure(ctx context.Context, id uint, maxAttempts int, lockedUntil time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordMFAFailure", ctx, id, maxAttempts, lockedUntil)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordMFAFailure indicates an expected call of RecordMFAFailure.
+func (mr *MockuserRepositoryMockRecorder) RecordMFAFailure(ctx, id, maxAttempts, lockedUntil any) *MockuserRepositoryRecordMFAFailureCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordMFAFailure", reflect.TypeOf((*MockuserRepository)(nil).RecordMFAFailure), ctx, id, maxAttempts, lockedUntil)
+	return &MockuserRepositoryRecordMFAFailureCall{Call: call}
+}
+
+// MockuserRepositoryRecordMFAFailureCall wrap *gomock.Call
+type MockuserRepositoryRecordMFAFailureCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRepositoryRecordMFAFailureCall) Return(arg0 bool, arg1 error) *MockuserRepositoryRecordMFAFailureCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRepositoryRecordMFAFailureCall) Do(f func(context.Context, uint, int, time.Time) (bool, error)) *MockuserRepositoryRecordMFAFailureCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRepositoryRecordMFAFailureCall) DoAndReturn(f func(context.Context, uint, int, time.Time) (bool, error)) *MockuserRepositoryRecordMFAFailureCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ResetMFAFailures mocks base method.
+func (m *MockuserRepository) ResetMFAFailures(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetMFAFailures", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetMFAFailures indicates an expected call of ResetMFAFailures.
+func (mr *MockuserRepositoryMockRecorder) ResetMFAFailures(ctx, id any) *MockuserRepositoryResetMFAFailuresCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetMFAFailures", reflect.TypeOf((*MockuserRepository)(nil).ResetMFAFailures), ctx, id)
+	return &MockuserRepositoryResetMFAFailuresCall{Call: call}
+}
+
+// MockuserRepositoryResetMFAFailuresCall wrap *gomock.Call
+type MockuserRepositoryResetMFAFailuresCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRepositoryResetMFAFailuresCall) Return(arg0 error) *MockuserRepositoryResetMFAFailuresCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRepositoryResetMFAFailuresCall) Do(f func(context.Context, uint) error) *MockuserRepositoryResetMFAFailuresCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRepositoryResetMFAFailuresCall) DoAndReturn(f func(context.Context, uint) error) *MockuserRepositoryResetMFAFailuresCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockuserRepository) Update(ctx context.Context, user *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockuserRepositoryMockRecorder) Update(ctx, user any) *MockuserRepositoryUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockuserRepository)(nil).Update), ctx, user)
+	return &MockuserRepositoryUpdateCall{Call: call}
+}
+
+// MockuserRepositoryUpdateCall wrap *gomock.Call
+type MockuserRepositoryUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRepositoryUpdateCall) Return(arg0 error) *MockuserRepositoryUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRepositoryUpdateCall) Do(f func(context.Context, *models.User) error) *MockuserRepositoryUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRepositoryUpdateCall) DoAndReturn(f func(context.Context, *models.User) error) *MockuserRepositoryUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UseTOTPStep mocks base method.
+func (m *MockuserRepository) UseTOTPStep(ctx context.Context, id uint, step int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPStep", ctx, id, step)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTOTPStep indicates an expected call of UseTOTPStep.
+func (mr *MockuserRepositoryMockRecorder) UseTOTPStep(ctx, id, step any) *MockuserRepositoryUseTOTPStepCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPStep", reflect.TypeOf((*MockuserRepository)(nil).UseTOTPStep), ctx, id, step)
+	return &MockuserRepositoryUseTOTPStepCall{Call: call}
+}
+
+// MockuserRepositoryUseTOTPStepCall wrap *gomock.Call
+type MockuserRepositoryUseTOTPStepCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRepositoryUseTOTPStepCall) Return(arg0 bool, arg1 error) *MockuserRepositoryUseTOTPStepCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRepositoryUseTOTPStepCall) Do(f func(context.Context, uint, int64) (bool, error)) *MockuserRepositoryUseTOTPStepCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRepositoryUseTOTPStepCall) DoAndReturn(f func(context.Context, uint, int64) (bool, error)) *MockuserRepositoryUseTOTPStepCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockrecoveryCodeRepository is a mock of recoveryCodeRepository interface.
+type MockrecoveryCodeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockrecoveryCodeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockrecoveryCodeRepositoryMockRecorder is the mock recorder for MockrecoveryCodeRepository.
+type MockrecoveryCodeRepositoryMockRecorder struct {
+	mock *MockrecoveryCodeRepository
+}
+
+// NewMockrecoveryCodeRepository creates a new mock instance.
+func NewMockrecoveryCodeRepository(ctrl *gomock.Controller) *MockrecoveryCodeRepository {
+	mock := &MockrecoveryCodeRepository{ctrl: ctrl}
+	mock.recorder = &MockrecoveryCodeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrecoveryCodeRepository) EXPECT() *MockrecoveryCodeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListUnused mocks base method.
+func (m *MockrecoveryCodeRepository) ListUnused(ctx context.Context, userID uint) ([]models.MFARecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnused", ctx, userID)
+	ret0, _ := ret[0].([]models.MFARecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnused indicates an expected call of ListUnused.
+func (mr *MockrecoveryCodeRepositoryMockRecorder) ListUnused(ctx, userID any) *MockrecoveryCodeRepositoryListUnusedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnused", reflect.TypeOf((*MockrecoveryCodeRepository)(nil).ListUnused), ctx, userID)
+	return &MockrecoveryCodeRepositoryListUnusedCall{Call: call}
+}
+
+// MockrecoveryCodeRepositoryListUnusedCall wrap *gomock.Call
+type MockrecoveryCodeRepositoryListUnusedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrecoveryCodeRepositoryListUnusedCall) Return(arg0 []models.MFARecoveryCode, arg1 error) *MockrecoveryCodeRepositoryListUnusedCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrecoveryCodeRepositoryListUnusedCall) Do(f func(context.Context, uint) ([]models.MFARecoveryCode, error)) *MockrecoveryCodeRepositoryListUnusedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrecoveryCodeRepositoryListUnusedCall) DoAndReturn(f func(context.Context, uint) ([]models.MFARecoveryCode, error)) *MockrecoveryCodeRepositoryListUnusedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MarkUsed mocks base method.
+func (m *MockrecoveryCodeRepository) MarkUsed(ctx context.Context, id uint, usedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id, usedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockrecoveryCodeRepositoryMockRecorder) MarkUsed(ctx, id, usedAt any) *MockrecoveryCodeRepositoryMarkUsedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockrecoveryCodeRepository)(nil).MarkUsed), ctx, id, usedAt)
+	return &MockrecoveryCodeRepositoryMarkUsedCall{Call: call}
+}
+
+// MockrecoveryCodeRepositoryMarkUsedCall wrap *gomock.Call
+type MockrecoveryCodeRepositoryMarkUsedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrecoveryCodeRepositoryMarkUsedCall) Return(arg0 bool, arg1 error) *MockrecoveryCodeRepositoryMarkUsedCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrecoveryCodeRepositoryMarkUsedCall) Do(f func(context.Context, uint, time.Time) (bool, error)) *MockrecoveryCodeRepositoryMarkUsedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrecoveryCodeRepositoryMarkUsedCall) DoAndReturn(f func(context.Context, uint, time.Time) (bool, error)) *MockrecoveryCodeRepositoryMarkUsedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Replace mocks base method.
+func (m *MockrecoveryCodeRepository) Replace(ctx context.Context, userID uint, codes []models.MFARecoveryCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, userID, codes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockrecoveryCodeRepositoryMockRecorder) Replace(ctx, userID, codes any) *MockrecoveryCodeRepositoryReplaceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockrecoveryCodeRepository)(nil).Replace), ctx, userID, codes)
+	return &MockrecoveryCodeRepositoryReplaceCall{Call: call}
+}
+
+// MockrecoveryCodeRepositoryReplaceCall wrap *gomock.Call
+type MockrecoveryCodeRepositoryReplaceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrecoveryCodeRepositoryReplaceCall) Return(arg0 error) *MockrecoveryCodeRepositoryReplaceCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrecoveryCodeRepositoryReplaceCall) Do(f func(context.Context, uint, []models.MFARecoveryCode) error) *MockrecoveryCodeRepositoryReplaceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrecoveryCodeRepositoryReplaceCall) DoAndReturn(f func(context.Context, uint, []models.MFARecoveryCode) error) *MockrecoveryCodeRepositoryReplaceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Mocktransactor is a mock of transactor interface.
+type Mocktransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactorMockRecorder
+	isgomock struct{}
+}
+
+// MocktransactorMockRecorder is the mock recorder for Mocktransactor.
+type MocktransactorMockRecorder struct {
+	mock *Mocktransactor
+}
+
+// NewMocktransactor creates a new mock instance.
+func NewMocktransactor(ctrl *gomock.Controller) *Mocktransactor {
+	mock := &Mocktransactor{ctrl: ctrl}
+	mock.recorder = &MocktransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocktransactor) EXPECT() *MocktransactorMockRecorder {
+	return m.recorder
+}
+
+// Transaction mocks base method.
+func (m *Mocktransactor) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MocktransactorMockRecorder) Transaction(ctx, fn any) *MocktransactorTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*Mocktransactor)(nil).Transaction), ctx, fn)
+	return &MocktransactorTransactionCall{Call: call}
+}
+
+// MocktransactorTransactionCall wrap *gomock.Call
+type MocktransactorTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktransactorTransactionCall) Return(arg0 error) *MocktransactorTransactionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktransactorTransactionCall) Do(f func(context.Context, func(context.Context) error) error) *MocktransactorTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktransactorTransactionCall) DoAndReturn(f func(context.Context, func(context.Context) error) error) *MocktransactorTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,384 @@
+package mfa_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"echo-app/internal/config"
+	"echo-app/internal/models"
+	"echo-app/internal/services/mfa"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// rfcSecret is the SHA1 secret of the RFC 6238 test vectors.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+type transactorStub struct{}
+
+func (transactorStub) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type serviceMocks struct {
+	userRepository         *MockuserRepository
+	recoveryCodeRepository *MockrecoveryCodeRepository
+}
+
+func newService(t *testing.T) (*mfa.Service, serviceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mocks := serviceMocks{
+		userRepository:         NewMockuserRepository(ctrl),
+		recoveryCodeRepository: NewMockrecoveryCodeRepository(ctrl),
+	}
+
+	service := mfa.NewService(mocks.userRepository, mocks.recoveryCodeRepository, transactorStub{}, config.MFA{Issuer: "Echo App"})
+
+	return service, mocks
+}
+
+// totpCode computes the code of the secret at the time independently of the service.
+func totpCode(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	require.NoError(t, err)
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(at.Unix()/30))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+
+	return fmt.Sprintf("%06d", (binary.BigEndian.Uint32(sum[offset:])&0x7fffffff)%1_000_000)
+}
+
+func TestTOTPCode(t *testing.T) {
+	// RFC 6238 appendix B, the last 6 digits of the 8 digit codes
+	assert.Equal(t, "287082", totpCode(t, rfcSecret, time.Unix(59, 0)))
+	assert.Equal(t, "081804", totpCode(t, rfcSecret, time.Unix(1111111109, 0)))
+}
+
+func passwordUser() models.User {
+	return models.User{Model: gorm.Model{ID: 7}, Email: "john.doe@example.com", Password: "hash"}
+}
+
+func TestService_Enroll(t *testing.T) {
+	t.Run("It should generate a secret with its otpauth URI", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.userRepository.
+			EXPECT().
+			GetByID(gomock.Any(), uint(7)).
+			Return(passwordUser(), nil)
+
+		var updated models.User
+
+		mocks.userRepository.
+			EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, user *models.User) error {
+				updated = *user
+				return nil
+			})
+
+		enrollment, err := service.Enroll(t.Context(), 7)
+		require.NoError(t, err)
+
+		assert.Len(t, enrollment.Secret, 32)
+		assert.Equal(t, enrollment.Secret, updated.TOTPSecret)
+		assert.Nil(t, updated.TOTPEnabledAt)
+
+		uri, err := url.Parse(enrollment.URI)
+		require.NoError(t, err)
+
+		assert.Equal(t, "otpauth", uri.Scheme)
+		assert.Equal(t, "totp", uri.Host)
+		assert.Equal(t, "/Echo App:john.doe@example.com", uri.Path)
+		assert.Equal(t, enrollment.Secret, uri.Query().Get("secret"))
+		assert.Equal(t, "Echo App", uri.Query().Get("issuer"))
+	})
+
+	t.Run("It should not enroll an OIDC user", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.userRepository.
+			EXPECT().
+			GetByID(gomock.Any(), uint(7)).
+			Return(models.User{Model: gorm.Model{ID: 7}}, nil)
+
+		_, err := service.Enroll(t.Context(), 7)
+		assert.ErrorIs(t, err, mfa.ErrMFANotAvailable)
+	})
+
+	t.Run("It should not enroll a user with MFA enabled", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		enabledAt := time.Now()
+		user := passwordUser()
+		user.TOTPEnabledAt = &enabledAt
+
+		mocks.userRepository.
+			EXPECT().
+			GetByID(gomock.Any(), uint(7)).
+			Return(user, nil)
+
+		_, err := service.Enroll(t.Context(), 7)
+		assert.ErrorIs(t, err, mfa.ErrMFAAlreadyEnabled)
+	})
+}
+
+func TestService_Confirm(t *testing.T) {
+	t.Run("It should enable MFA and return the recovery codes", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		user := passwordUser()
+		user.TOTPSecret = rfcSecret
+
+		mocks.userRepository.
+			EXPECT().
+			GetByID(gomock.Any(), uint(7)).
+			Return(user, nil)
+
+		var updated models.User
+
+		mocks.userRepository.
+			EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, user *models.User) error {
+				updated = *user
+				return nil
+			})
+
+		var stored []models.MFARecoveryCode
+
+		mocks.recoveryCodeRepository.
+			EXPECT().
+			Replace(gomock.Any(), uint(7), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, codes []models.MFARecoveryCode) error {
+				stored = codes
+				return nil
+			})
+
+		codes, err := service.Confirm(t.Context(), 7, totpCode(t, rfcSecret, time.Now()))
+		require.NoError(t, err)
+
+		assert.NotNil(t, updated.TOTPEnabledAt)
+		assert.NotZero(t, updated.TOTPLastStep)
+
+		require.Len(t, codes, 10)
+		require.Len(t, stored, 10)
+
+		for i, code := range codes {
+			assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, code)
+			assert.Equal(t, uint(7), stored[i].UserID)
+			assert.NotContains(t, stored[i].CodeHash, code)
+			assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(stored[i].CodeHash), []byte(strings.ReplaceAll(code, "-", ""))))
+		}
+	})
+
+	t.Run("It should reject an invalid code", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		user := passwordUser()
+		user.TOTPSecret = rfcSecret
+
+		mocks.userRepository.
+			EXPECT().
+			GetByID(gomock.Any(), uint(7)).
+			Return(user, nil)
+
+		_, err := service.Confirm(t.Context(), 7, totpCode(t, rfcSecret, time.Now().Add(time.Hour)))
+		assert.ErrorIs(t, err, mfa.ErrInvalidCode)
+	})
+
+	t.Run("It should reject a user who didn't enroll", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.userRepository.
+			EXPECT().
+			GetByID(gomock.Any(), uint(7)).
+			Return(passwordUser(), nil)
+
+		_, err := service.Confirm(t.Context(), 7, "123456")
+		assert.ErrorIs(t, err, mfa.ErrMFANotEnrolled)
+	})
+}
+
+func enabledUser() models.User {
+	enabledAt := time.Now().Add(-time.Hour)
+
+	user := passwordUser()
+	user.TOTPSecret = rfcSecret
+	user.TOTPEnabledAt = &enabledAt
+
+	return user
+}
+
+func TestService_Verify(t *testing.T) {
+	t.Run("It should accept a TOTP code and reset the failed attempts", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		user := enabledUser()
+		user.MFAFailedAttempts = 2
+
+		mocks.userRepository.
+			EXPECT().
+			GetByID(gomock.Any(), uint(7)).
+			Return(user, nil)
+
+		mocks.userRepository.
+			EXPECT().
+			UseTOTPStep(gomock.Any(), uint(7), gomock.Any()).
+			Return(true, nil)
+
+		mocks.userRepository.
+			EXPECT().
+			ResetMFAFailures(gomock.Any(), uint(7)).
+			Return(nil)
+
+		code := totpCode(t, rfcSecret, time.Now())
+
+		verified, err := service.Verify(t.Context(), 7, code[:3]+" "+code[3:])
+		require.NoError(t, err)
+
+		assert.Equal(t, uint(7), verified.ID)
+		assert.Zero(t, verified.MFAFailedAttempts)
+		assert.NotZero(t, verified.TOTPLastStep)
+	})
+
+	t.Run("It should reject a TOTP code another login already used", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.userRepository.
+			EXPECT().
+			GetByID(gomock.Any(), uint(7)).
+			Return(enabledUser(), nil)
+
+		mocks.userRepository.
+			EXPECT().
+			UseTOTPStep(gomock.Any(), uint(7), gomock.Any()).
+			Return(false, nil)
+
+		mocks.userRepository.
+			EXPECT().
+			RecordMFAFailure(gomock.Any(), uint(7), 5, gomock.Any()).
+			Return(false, nil)
+
+		_, err := service.Verify(t.Context(), 7, totpCode(t, rfcSecret, time.Now()))
+		assert.ErrorIs(t, err, mfa.ErrInvalidCode)
+	})
+
+	t.Run("It should accept an unused recovery code", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		hash, err := bcrypt.GenerateFromPassword([]byte("abcdefghij"), bcrypt.MinCost)
+		require.NoError(t, err)
+
+		mocks.userRepository.
+			EXPECT().
+			GetByID(gomock.Any(), uint(7)).
+			Return(enabledUser(), nil)
+
+		mocks.recoveryCodeRepository.
+			EXPECT().
+			ListUnused(gomock.Any(), uint(7)).
+			Return([]models.MFARecoveryCode{{ID: 3, UserID: 7, CodeHash: string(hash)}}, nil)
+
+		mocks.recoveryCodeRepository.
+			EXPECT().
+			MarkUsed(gomock.Any(), uint(3), gomock.Any()).
+			Return(true, nil)
+
+		mocks.userRepository.
+			EXPECT().
+			ResetMFAFailures(gomock.Any(), uint(7)).
+			Return(nil)
+
+		_, err = service.Verify(t.Context(), 7, "ABCDE-FGHIJ")
+		require.NoError(t, err)
+	})
+
+	t.Run("It should record an invalid code as a failed attempt", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.userRepository.
+			EXPECT().
+			GetByID(gomock.Any(), uint(7)).
+			Return(enabledUser(), nil)
+
+		mocks.userRepository.
+			EXPECT().
+			RecordMFAFailure(gomock.Any(), uint(7), 5, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, _ int, lockedUntil time.Time) (bool, error) {
+				assert.WithinDuration(t, time.Now().Add(15*time.Minute), lockedUntil, 5*time.Second)
+				return false, nil
+			})
+
+		_, err := service.Verify(t.Context(), 7, totpCode(t, rfcSecret, time.Now().Add(time.Hour)))
+		assert.ErrorIs(t, err, mfa.ErrInvalidCode)
+	})
+
+	t.Run("It should lock the second step when a failed attempt reaches the limit", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.userRepository.
+			EXPECT().
+			GetByID(gomock.Any(), uint(7)).
+			Return(enabledUser(), nil)
+
+		mocks.userRepository.
+			EXPECT().
+			RecordMFAFailure(gomock.Any(), uint(7), 5, gomock.Any()).
+			Return(true, nil)
+
+		_, err := service.Verify(t.Context(), 7, totpCode(t, rfcSecret, time.Now().Add(time.Hour)))
+		assert.ErrorIs(t, err, mfa.ErrTooManyAttempts)
+	})
+
+	t.Run("It should reject every code while the second step is locked", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		lockedUntil := time.Now().Add(time.Minute)
+
+		user := enabledUser()
+		user.MFALockedUntil = &lockedUntil
+
+		mocks.userRepository.
+			EXPECT().
+			GetByID(gomock.Any(), uint(7)).
+			Return(user, nil)
+
+		_, err := service.Verify(t.Context(), 7, totpCode(t, rfcSecret, time.Now()))
+		assert.ErrorIs(t, err, mfa.ErrTooManyAttempts)
+	})
+
+	t.Run("It should reject a user without MFA", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.userRepository.
+			EXPECT().
+			GetByID(gomock.Any(), uint(7)).
+			Return(passwordUser(), nil)
+
+		_, err := service.Verify(t.Context(), 7, "123456")
+		assert.ErrorIs(t, err, mfa.ErrMFANotEnrolled)
+	})
+}
@@ -0,0 +1,93 @@
+package mfa
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP parameters of RFC 6238 with the defaults authenticator apps expect,
+// many apps ignore other algorithms than HMAC-SHA1.
+const (
+	totpDigits       = 6
+	totpModulus      = 1_000_000
+	totpPeriod       = 30 * time.Second
+	totpSecretLength = 20
+	// totpSkew accepts codes of the neighboring time steps, so clocks may drift a little.
+	totpSkew = 1
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// newSecret returns a random base32 encoded TOTP secret.
+func newSecret() (string, error) {
+	raw := make([]byte, totpSecretLength)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generate TOTP secret: %w", err)
+	}
+
+	return secretEncoding.EncodeToString(raw), nil
+}
+
+// otpauthURI returns the otpauth URI of the secret, authenticator apps import it from a QR code.
+func otpauthURI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(int(totpPeriod.Seconds())))
+
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}
+
+	return uri.String()
+}
+
+// validateTOTP returns the time step the code is valid in. Steps up to lastStep were already used and are rejected.
+func validateTOTP(secret, code string, now time.Time, lastStep int64) (int64, bool) {
+	key, err := secretEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := now.Unix() / int64(totpPeriod.Seconds())
+
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= lastStep {
+			continue
+		}
+
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// totpCode is the HOTP value of RFC 4226 for the time step.
+func totpCode(key []byte, step int64) string {
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, value%totpModulus)
+}
@@ -0,0 +1,344 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"echo-app/internal/config"
+	"echo-app/internal/models"
+	"echo-app/internal/permify"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+// leaseDuration keeps a claimed batch away from other dispatchers while its Permify calls run.
+// Entries not applied within half of it are released for the next dispatch.
+const leaseDuration = time.Minute
+
+type outboxRepository interface {
+	Enqueue(ctx context.Context, entries ...models.OutboxEntry) ([]uint, error)
+	LockPending(ctx context.Context, now time.Time, limit int, ids []uint) ([]models.OutboxEntry, error)
+	Lease(ctx context.Context, ids []uint, until time.Time) error
+	ListPending(ctx context.Context, entityType, entityID string) ([]models.OutboxEntry, error)
+	Update(ctx context.Context, entry *models.OutboxEntry) error
+	SetSnapToken(ctx context.Context, entityType, entityID, snapToken string) error
+}
+
+type relationships interface {
+	Write(ctx context.Context, tuples ...permify.Tuple) (string, error)
+	Delete(ctx context.Context, filter permify.Tuple) (string, error)
+}
+
+type transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Service records relationship changes in the outbox table and dispatches them to Permify,
+// which gives at-least-once delivery of the changes committed together with the application data.
+// Entries failing maxAttempts times are given up, so they stop holding back the later changes of their entity.
+type Service struct {
+	repository    outboxRepository
+	relationships relationships
+	transactor    transactor
+	pollInterval  time.Duration
+	batchSize     int
+	minBackoff    time.Duration
+	maxBackoff    time.Duration
+	maxAttempts   int
+	now           func() time.Time
+}
+
+func NewService(
+	repository outboxRepository,
+	relationships relationships,
+	transactor transactor,
+	cfg config.Outbox,
+) *Service {
+	return &Service{
+		repository:    repository,
+		relationships: relationships,
+		transactor:    transactor,
+		pollInterval:  cfg.PollInterval,
+		batchSize:     cfg.BatchSize,
+		minBackoff:    cfg.MinBackoff,
+		maxBackoff:    cfg.MaxBackoff,
+		maxAttempts:   cfg.MaxAttempts,
+		now:           time.Now,
+	}
+}
+
+// Write records the tuples to be written to Permify and returns the IDs of the entries, see Flush.
+// It takes part in the transaction carried by ctx.
+func (s *Service) Write(ctx context.Context, tuples ...permify.Tuple) ([]uint, error) {
+	entries := make([]models.OutboxEntry, 0, len(tuples))
+	for _, tuple := range tuples {
+		entries = append(entries, models.OutboxEntry{
+			Operation:     models.OutboxWrite,
+			EntityType:    tuple.EntityType,
+			EntityID:      tuple.EntityID,
+			Relation:      tuple.Relation,
+			SubjectType:   tuple.SubjectType,
+			SubjectID:     tuple.SubjectID,
+			NextAttemptAt: s.now(),
+		})
+	}
+
+	ids, err := s.repository.Enqueue(ctx, entries...)
+	if err != nil {
+		return nil, fmt.Errorf("enqueue relationship writes: %w", err)
+	}
+
+	return ids, nil
+}
+
+// DeleteEntity records the deletion of all relationships of the entity and returns the ID of the entry.
+// It takes part in the transaction carried by ctx.
+func (s *Service) DeleteEntity(ctx context.Context, entityType, entityID string) (uint, error) {
+	ids, err := s.repository.Enqueue(ctx, models.OutboxEntry{
+		Operation:     models.OutboxDelete,
+		EntityType:    entityType,
+		EntityID:      entityID,
+		NextAttemptAt: s.now(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("enqueue relationship deletion: %w", err)
+	}
+
+	return ids[0], nil
+}
+
+// DeleteSubject records the deletion of all relationships between the entity and the subject and returns the ID
+// of the entry. It takes part in the transaction carried by ctx.
+func (s *Service) DeleteSubject(ctx context.Context, entityType, entityID, subjectType, subjectID string) (uint, error) {
+	ids, err := s.repository.Enqueue(ctx, models.OutboxEntry{
+		Operation:     models.OutboxDelete,
+		EntityType:    entityType,
+		EntityID:      entityID,
+		SubjectType:   subjectType,
+		SubjectID:     subjectID,
+		NextAttemptAt: s.now(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("enqueue relationship deletion: %w", err)
+	}
+
+	return ids[0], nil
+}
+
+// Pending returns the recorded changes of the entity that aren't dispatched yet, in the order they are dispatched.
+// Permify doesn't know about them, so checks that must see every committed change apply them on top of Permify.
+func (s *Service) Pending(ctx context.Context, entityType, entityID string) ([]models.OutboxEntry, error) {
+	entries, err := s.repository.ListPending(ctx, entityType, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("list pending entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Flush dispatches the entries with the IDs right away, so the snap tokens of the changes are recorded
+// before the response is sent. It should be called with the entries the caller recorded once their transaction
+// is committed. Entries waiting for earlier changes of their entity and failures are left to the background dispatcher.
+func (s *Service) Flush(ctx context.Context, ids []uint) {
+	if len(ids) == 0 {
+		return
+	}
+
+	s.dispatchAll(ctx, ids)
+}
+
+// Run dispatches the outbox until ctx is done.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.dispatchAll(ctx, nil)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchAll dispatches batches of the entries with the IDs, or of all entries without IDs, until one applies nothing.
+// A batch only has the oldest pending entry of each entity, so a short batch doesn't mean the outbox is drained.
+func (s *Service) dispatchAll(ctx context.Context, ids []uint) {
+	for {
+		dispatched, err := s.dispatch(ctx, ids)
+		if err != nil {
+			slog.ErrorContext(ctx, "Dispatch Permify outbox", "err", err.Error())
+			return
+		}
+
+		if dispatched == 0 {
+			return
+		}
+	}
+}
+
+// Dispatch pushes one batch of pending entries to Permify and returns the number of entries applied.
+// The snap tokens returned by Permify are stored on the affected entities. Failed entries are rescheduled
+// with exponential backoff, later entries of the same entity wait for them until they are given up.
+func (s *Service) Dispatch(ctx context.Context) (int, error) {
+	return s.dispatch(ctx, nil)
+}
+
+// dispatch claims a batch of entries and applies them to Permify outside of a transaction, so no database lock
+// is held during the calls. The results are stored in a second transaction.
+func (s *Service) dispatch(ctx context.Context, ids []uint) (int, error) {
+	claimedAt := s.now()
+
+	entries, err := s.claim(ctx, claimedAt, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	applyCtx, cancel := context.WithDeadline(ctx, claimedAt.Add(leaseDuration/2))
+	defer cancel()
+
+	var dispatched int
+
+	for i := range entries {
+		entry := &entries[i]
+
+		if applyCtx.Err() != nil {
+			entry.NextAttemptAt = s.now()
+			continue
+		}
+
+		snapToken, err := s.apply(applyCtx, entry)
+		if err != nil {
+			s.fail(ctx, entry, err)
+			continue
+		}
+
+		now := s.now()
+		entry.SnapToken = snapToken
+		entry.ProcessedAt = &now
+		dispatched++
+	}
+
+	if err := s.store(ctx, entries); err != nil {
+		return 0, err
+	}
+
+	return dispatched, nil
+}
+
+// claim locks the next batch of pending entries and leases them, so other dispatchers skip them once the lock
+// is released. Later entries of their entities stay held back until the entries are processed or given up.
+func (s *Service) claim(ctx context.Context, now time.Time, ids []uint) ([]models.OutboxEntry, error) {
+	var entries []models.OutboxEntry
+
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		entries, err = s.repository.LockPending(ctx, now, s.batchSize, ids)
+		if err != nil {
+			return fmt.Errorf("lock pending entries: %w", err)
+		}
+
+		if len(entries) == 0 {
+			return nil
+		}
+
+		claimedIDs := make([]uint, 0, len(entries))
+		for _, entry := range entries {
+			claimedIDs = append(claimedIDs, entry.ID)
+		}
+
+		if err := s.repository.Lease(ctx, claimedIDs, now.Add(leaseDuration)); err != nil {
+			return fmt.Errorf("lease pending entries: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("claim outbox entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// fail reschedules the failed entry with exponential backoff or gives it up after maxAttempts attempts.
+func (s *Service) fail(ctx context.Context, entry *models.OutboxEntry, err error) {
+	now := s.now()
+
+	entry.Attempts++
+	entry.LastError = err.Error()
+
+	if entry.Attempts >= s.maxAttempts {
+		entry.FailedAt = &now
+
+		slog.ErrorContext(ctx, "Permify outbox entry given up", "id", entry.ID, "attempts", entry.Attempts, "err", err.Error())
+
+		return
+	}
+
+	entry.NextAttemptAt = now.Add(s.backoff(entry.Attempts))
+
+	slog.WarnContext(ctx, "Permify outbox entry failed", "id", entry.ID, "attempts", entry.Attempts, "err", err.Error())
+}
+
+// store records the results of the dispatched entries together with the snap tokens of their entities.
+func (s *Service) store(ctx context.Context, entries []models.OutboxEntry) error {
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		for i := range entries {
+			entry := &entries[i]
+
+			if entry.ProcessedAt != nil {
+				if err := s.repository.SetSnapToken(ctx, entry.EntityType, entry.EntityID, entry.SnapToken); err != nil {
+					return fmt.Errorf("set snap token of %s:%s: %w", entry.EntityType, entry.EntityID, err)
+				}
+			}
+
+			if err := s.repository.Update(ctx, entry); err != nil {
+				return fmt.Errorf("update outbox entry: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("store dispatched outbox entries: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) apply(ctx context.Context, entry *models.OutboxEntry) (string, error) {
+	tuple := permify.Tuple{
+		EntityType:  entry.EntityType,
+		EntityID:    entry.EntityID,
+		Relation:    entry.Relation,
+		SubjectType: entry.SubjectType,
+		SubjectID:   entry.SubjectID,
+	}
+
+	switch entry.Operation {
+	case models.OutboxWrite:
+		return s.relationships.Write(ctx, tuple)
+	case models.OutboxDelete:
+		return s.relationships.Delete(ctx, tuple)
+	default:
+		return "", fmt.Errorf("unknown outbox operation %q", entry.Operation)
+	}
+}
+
+func (s *Service) backoff(attempts int) time.Duration {
+	backoff := s.minBackoff
+	for i := 1; i < attempts && backoff < s.maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, s.maxBackoff)
+}
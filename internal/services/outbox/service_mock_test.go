@@ -0,0 +1,449 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=outbox_test -typed=true
+//
+
+// Package outbox_test is a generated GoMock package.
+package outbox_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "echo-app/internal/models"
+	permify "echo-app/internal/permify"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockoutboxRepository is a mock of outboxRepository interface.
+type MockoutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockoutboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockoutboxRepositoryMockRecorder is the mock recorder for MockoutboxRepository.
+type MockoutboxRepositoryMockRecorder struct {
+	mock *MockoutboxRepository
+}
+
+// NewMockoutboxRepository creates a new mock instance.
+func NewMockoutboxRepository(ctrl *gomock.Controller) *MockoutboxRepository {
+	mock := &MockoutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockoutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockoutboxRepository) EXPECT() *MockoutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockoutboxRepository) Enqueue(ctx context.Context, entries ...models.OutboxEntry) ([]uint, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range entries {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Enqueue", varargs...)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockoutboxRepositoryMockRecorder) Enqueue(ctx any, entries ...any) *MockoutboxRepositoryEnqueueCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, entries...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockoutboxRepository)(nil).Enqueue), varargs...)
+	return &MockoutboxRepositoryEnqueueCall{Call: call}
+}
+
+// MockoutboxRepositoryEnqueueCall wrap *gomock.Call
+type MockoutboxRepositoryEnqueueCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockoutboxRepositoryEnqueueCall) Return(arg0 []uint, arg1 error) *MockoutboxRepositoryEnqueueCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockoutboxRepositoryEnqueueCall) Do(f func(context.Context, ...models.OutboxEntry) ([]uint, error)) *MockoutboxRepositoryEnqueueCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockoutboxRepositoryEnqueueCall) DoAndReturn(f func(context.Context, ...models.OutboxEntry) ([]uint, error)) *MockoutboxRepositoryEnqueueCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Lease mocks base method.
+func (m *MockoutboxRepository) Lease(ctx context.Context, ids []uint, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lease", ctx, ids, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lease indicates an expected call of Lease.
+func (mr *MockoutboxRepositoryMockRecorder) Lease(ctx, ids, until any) *MockoutboxRepositoryLeaseCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lease", reflect.TypeOf((*MockoutboxRepository)(nil).Lease), ctx, ids, until)
+	return &MockoutboxRepositoryLeaseCall{Call: call}
+}
+
+// MockoutboxRepositoryLeaseCall wrap *gomock.Call
+type MockoutboxRepositoryLeaseCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockoutboxRepositoryLeaseCall) Return(arg0 error) *MockoutboxRepositoryLeaseCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockoutboxRepositoryLeaseCall) Do(f func(context.Context, []uint, time.Time) error) *MockoutboxRepositoryLeaseCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockoutboxRepositoryLeaseCall) DoAndReturn(f func(context.Context, []uint, time.Time) error) *MockoutboxRepositoryLeaseCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListPending mocks base method.
+func (m *MockoutboxRepository) ListPending(ctx context.Context, entityType, entityID string) ([]models.OutboxEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", ctx, entityType, entityID)
+	ret0, _ := ret[0].([]models.OutboxEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockoutboxRepositoryMockRecorder) ListPending(ctx, entityType, entityID any) *MockoutboxRepositoryListPendingCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockoutboxRepository)(nil).ListPending), ctx, entityType, entityID)
+	return &MockoutboxRepositoryListPendingCall{Call: call}
+}
+
+// MockoutboxRepositoryListPendingCall wrap *gomock.Call
+type MockoutboxRepositoryListPendingCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockoutboxRepositoryListPendingCall) Return(arg0 []models.OutboxEntry, arg1 error) *MockoutboxRepositoryListPendingCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockoutboxRepositoryListPendingCall) Do(f func(context.Context, string, string) ([]models.OutboxEntry, error)) *MockoutboxRepositoryListPendingCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockoutboxRepositoryListPendingCall) DoAndReturn(f func(context.Context, string, string) ([]models.OutboxEntry, error)) *MockoutboxRepositoryListPendingCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// LockPending mocks base method.
+func (m *MockoutboxRepository) LockPending(ctx context.Context, now time.Time, limit int, ids []uint) ([]models.OutboxEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockPending", ctx, now, limit, ids)
+	ret0, _ := ret[0].([]models.OutboxEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockPending indicates an expected call of LockPending.
+func (mr *MockoutboxRepositoryMockRecorder) LockPending(ctx, now, limit, ids any) *MockoutboxRepositoryLockPendingCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockPending", reflect.TypeOf((*MockoutboxRepository)(nil).LockPending), ctx, now, limit, ids)
+	return &MockoutboxRepositoryLockPendingCall{Call: call}
+}
+
+// MockoutboxRepositoryLockPendingCall wrap *gomock.Call
+type MockoutboxRepositoryLockPendingCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockoutboxRepositoryLockPendingCall) Return(arg0 []models.OutboxEntry, arg1 error) *MockoutboxRepositoryLockPendingCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockoutboxRepositoryLockPendingCall) Do(f func(context.Context, time.Time, int, []uint) ([]models.OutboxEntry, error)) *MockoutboxRepositoryLockPendingCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockoutboxRepositoryLockPendingCall) DoAndReturn(f func(context.Context, time.Time, int, []uint) ([]models.OutboxEntry, error)) *MockoutboxRepositoryLockPendingCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetSnapToken mocks base method.
+func (m *MockoutboxRepository) SetSnapToken(ctx context.Context, entityType, entityID, snapToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSnapToken", ctx, entityType, entityID, snapToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSnapToken indicates an expected call of SetSnapToken.
+func (mr *MockoutboxRepositoryMockRecorder) SetSnapToken(ctx, entityType, entityID, snapToken any) *MockoutboxRepositorySetSnapTokenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSnapToken", reflect.TypeOf((*MockoutboxRepository)(nil).SetSnapToken), ctx, entityType, entityID, snapToken)
+	return &MockoutboxRepositorySetSnapTokenCall{Call: call}
+}
+
+// MockoutboxRepositorySetSnapTokenCall wrap *gomock.Call
+type MockoutboxRepositorySetSnapTokenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockoutboxRepositorySetSnapTokenCall) Return(arg0 error) *MockoutboxRepositorySetSnapTokenCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockoutboxRepositorySetSnapTokenCall) Do(f func(context.Context, string, string, string) error) *MockoutboxRepositorySetSnapTokenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockoutboxRepositorySetSnapTokenCall) DoAndReturn(f func(context.Context, string, string, string) error) *MockoutboxRepositorySetSnapTokenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockoutboxRepository) Update(ctx context.Context, entry *models.OutboxEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockoutboxRepositoryMockRecorder) Update(ctx, entry any) *MockoutboxRepositoryUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockoutboxRepository)(nil).Update), ctx, entry)
+	return &MockoutboxRepositoryUpdateCall{Call: call}
+}
+
+// MockoutboxRepositoryUpdateCall wrap *gomock.Call
+type MockoutboxRepositoryUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockoutboxRepositoryUpdateCall) Return(arg0 error) *MockoutboxRepositoryUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockoutboxRepositoryUpdateCall) Do(f func(context.Context, *models.OutboxEntry) error) *MockoutboxRepositoryUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockoutboxRepositoryUpdateCall) DoAndReturn(f func(context.Context, *models.OutboxEntry) error) *MockoutboxRepositoryUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Mockrelationships is a mock of relationships interface.
+type Mockrelationships struct {
+	ctrl     *gomock.Controller
+	recorder *MockrelationshipsMockRecorder
+	isgomock struct{}
+}
+
+// MockrelationshipsMockRecorder is the mock recorder for Mockrelationships.
+type MockrelationshipsMockRecorder struct {
+	mock *Mockrelationships
+}
+
+// NewMockrelationships creates a new mock instance.
+func NewMockrelationships(ctrl *gomock.Controller) *Mockrelationships {
+	mock := &Mockrelationships{ctrl: ctrl}
+	mock.recorder = &MockrelationshipsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockrelationships) EXPECT() *MockrelationshipsMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *Mockrelationships) Delete(ctx context.Context, filter permify.Tuple) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, filter)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockrelationshipsMockRecorder) Delete(ctx, filter any) *MockrelationshipsDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*Mockrelationships)(nil).Delete), ctx, filter)
+	return &MockrelationshipsDeleteCall{Call: call}
+}
+
+// MockrelationshipsDeleteCall wrap *gomock.Call
+type MockrelationshipsDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrelationshipsDeleteCall) Return(arg0 string, arg1 error) *MockrelationshipsDeleteCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrelationshipsDeleteCall) Do(f func(context.Context, permify.Tuple) (string, error)) *MockrelationshipsDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrelationshipsDeleteCall) DoAndReturn(f func(context.Context, permify.Tuple) (string, error)) *MockrelationshipsDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Write mocks base method.
+func (m *Mockrelationships) Write(ctx context.Context, tuples ...permify.Tuple) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range tuples {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Write", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockrelationshipsMockRecorder) Write(ctx any, tuples ...any) *MockrelationshipsWriteCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, tuples...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*Mockrelationships)(nil).Write), varargs...)
+	return &MockrelationshipsWriteCall{Call: call}
+}
+
+// MockrelationshipsWriteCall wrap *gomock.Call
+type MockrelationshipsWriteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrelationshipsWriteCall) Return(arg0 string, arg1 error) *MockrelationshipsWriteCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrelationshipsWriteCall) Do(f func(context.Context, ...permify.Tuple) (string, error)) *MockrelationshipsWriteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrelationshipsWriteCall) DoAndReturn(f func(context.Context, ...permify.Tuple) (string, error)) *MockrelationshipsWriteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Mocktransactor is a mock of transactor interface.
+type Mocktransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactorMockRecorder
+	isgomock struct{}
+}
+
+// MocktransactorMockRecorder is the mock recorder for Mocktransactor.
+type MocktransactorMockRecorder struct {
+	mock *Mocktransactor
+}
+
+// NewMocktransactor creates a new mock instance.
+func NewMocktransactor(ctrl *gomock.Controller) *Mocktransactor {
+	mock := &Mocktransactor{ctrl: ctrl}
+	mock.recorder = &MocktransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocktransactor) EXPECT() *MocktransactorMockRecorder {
+	return m.recorder
+}
+
+// Transaction mocks base method.
+func (m *Mocktransactor) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MocktransactorMockRecorder) Transaction(ctx, fn any) *MocktransactorTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*Mocktransactor)(nil).Transaction), ctx, fn)
+	return &MocktransactorTransactionCall{Call: call}
+}
+
+// MocktransactorTransactionCall wrap *gomock.Call
+type MocktransactorTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktransactorTransactionCall) Return(arg0 error) *MocktransactorTransactionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktransactorTransactionCall) Do(f func(context.Context, func(context.Context) error) error) *MocktransactorTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktransactorTransactionCall) DoAndReturn(f func(context.Context, func(context.Context) error) error) *MocktransactorTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
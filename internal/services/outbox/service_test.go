@@ -0,0 +1,246 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"echo-app/internal/config"
+	"echo-app/internal/models"
+	"echo-app/internal/permify"
+	"echo-app/internal/services/outbox"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// inTransaction marks the context passed to the function of transactorStub.
+type inTransaction struct{}
+
+type transactorStub struct{}
+
+func (transactorStub) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, inTransaction{}, true))
+}
+
+func newService(t *testing.T) (*outbox.Service, *MockoutboxRepository, *Mockrelationships) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	repository := NewMockoutboxRepository(ctrl)
+	relationships := NewMockrelationships(ctrl)
+
+	outboxService := outbox.NewService(repository, relationships, transactorStub{}, config.Outbox{
+		PollInterval: time.Second,
+		BatchSize:    10,
+		MinBackoff:   time.Second,
+		MaxBackoff:   time.Minute,
+		MaxAttempts:  5,
+	})
+
+	return outboxService, repository, relationships
+}
+
+// notInTransaction asserts the Permify call is made outside of a database transaction.
+func notInTransaction(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	assert.Nil(t, ctx.Value(inTransaction{}), "Permify is called inside of a transaction")
+}
+
+func TestService_Write(t *testing.T) {
+	outboxService, repository, _ := newService(t)
+
+	repository.
+		EXPECT().
+		Enqueue(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, entries ...models.OutboxEntry) ([]uint, error) {
+			require.Len(t, entries, 1)
+			assert.Equal(t, models.OutboxWrite, entries[0].Operation)
+			assert.Equal(t, "post", entries[0].EntityType)
+			assert.Equal(t, "admin", entries[0].Relation)
+			assert.Equal(t, "7", entries[0].SubjectID)
+			return []uint{4}, nil
+		})
+
+	ids, err := outboxService.Write(t.Context(), permify.Tuple{
+		EntityType:  "post",
+		EntityID:    "1",
+		Relation:    "admin",
+		SubjectType: "user",
+		SubjectID:   "7",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []uint{4}, ids)
+}
+
+func TestService_Dispatch(t *testing.T) {
+	t.Run("It should record the snap token of dispatched entries", func(t *testing.T) {
+		outboxService, repository, relationships := newService(t)
+
+		gomock.InOrder(
+			repository.
+				EXPECT().
+				LockPending(gomock.Any(), gomock.Any(), 10, nil).
+				Return([]models.OutboxEntry{{ID: 1, Operation: models.OutboxDelete, EntityType: "post", EntityID: "1"}}, nil),
+			repository.
+				EXPECT().
+				Lease(gomock.Any(), []uint{1}, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ []uint, until time.Time) error {
+					assert.WithinDuration(t, time.Now().Add(time.Minute), until, time.Second)
+					return nil
+				}),
+			relationships.
+				EXPECT().
+				Delete(gomock.Any(), permify.Tuple{EntityType: "post", EntityID: "1"}).
+				DoAndReturn(func(ctx context.Context, _ permify.Tuple) (string, error) {
+					notInTransaction(t, ctx)
+					return "snap-token", nil
+				}),
+			repository.
+				EXPECT().
+				SetSnapToken(gomock.Any(), "post", "1", "snap-token").
+				Return(nil),
+			repository.
+				EXPECT().
+				Update(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, entry *models.OutboxEntry) error {
+					assert.Equal(t, "snap-token", entry.SnapToken)
+					assert.NotNil(t, entry.ProcessedAt)
+					return nil
+				}),
+		)
+
+		dispatched, err := outboxService.Dispatch(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 1, dispatched)
+	})
+
+	t.Run("It should reschedule a failed entry", func(t *testing.T) {
+		outboxService, repository, relationships := newService(t)
+
+		repository.
+			EXPECT().
+			LockPending(gomock.Any(), gomock.Any(), 10, nil).
+			Return([]models.OutboxEntry{{ID: 1, Operation: models.OutboxWrite, EntityType: "post", EntityID: "1", Attempts: 2}}, nil)
+
+		repository.
+			EXPECT().
+			Lease(gomock.Any(), []uint{1}, gomock.Any()).
+			Return(nil)
+
+		relationships.
+			EXPECT().
+			Write(gomock.Any(), gomock.Any()).
+			Return("", errors.New("unavailable"))
+
+		repository.
+			EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, entry *models.OutboxEntry) error {
+				assert.Equal(t, uint(1), entry.ID)
+				assert.Equal(t, 3, entry.Attempts)
+				assert.Equal(t, "unavailable", entry.LastError)
+				assert.Nil(t, entry.ProcessedAt)
+				assert.Nil(t, entry.FailedAt)
+				assert.WithinDuration(t, time.Now().Add(4*time.Second), entry.NextAttemptAt, time.Second)
+				return nil
+			})
+
+		dispatched, err := outboxService.Dispatch(t.Context())
+		require.NoError(t, err)
+		assert.Zero(t, dispatched)
+	})
+
+	t.Run("It should give up an entry after the last attempt", func(t *testing.T) {
+		outboxService, repository, relationships := newService(t)
+
+		repository.
+			EXPECT().
+			LockPending(gomock.Any(), gomock.Any(), 10, nil).
+			Return([]models.OutboxEntry{{ID: 1, Operation: models.OutboxWrite, EntityType: "post", EntityID: "1", Attempts: 4}}, nil)
+
+		repository.
+			EXPECT().
+			Lease(gomock.Any(), []uint{1}, gomock.Any()).
+			Return(nil)
+
+		relationships.
+			EXPECT().
+			Write(gomock.Any(), gomock.Any()).
+			Return("", errors.New("invalid relation"))
+
+		repository.
+			EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, entry *models.OutboxEntry) error {
+				assert.Equal(t, 5, entry.Attempts)
+				assert.Nil(t, entry.ProcessedAt)
+				assert.NotNil(t, entry.FailedAt)
+				return nil
+			})
+
+		dispatched, err := outboxService.Dispatch(t.Context())
+		require.NoError(t, err)
+		assert.Zero(t, dispatched)
+	})
+
+	t.Run("It should not call Permify without pending entries", func(t *testing.T) {
+		outboxService, repository, _ := newService(t)
+
+		repository.
+			EXPECT().
+			LockPending(gomock.Any(), gomock.Any(), 10, nil).
+			Return(nil, nil)
+
+		dispatched, err := outboxService.Dispatch(t.Context())
+		require.NoError(t, err)
+		assert.Zero(t, dispatched)
+	})
+}
+
+func TestService_Flush(t *testing.T) {
+	t.Run("It should dispatch only the entries of the caller, in order", func(t *testing.T) {
+		outboxService, repository, relationships := newService(t)
+
+		deleteRole := models.OutboxEntry{
+			ID: 1, Operation: models.OutboxDelete, EntityType: "domain", EntityID: "3", SubjectType: "user", SubjectID: "7",
+		}
+		writeRole := models.OutboxEntry{
+			ID: 2, Operation: models.OutboxWrite, EntityType: "domain", EntityID: "3", Relation: "admin", SubjectType: "user", SubjectID: "7",
+		}
+		ids := []uint{1, 2}
+
+		// The later entry of the entity is only selected once the earlier one is processed
+		gomock.InOrder(
+			repository.EXPECT().LockPending(gomock.Any(), gomock.Any(), 10, ids).Return([]models.OutboxEntry{deleteRole}, nil),
+			repository.EXPECT().Lease(gomock.Any(), []uint{1}, gomock.Any()).Return(nil),
+			relationships.EXPECT().
+				Delete(gomock.Any(), permify.Tuple{EntityType: "domain", EntityID: "3", SubjectType: "user", SubjectID: "7"}).
+				Return("first-snap-token", nil),
+			repository.EXPECT().SetSnapToken(gomock.Any(), "domain", "3", "first-snap-token").Return(nil),
+			repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil),
+
+			repository.EXPECT().LockPending(gomock.Any(), gomock.Any(), 10, ids).Return([]models.OutboxEntry{writeRole}, nil),
+			repository.EXPECT().Lease(gomock.Any(), []uint{2}, gomock.Any()).Return(nil),
+			relationships.EXPECT().
+				Write(gomock.Any(), permify.Tuple{EntityType: "domain", EntityID: "3", Relation: "admin", SubjectType: "user", SubjectID: "7"}).
+				Return("second-snap-token", nil),
+			repository.EXPECT().SetSnapToken(gomock.Any(), "domain", "3", "second-snap-token").Return(nil),
+			repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil),
+
+			repository.EXPECT().LockPending(gomock.Any(), gomock.Any(), 10, ids).Return(nil, nil),
+		)
+
+		outboxService.Flush(t.Context(), ids)
+	})
+
+	t.Run("It should do nothing without entries", func(t *testing.T) {
+		outboxService, _, _ := newService(t)
+
+		outboxService.Flush(t.Context(), nil)
+	})
+}
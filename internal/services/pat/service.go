@@ -0,0 +1,163 @@
+package pat
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"echo-app/internal/config"
+	"echo-app/internal/models"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+const (
+	// Prefix starts every token value, it tells personal access tokens apart from the JWT access tokens.
+	Prefix = "pat_"
+
+	tokenLength = 32
+
+	// touchInterval limits how often the last use of a token is written.
+	touchInterval = time.Minute
+)
+
+var (
+	ErrMissingSecret = errors.New("personal access token secret is not configured")
+	ErrTokenInvalid  = errors.New("personal access token is unknown, revoked or expired")
+	ErrInvalidExpiry = errors.New("personal access token expiry is in the past or too far in the future")
+)
+
+type tokenRepository interface {
+	Create(ctx context.Context, token *models.PersonalAccessToken) error
+	GetByHash(ctx context.Context, hash string) (models.PersonalAccessToken, error)
+	ListUnrevoked(ctx context.Context, userID uint) ([]models.PersonalAccessToken, error)
+	Touch(ctx context.Context, id uint, usedAt time.Time) error
+	Revoke(ctx context.Context, userID, id uint, revokedAt time.Time) error
+}
+
+// Service manages the personal access tokens of API and CI clients.
+type Service struct {
+	tokenRepository tokenRepository
+	secret          []byte
+	maxTTL          time.Duration
+	now             func() time.Time
+}
+
+func NewService(tokenRepository tokenRepository, cfg config.PersonalAccessToken) (*Service, error) {
+	if cfg.Secret == "" {
+		return nil, ErrMissingSecret
+	}
+
+	return &Service{
+		tokenRepository: tokenRepository,
+		secret:          []byte(cfg.Secret),
+		maxTTL:          cfg.MaxTTL,
+		now:             time.Now,
+	}, nil
+}
+
+// Create creates a token of the user and returns it with the token value, which can't be read again later.
+func (s *Service) Create(
+	ctx context.Context,
+	userID uint,
+	name string,
+	scopes []string,
+	expiresAt time.Time,
+) (models.PersonalAccessToken, string, error) {
+	now := s.now()
+
+	if !expiresAt.After(now) || expiresAt.After(now.Add(s.maxTTL)) {
+		return models.PersonalAccessToken{}, "", ErrInvalidExpiry
+	}
+
+	raw := make([]byte, tokenLength)
+	if _, err := rand.Read(raw); err != nil {
+		return models.PersonalAccessToken{}, "", fmt.Errorf("generate personal access token: %w", err)
+	}
+
+	value := Prefix + base64.RawURLEncoding.EncodeToString(raw)
+
+	token := &models.PersonalAccessToken{
+		UserID:    userID,
+		Name:      name,
+		TokenHash: s.hash(value),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+
+	if err := s.tokenRepository.Create(ctx, token); err != nil {
+		return models.PersonalAccessToken{}, "", fmt.Errorf("create personal access token in repository: %w", err)
+	}
+
+	return *token, value, nil
+}
+
+// Authenticate returns the token with the value and records it was used.
+// Unknown, revoked and expired tokens are rejected with ErrTokenInvalid.
+func (s *Service) Authenticate(ctx context.Context, value string) (models.PersonalAccessToken, error) {
+	if !IsToken(value) {
+		return models.PersonalAccessToken{}, ErrTokenInvalid
+	}
+
+	token, err := s.tokenRepository.GetByHash(ctx, s.hash(value))
+	if errors.Is(err, models.ErrPersonalAccessTokenNotFound) {
+		return models.PersonalAccessToken{}, ErrTokenInvalid
+	} else if err != nil {
+		return models.PersonalAccessToken{}, fmt.Errorf("get personal access token: %w", err)
+	}
+
+	now := s.now()
+
+	if token.RevokedAt != nil || !now.Before(token.ExpiresAt) {
+		return models.PersonalAccessToken{}, ErrTokenInvalid
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= touchInterval {
+		if err := s.tokenRepository.Touch(ctx, token.ID, now); err != nil {
+			return models.PersonalAccessToken{}, fmt.Errorf("touch personal access token in repository: %w", err)
+		}
+
+		token.LastUsedAt = &now
+	}
+
+	return token, nil
+}
+
+// List returns the tokens of the user that aren't revoked, the newest first.
+func (s *Service) List(ctx context.Context, userID uint) ([]models.PersonalAccessToken, error) {
+	tokens, err := s.tokenRepository.ListUnrevoked(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list personal access tokens from repository: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// Revoke revokes the token of the user.
+func (s *Service) Revoke(ctx context.Context, userID, id uint) error {
+	if err := s.tokenRepository.Revoke(ctx, userID, id, s.now()); err != nil {
+		return fmt.Errorf("revoke personal access token in repository: %w", err)
+	}
+
+	return nil
+}
+
+// IsToken reports whether the value looks like a personal access token rather than a JWT.
+func IsToken(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+func (s *Service) hash(value string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(value))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
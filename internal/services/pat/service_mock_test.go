@@ -0,0 +1,235 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=pat_test -typed=true
+//
+
+// Package pat_test is a generated GoMock package.
+package pat_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "echo-app/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MocktokenRepository is a mock of tokenRepository interface.
+type MocktokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MocktokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MocktokenRepositoryMockRecorder is the mock recorder for MocktokenRepository.
+type MocktokenRepositoryMockRecorder struct {
+	mock *MocktokenRepository
+}
+
+// NewMocktokenRepository creates a new mock instance.
+func NewMocktokenRepository(ctrl *gomock.Controller) *MocktokenRepository {
+	mock := &MocktokenRepository{ctrl: ctrl}
+	mock.recorder = &MocktokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktokenRepository) EXPECT() *MocktokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MocktokenRepository) Create(ctx context.Context, token *models.PersonalAccessToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MocktokenRepositoryMockRecorder) Create(ctx, token any) *MocktokenRepositoryCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MocktokenRepository)(nil).Create), ctx, token)
+	return &MocktokenRepositoryCreateCall{Call: call}
+}
+
+// MocktokenRepositoryCreateCall wrap *gomock.Call
+type MocktokenRepositoryCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenRepositoryCreateCall) Return(arg0 error) *MocktokenRepositoryCreateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenRepositoryCreateCall) Do(f func(context.Context, *models.PersonalAccessToken) error) *MocktokenRepositoryCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenRepositoryCreateCall) DoAndReturn(f func(context.Context, *models.PersonalAccessToken) error) *MocktokenRepositoryCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByHash mocks base method.
+func (m *MocktokenRepository) GetByHash(ctx context.Context, hash string) (models.PersonalAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, hash)
+	ret0, _ := ret[0].(models.PersonalAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MocktokenRepositoryMockRecorder) GetByHash(ctx, hash any) *MocktokenRepositoryGetByHashCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MocktokenRepository)(nil).GetByHash), ctx, hash)
+	return &MocktokenRepositoryGetByHashCall{Call: call}
+}
+
+// MocktokenRepositoryGetByHashCall wrap *gomock.Call
+type MocktokenRepositoryGetByHashCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenRepositoryGetByHashCall) Return(arg0 models.PersonalAccessToken, arg1 error) *MocktokenRepositoryGetByHashCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenRepositoryGetByHashCall) Do(f func(context.Context, string) (models.PersonalAccessToken, error)) *MocktokenRepositoryGetByHashCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenRepositoryGetByHashCall) DoAndReturn(f func(context.Context, string) (models.PersonalAccessToken, error)) *MocktokenRepositoryGetByHashCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListUnrevoked mocks base method.
+func (m *MocktokenRepository) ListUnrevoked(ctx context.Context, userID uint) ([]models.PersonalAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnrevoked", ctx, userID)
+	ret0, _ := ret[0].([]models.PersonalAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnrevoked indicates an expected call of ListUnrevoked.
+func (mr *MocktokenRepositoryMockRecorder) ListUnrevoked(ctx, userID any) *MocktokenRepositoryListUnrevokedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnrevoked", reflect.TypeOf((*MocktokenRepository)(nil).ListUnrevoked), ctx, userID)
+	return &MocktokenRepositoryListUnrevokedCall{Call: call}
+}
+
+// MocktokenRepositoryListUnrevokedCall wrap *gomock.Call
+type MocktokenRepositoryListUnrevokedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenRepositoryListUnrevokedCall) Return(arg0 []models.PersonalAccessToken, arg1 error) *MocktokenRepositoryListUnrevokedCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenRepositoryListUnrevokedCall) Do(f func(context.Context, uint) ([]models.PersonalAccessToken, error)) *MocktokenRepositoryListUnrevokedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenRepositoryListUnrevokedCall) DoAndReturn(f func(context.Context, uint) ([]models.PersonalAccessToken, error)) *MocktokenRepositoryListUnrevokedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MocktokenRepository) Revoke(ctx context.Context, userID, id uint, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userID, id, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MocktokenRepositoryMockRecorder) Revoke(ctx, userID, id, revokedAt any) *MocktokenRepositoryRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MocktokenRepository)(nil).Revoke), ctx, userID, id, revokedAt)
+	return &MocktokenRepositoryRevokeCall{Call: call}
+}
+
+// MocktokenRepositoryRevokeCall wrap *gomock.Call
+type MocktokenRepositoryRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenRepositoryRevokeCall) Return(arg0 error) *MocktokenRepositoryRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenRepositoryRevokeCall) Do(f func(context.Context, uint, uint, time.Time) error) *MocktokenRepositoryRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenRepositoryRevokeCall) DoAndReturn(f func(context.Context, uint, uint, time.Time) error) *MocktokenRepositoryRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Touch mocks base method.
+func (m *MocktokenRepository) Touch(ctx context.Context, id uint, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, id, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MocktokenRepositoryMockRecorder) Touch(ctx, id, usedAt any) *MocktokenRepositoryTouchCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MocktokenRepository)(nil).Touch), ctx, id, usedAt)
+	return &MocktokenRepositoryTouchCall{Call: call}
+}
+
+// MocktokenRepositoryTouchCall wrap *gomock.Call
+type MocktokenRepositoryTouchCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenRepositoryTouchCall) Return(arg0 error) *MocktokenRepositoryTouchCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenRepositoryTouchCall) Do(f func(context.Context, uint, time.Time) error) *MocktokenRepositoryTouchCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenRepositoryTouchCall) DoAndReturn(f func(context.Context, uint, time.Time) error) *MocktokenRepositoryTouchCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
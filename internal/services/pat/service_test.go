@@ -0,0 +1,166 @@
+package pat_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"echo-app/internal/config"
+	"echo-app/internal/models"
+	"echo-app/internal/services/pat"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newService(t *testing.T) (*pat.Service, *MocktokenRepository) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	tokenRepository := NewMocktokenRepository(ctrl)
+
+	patService, err := pat.NewService(tokenRepository, config.PersonalAccessToken{Secret: "secret", MaxTTL: 24 * time.Hour})
+	require.NoError(t, err)
+
+	return patService, tokenRepository
+}
+
+func TestNewService(t *testing.T) {
+	_, err := pat.NewService(nil, config.PersonalAccessToken{MaxTTL: 24 * time.Hour})
+	assert.ErrorIs(t, err, pat.ErrMissingSecret)
+}
+
+func TestService_Create(t *testing.T) {
+	t.Run("It should create a token and store only its hash", func(t *testing.T) {
+		service, tokenRepository := newService(t)
+
+		var created models.PersonalAccessToken
+
+		tokenRepository.
+			EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, token *models.PersonalAccessToken) error {
+				token.ID = 3
+				created = *token
+				return nil
+			})
+
+		expiresAt := time.Now().Add(time.Hour)
+
+		token, value, err := service.Create(t.Context(), 7, "ci", []string{models.ScopePostsRead}, expiresAt)
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(value, pat.Prefix))
+		assert.Equal(t, created, token)
+		assert.Equal(t, uint(3), token.ID)
+		assert.Equal(t, uint(7), token.UserID)
+		assert.Equal(t, "ci", token.Name)
+		assert.Equal(t, []string{models.ScopePostsRead}, token.Scopes)
+		assert.Equal(t, expiresAt, token.ExpiresAt)
+		assert.Len(t, token.TokenHash, 64)
+		assert.NotContains(t, token.TokenHash, value)
+
+		tokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), created.TokenHash).
+			Return(created, nil)
+
+		tokenRepository.
+			EXPECT().
+			Touch(gomock.Any(), uint(3), gomock.Any()).
+			Return(nil)
+
+		authenticated, err := service.Authenticate(t.Context(), value)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint(3), authenticated.ID)
+	})
+
+	expiries := map[string]time.Time{
+		"in the past":             time.Now().Add(-time.Minute),
+		"after the maximum TTL":   time.Now().Add(25 * time.Hour),
+		"without an expiry value": {},
+	}
+
+	for name, expiresAt := range expiries {
+		t.Run("It should reject an expiry "+name, func(t *testing.T) {
+			service, _ := newService(t)
+
+			_, _, err := service.Create(t.Context(), 7, "ci", []string{models.ScopePostsRead}, expiresAt)
+			assert.ErrorIs(t, err, pat.ErrInvalidExpiry)
+		})
+	}
+}
+
+func TestService_Authenticate(t *testing.T) {
+	t.Run("It should not record the use of a recently used token again", func(t *testing.T) {
+		service, tokenRepository := newService(t)
+
+		lastUsedAt := time.Now().Add(-time.Second)
+		stored := models.PersonalAccessToken{ID: 3, UserID: 7, ExpiresAt: time.Now().Add(time.Hour), LastUsedAt: &lastUsedAt}
+
+		tokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), gomock.Any()).
+			Return(stored, nil)
+
+		token, err := service.Authenticate(t.Context(), "pat_token")
+		require.NoError(t, err)
+
+		assert.Equal(t, stored, token)
+	})
+
+	revokedAt := time.Now()
+
+	rejected := map[string]models.PersonalAccessToken{
+		"revoked": {ID: 3, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt},
+		"expired": {ID: 3, ExpiresAt: time.Now().Add(-time.Second)},
+	}
+
+	for name, stored := range rejected {
+		t.Run("It should reject a "+name+" token", func(t *testing.T) {
+			service, tokenRepository := newService(t)
+
+			tokenRepository.
+				EXPECT().
+				GetByHash(gomock.Any(), gomock.Any()).
+				Return(stored, nil)
+
+			_, err := service.Authenticate(t.Context(), "pat_token")
+			assert.ErrorIs(t, err, pat.ErrTokenInvalid)
+		})
+	}
+
+	t.Run("It should reject an unknown token", func(t *testing.T) {
+		service, tokenRepository := newService(t)
+
+		tokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), gomock.Any()).
+			Return(models.PersonalAccessToken{}, models.ErrPersonalAccessTokenNotFound)
+
+		_, err := service.Authenticate(t.Context(), "pat_token")
+		assert.ErrorIs(t, err, pat.ErrTokenInvalid)
+	})
+
+	t.Run("It should reject a value without the prefix", func(t *testing.T) {
+		service, _ := newService(t)
+
+		_, err := service.Authenticate(t.Context(), "eyJhbGciOiJIUzI1NiJ9")
+		assert.ErrorIs(t, err, pat.ErrTokenInvalid)
+	})
+}
+
+func TestService_Revoke(t *testing.T) {
+	service, tokenRepository := newService(t)
+
+	tokenRepository.
+		EXPECT().
+		Revoke(gomock.Any(), uint(7), uint(3), gomock.Any()).
+		Return(models.ErrPersonalAccessTokenNotFound)
+
+	err := service.Revoke(t.Context(), 7, 3)
+	assert.ErrorIs(t, err, models.ErrPersonalAccessTokenNotFound)
+}
@@ -0,0 +1,114 @@
+package permission
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"echo-app/internal/models"
+
+	"golang.org/x/sync/errgroup"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type subjectPermissions interface {
+	SubjectPermission(ctx context.Context, entityType, entityID, userID, snapToken string) (map[string]bool, error)
+}
+
+// SnapTokenLookup returns the Permify snap token stored on the entity, or an empty string if there is none.
+type SnapTokenLookup func(ctx context.Context, entityID string) (string, error)
+
+type Service struct {
+	permissions subjectPermissions
+	snapTokens  map[string]SnapTokenLookup
+	concurrency int
+}
+
+// NewService creates the service, concurrency is the number of entities checked in Permify at the same time.
+func NewService(permissions subjectPermissions, snapTokens map[string]SnapTokenLookup, concurrency int) Service {
+	return Service{
+		permissions: permissions,
+		snapTokens:  snapTokens,
+		concurrency: concurrency,
+	}
+}
+
+type entity struct {
+	entityType string
+	entityID   string
+}
+
+// BulkCheck answers the checks in request order. Permify is asked once per entity for all permissions
+// of the user on it, so checking edit and delete of a post costs a single request.
+func (s Service) BulkCheck(ctx context.Context, userID uint, checks []models.PermissionCheck) ([]models.PermissionCheckResult, error) {
+	var (
+		mu          sync.Mutex
+		permissions = make(map[entity]map[string]bool)
+		requested   = make(map[entity]bool)
+	)
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(s.concurrency)
+
+	for _, check := range checks {
+		key := entity{entityType: check.EntityType, entityID: check.EntityID}
+		if requested[key] {
+			continue
+		}
+
+		requested[key] = true
+
+		group.Go(func() error {
+			entityPermissions, err := s.subjectPermissions(groupCtx, key, userID)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			permissions[key] = entityPermissions
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	results := make([]models.PermissionCheckResult, 0, len(checks))
+	for _, check := range checks {
+		key := entity{entityType: check.EntityType, entityID: check.EntityID}
+		results = append(results, models.PermissionCheckResult{PermissionCheck: check, Allowed: permissions[key][check.Permission]})
+	}
+
+	return results, nil
+}
+
+func (s Service) subjectPermissions(ctx context.Context, key entity, userID uint) (map[string]bool, error) {
+	var snapToken string
+	if lookup, ok := s.snapTokens[key.entityType]; ok {
+		var err error
+
+		snapToken, err = lookup(ctx, key.entityID)
+		if err != nil {
+			return nil, fmt.Errorf("get snap token of %s:%s: %w", key.entityType, key.entityID, err)
+		}
+	}
+
+	permissions, err := s.permissions.SubjectPermission(
+		ctx,
+		key.entityType,
+		key.entityID,
+		strconv.FormatUint(uint64(userID), 10),
+		snapToken,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get permissions: %w", err)
+	}
+
+	return permissions, nil
+}
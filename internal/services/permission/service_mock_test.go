@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=permission_test -typed=true
+//
+
+// Package permission_test is a generated GoMock package.
+package permission_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MocksubjectPermissions is a mock of subjectPermissions interface.
+type MocksubjectPermissions struct {
+	ctrl     *gomock.Controller
+	recorder *MocksubjectPermissionsMockRecorder
+	isgomock struct{}
+}
+
+// MocksubjectPermissionsMockRecorder is the mock recorder for MocksubjectPermissions.
+type MocksubjectPermissionsMockRecorder struct {
+	mock *MocksubjectPermissions
+}
+
+// NewMocksubjectPermissions creates a new mock instance.
+func NewMocksubjectPermissions(ctrl *gomock.Controller) *MocksubjectPermissions {
+	mock := &MocksubjectPermissions{ctrl: ctrl}
+	mock.recorder = &MocksubjectPermissionsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksubjectPermissions) EXPECT() *MocksubjectPermissionsMockRecorder {
+	return m.recorder
+}
+
+// SubjectPermission mocks base method.
+func (m *MocksubjectPermissions) SubjectPermission(ctx context.Context, entityType, entityID, userID, snapToken string) (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubjectPermission", ctx, entityType, entityID, userID, snapToken)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubjectPermission indicates an expected call of SubjectPermission.
+func (mr *MocksubjectPermissionsMockRecorder) SubjectPermission(ctx, entityType, entityID, userID, snapToken any) *MocksubjectPermissionsSubjectPermissionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubjectPermission", reflect.TypeOf((*MocksubjectPermissions)(nil).SubjectPermission), ctx, entityType, entityID, userID, snapToken)
+	return &MocksubjectPermissionsSubjectPermissionCall{Call: call}
+}
+
+// MocksubjectPermissionsSubjectPermissionCall wrap *gomock.Call
+type MocksubjectPermissionsSubjectPermissionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksubjectPermissionsSubjectPermissionCall) Return(arg0 map[string]bool, arg1 error) *MocksubjectPermissionsSubjectPermissionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksubjectPermissionsSubjectPermissionCall) Do(f func(context.Context, string, string, string, string) (map[string]bool, error)) *MocksubjectPermissionsSubjectPermissionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksubjectPermissionsSubjectPermissionCall) DoAndReturn(f func(context.Context, string, string, string, string) (map[string]bool, error)) *MocksubjectPermissionsSubjectPermissionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
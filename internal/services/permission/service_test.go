@@ -0,0 +1,80 @@
+package permission_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"echo-app/internal/models"
+	"echo-app/internal/services/permission"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_BulkCheck(t *testing.T) {
+	snapTokens := map[string]permission.SnapTokenLookup{
+		"post": func(_ context.Context, entityID string) (string, error) {
+			return "snap-token-" + entityID, nil
+		},
+	}
+
+	checks := []models.PermissionCheck{
+		{EntityType: "post", EntityID: "1", Permission: "edit"},
+		{EntityType: "post", EntityID: "1", Permission: "view"},
+		{EntityType: "post", EntityID: "2", Permission: "edit"},
+		{EntityType: "domain", EntityID: "d1", Permission: "share"},
+	}
+
+	t.Run("It should ask for the permissions of every entity once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		permissions := NewMocksubjectPermissions(ctrl)
+		permissionService := permission.NewService(permissions, snapTokens, 2)
+
+		permissions.
+			EXPECT().
+			SubjectPermission(gomock.Any(), "post", "1", "7", "snap-token-1").
+			Return(map[string]bool{"edit": true, "view": true}, nil)
+
+		permissions.
+			EXPECT().
+			SubjectPermission(gomock.Any(), "post", "2", "7", "snap-token-2").
+			Return(map[string]bool{"edit": false, "view": true}, nil)
+
+		permissions.
+			EXPECT().
+			SubjectPermission(gomock.Any(), "domain", "d1", "7", "").
+			Return(map[string]bool{"edit": true, "view": true}, nil)
+
+		results, err := permissionService.BulkCheck(t.Context(), 7, checks)
+		require.NoError(t, err)
+
+		assert.Equal(t, []models.PermissionCheckResult{
+			{PermissionCheck: checks[0], Allowed: true},
+			{PermissionCheck: checks[1], Allowed: true},
+			{PermissionCheck: checks[2], Allowed: false},
+			{PermissionCheck: checks[3], Allowed: false},
+		}, results)
+	})
+
+	t.Run("It should fail when a check fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		permissions := NewMocksubjectPermissions(ctrl)
+		permissionService := permission.NewService(permissions, snapTokens, 1)
+
+		permissions.
+			EXPECT().
+			SubjectPermission(gomock.Any(), "post", "1", "7", "snap-token-1").
+			Return(nil, errors.New("permify is down"))
+
+		permissions.
+			EXPECT().
+			SubjectPermission(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(map[string]bool{}, nil).
+			AnyTimes()
+
+		_, err := permissionService.BulkCheck(t.Context(), 7, checks)
+		assert.Error(t, err)
+	})
+}
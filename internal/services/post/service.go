@@ -2,10 +2,17 @@ package post
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 
 	"echo-app/internal/models"
+	"echo-app/internal/permify"
 	"echo-app/internal/requests"
+	"echo-app/internal/tenant"
+
+	safecast "github.com/ccoveille/go-safecast"
+	"github.com/google/uuid"
 )
 
 //go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
@@ -13,33 +20,186 @@ import (
 type postRepository interface {
 	Create(ctx context.Context, post *models.Post) error
 	GetPosts(ctx context.Context) ([]models.Post, error)
+	GetPostsByIDs(ctx context.Context, ids []uint) ([]models.Post, error)
+	CountPosts(ctx context.Context) (int64, error)
 	GetPost(ctx context.Context, id uint) (models.Post, error)
 	Update(ctx context.Context, post *models.Post) error
 	Delete(ctx context.Context, post *models.Post) error
 }
 
+type domainRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (models.Domain, error)
+}
+
+type transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type relationshipOutbox interface {
+	Write(ctx context.Context, tuples ...permify.Tuple) ([]uint, error)
+	DeleteEntity(ctx context.Context, entityType, entityID string) (uint, error)
+	Flush(ctx context.Context, ids []uint)
+}
+
+type permissionChecker interface {
+	Check(ctx context.Context, request permify.CheckRequest) (bool, error)
+	LookupEntity(ctx context.Context, entityType, permission, userID, snapToken string) ([]string, error)
+}
+
+// checkFilterLimit is the number of posts in a domain up to which GetPosts checks every post
+// instead of looking up all posts the user can view, which spans every domain of the user.
+const checkFilterLimit = 20
+
 type Service struct {
-	postRepository postRepository
+	postRepository   postRepository
+	domainRepository domainRepository
+	transactor       transactor
+	relationships    relationshipOutbox
+	permissions      permissionChecker
 }
 
-func NewService(postRepository postRepository) Service {
-	return Service{postRepository: postRepository}
+func NewService(
+	postRepository postRepository,
+	domainRepository domainRepository,
+	transactor transactor,
+	relationships relationshipOutbox,
+	permissions permissionChecker,
+) Service {
+	return Service{
+		postRepository:   postRepository,
+		domainRepository: domainRepository,
+		transactor:       transactor,
+		relationships:    relationships,
+		permissions:      permissions,
+	}
 }
 
+// Create stores the post in the current domain, see tenant.WithDomain, and makes its author the post admin
+// in Permify. The relationships are recorded in the outbox in the same transaction as the post.
 func (s Service) Create(ctx context.Context, post *models.Post) error {
-	if err := s.postRepository.Create(ctx, post); err != nil {
-		return fmt.Errorf("create post in repository: %w", err)
+	var entryIDs []uint
+
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.postRepository.Create(ctx, post); err != nil {
+			return fmt.Errorf("create post in repository: %w", err)
+		}
+
+		var err error
+		if entryIDs, err = s.relationships.Write(ctx, postTuples(post)...); err != nil {
+			return fmt.Errorf("write post relationships: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
+	s.relationships.Flush(ctx, entryIDs)
+
 	return nil
 }
 
-func (s Service) GetPosts(ctx context.Context) ([]models.Post, error) {
+func postTuples(post *models.Post) []permify.Tuple {
+	postID := strconv.FormatUint(uint64(post.ID), 10)
+
+	return []permify.Tuple{
+		{
+			EntityType:  "post",
+			EntityID:    postID,
+			Relation:    "owner",
+			SubjectType: "user",
+			SubjectID:   strconv.FormatUint(uint64(post.UserID), 10),
+		},
+		{
+			EntityType:  "post",
+			EntityID:    postID,
+			Relation:    "parent",
+			SubjectType: "domain",
+			SubjectID:   post.DomainID.String(),
+		},
+	}
+}
+
+// GetPosts returns the posts of the current domain the user can view.
+func (s Service) GetPosts(ctx context.Context, userID uint) ([]models.Post, error) {
+	count, err := s.postRepository.CountPosts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("count posts in repository: %w", err)
+	}
+
+	if count <= checkFilterLimit {
+		return s.filterByCheck(ctx, userID)
+	}
+
+	return s.filterByLookup(ctx, userID)
+}
+
+func (s Service) filterByCheck(ctx context.Context, userID uint) ([]models.Post, error) {
 	posts, err := s.postRepository.GetPosts(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("get posts from repository: %w", err)
 	}
 
+	visible := make([]models.Post, 0, len(posts))
+	for _, post := range posts {
+		allowed, err := s.permissions.Check(ctx, permify.CheckRequest{
+			EntityType: "post",
+			EntityID:   strconv.FormatUint(uint64(post.ID), 10),
+			Permission: "view",
+			UserID:     strconv.FormatUint(uint64(userID), 10),
+			SnapToken:  post.SnapToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("check view permission on post %d: %w", post.ID, err)
+		}
+
+		if allowed {
+			visible = append(visible, post)
+		}
+	}
+
+	return visible, nil
+}
+
+// filterByLookup looks up the posts the user can view at least as fresh as the snap token of the current domain,
+// so members removed from the domain don't see its posts anymore.
+func (s Service) filterByLookup(ctx context.Context, userID uint) ([]models.Post, error) {
+	domainID, err := tenant.DomainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("scope posts: %w", err)
+	}
+
+	domain, err := s.domainRepository.GetByID(ctx, domainID)
+	if err != nil {
+		return nil, fmt.Errorf("get domain from repository: %w", err)
+	}
+
+	entityIDs, err := s.permissions.LookupEntity(ctx, "post", "view", strconv.FormatUint(uint64(userID), 10), domain.SnapToken)
+	if err != nil {
+		return nil, fmt.Errorf("lookup viewable posts: %w", err)
+	}
+
+	ids := make([]uint, 0, len(entityIDs))
+	for _, entityID := range entityIDs {
+		parsedID, err := strconv.ParseUint(entityID, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		id, err := safecast.ToUint(parsedID)
+		if err != nil {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	posts, err := s.postRepository.GetPostsByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get posts by ids from repository: %w", err)
+	}
+
 	return posts, nil
 }
 
@@ -52,6 +212,28 @@ func (s Service) GetPost(ctx context.Context, id uint) (models.Post, error) {
 	return post, nil
 }
 
+// SnapToken returns the Permify snap token of the post, it's empty for unknown posts.
+func (s Service) SnapToken(ctx context.Context, id string) (string, error) {
+	parsedID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return "", nil
+	}
+
+	postID, err := safecast.ToUint(parsedID)
+	if err != nil {
+		return "", nil
+	}
+
+	post, err := s.postRepository.GetPost(ctx, postID)
+	if errors.Is(err, models.ErrPostNotFound) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("get post from repository: %w", err)
+	}
+
+	return post.SnapToken, nil
+}
+
 func (s Service) Update(ctx context.Context, post *models.Post, updatePostRequest requests.UpdatePostRequest) error {
 	post.Content = updatePostRequest.Content
 	post.Title = updatePostRequest.Title
@@ -63,10 +245,27 @@ func (s Service) Update(ctx context.Context, post *models.Post, updatePostReques
 	return nil
 }
 
+// Delete deletes the post together with its Permify relationships.
 func (s Service) Delete(ctx context.Context, post *models.Post) error {
-	if err := s.postRepository.Delete(ctx, post); err != nil {
-		return fmt.Errorf("delete post in repository: %w", err)
+	var entryID uint
+
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.postRepository.Delete(ctx, post); err != nil {
+			return fmt.Errorf("delete post in repository: %w", err)
+		}
+
+		var err error
+		if entryID, err = s.relationships.DeleteEntity(ctx, "post", strconv.FormatUint(uint64(post.ID), 10)); err != nil {
+			return fmt.Errorf("delete post relationships: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
+	s.relationships.Flush(ctx, []uint{entryID})
+
 	return nil
 }
@@ -14,6 +14,8 @@ import (
 	reflect "reflect"
 
 	models "echo-app/internal/models"
+	permify "echo-app/internal/permify"
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +43,45 @@ func (m *MockpostRepository) EXPECT() *MockpostRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountPosts mocks base method.
+func (m *MockpostRepository) CountPosts(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPosts", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPosts indicates an expected call of CountPosts.
+func (mr *MockpostRepositoryMockRecorder) CountPosts(ctx any) *MockpostRepositoryCountPostsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPosts", reflect.TypeOf((*MockpostRepository)(nil).CountPosts), ctx)
+	return &MockpostRepositoryCountPostsCall{Call: call}
+}
+
+// MockpostRepositoryCountPostsCall wrap *gomock.Call
+type MockpostRepositoryCountPostsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpostRepositoryCountPostsCall) Return(arg0 int64, arg1 error) *MockpostRepositoryCountPostsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpostRepositoryCountPostsCall) Do(f func(context.Context) (int64, error)) *MockpostRepositoryCountPostsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpostRepositoryCountPostsCall) DoAndReturn(f func(context.Context) (int64, error)) *MockpostRepositoryCountPostsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Create mocks base method.
 func (m *MockpostRepository) Create(ctx context.Context, post *models.Post) error {
 	m.ctrl.T.Helper()
@@ -195,6 +236,45 @@ func (c *MockpostRepositoryGetPostsCall) DoAndReturn(f func(context.Context) ([]
 	return c
 }
 
+// GetPostsByIDs mocks base method.
+func (m *MockpostRepository) GetPostsByIDs(ctx context.Context, ids []uint) ([]models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostsByIDs", ctx, ids)
+	ret0, _ := ret[0].([]models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostsByIDs indicates an expected call of GetPostsByIDs.
+func (mr *MockpostRepositoryMockRecorder) GetPostsByIDs(ctx, ids any) *MockpostRepositoryGetPostsByIDsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostsByIDs", reflect.TypeOf((*MockpostRepository)(nil).GetPostsByIDs), ctx, ids)
+	return &MockpostRepositoryGetPostsByIDsCall{Call: call}
+}
+
+// MockpostRepositoryGetPostsByIDsCall wrap *gomock.Call
+type MockpostRepositoryGetPostsByIDsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpostRepositoryGetPostsByIDsCall) Return(arg0 []models.Post, arg1 error) *MockpostRepositoryGetPostsByIDsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpostRepositoryGetPostsByIDsCall) Do(f func(context.Context, []uint) ([]models.Post, error)) *MockpostRepositoryGetPostsByIDsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpostRepositoryGetPostsByIDsCall) DoAndReturn(f func(context.Context, []uint) ([]models.Post, error)) *MockpostRepositoryGetPostsByIDsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Update mocks base method.
 func (m *MockpostRepository) Update(ctx context.Context, post *models.Post) error {
 	m.ctrl.T.Helper()
@@ -232,3 +312,373 @@ func (c *MockpostRepositoryUpdateCall) DoAndReturn(f func(context.Context, *mode
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// MockdomainRepository is a mock of domainRepository interface.
+type MockdomainRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockdomainRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockdomainRepositoryMockRecorder is the mock recorder for MockdomainRepository.
+type MockdomainRepositoryMockRecorder struct {
+	mock *MockdomainRepository
+}
+
+// NewMockdomainRepository creates a new mock instance.
+func NewMockdomainRepository(ctrl *gomock.Controller) *MockdomainRepository {
+	mock := &MockdomainRepository{ctrl: ctrl}
+	mock.recorder = &MockdomainRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdomainRepository) EXPECT() *MockdomainRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockdomainRepository) GetByID(ctx context.Context, id uuid.UUID) (models.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockdomainRepositoryMockRecorder) GetByID(ctx, id any) *MockdomainRepositoryGetByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockdomainRepository)(nil).GetByID), ctx, id)
+	return &MockdomainRepositoryGetByIDCall{Call: call}
+}
+
+// MockdomainRepositoryGetByIDCall wrap *gomock.Call
+type MockdomainRepositoryGetByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockdomainRepositoryGetByIDCall) Return(arg0 models.Domain, arg1 error) *MockdomainRepositoryGetByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockdomainRepositoryGetByIDCall) Do(f func(context.Context, uuid.UUID) (models.Domain, error)) *MockdomainRepositoryGetByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockdomainRepositoryGetByIDCall) DoAndReturn(f func(context.Context, uuid.UUID) (models.Domain, error)) *MockdomainRepositoryGetByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Mocktransactor is a mock of transactor interface.
+type Mocktransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactorMockRecorder
+	isgomock struct{}
+}
+
+// MocktransactorMockRecorder is the mock recorder for Mocktransactor.
+type MocktransactorMockRecorder struct {
+	mock *Mocktransactor
+}
+
+// NewMocktransactor creates a new mock instance.
+func NewMocktransactor(ctrl *gomock.Controller) *Mocktransactor {
+	mock := &Mocktransactor{ctrl: ctrl}
+	mock.recorder = &MocktransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocktransactor) EXPECT() *MocktransactorMockRecorder {
+	return m.recorder
+}
+
+// Transaction mocks base method.
+func (m *Mocktransactor) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MocktransactorMockRecorder) Transaction(ctx, fn any) *MocktransactorTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*Mocktransactor)(nil).Transaction), ctx, fn)
+	return &MocktransactorTransactionCall{Call: call}
+}
+
+// MocktransactorTransactionCall wrap *gomock.Call
+type MocktransactorTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktransactorTransactionCall) Return(arg0 error) *MocktransactorTransactionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktransactorTransactionCall) Do(f func(context.Context, func(context.Context) error) error) *MocktransactorTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktransactorTransactionCall) DoAndReturn(f func(context.Context, func(context.Context) error) error) *MocktransactorTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockrelationshipOutbox is a mock of relationshipOutbox interface.
+type MockrelationshipOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockrelationshipOutboxMockRecorder
+	isgomock struct{}
+}
+
+// MockrelationshipOutboxMockRecorder is the mock recorder for MockrelationshipOutbox.
+type MockrelationshipOutboxMockRecorder struct {
+	mock *MockrelationshipOutbox
+}
+
+// NewMockrelationshipOutbox creates a new mock instance.
+func NewMockrelationshipOutbox(ctrl *gomock.Controller) *MockrelationshipOutbox {
+	mock := &MockrelationshipOutbox{ctrl: ctrl}
+	mock.recorder = &MockrelationshipOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrelationshipOutbox) EXPECT() *MockrelationshipOutboxMockRecorder {
+	return m.recorder
+}
+
+// DeleteEntity mocks base method.
+func (m *MockrelationshipOutbox) DeleteEntity(ctx context.Context, entityType, entityID string) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEntity", ctx, entityType, entityID)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEntity indicates an expected call of DeleteEntity.
+func (mr *MockrelationshipOutboxMockRecorder) DeleteEntity(ctx, entityType, entityID any) *MockrelationshipOutboxDeleteEntityCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntity", reflect.TypeOf((*MockrelationshipOutbox)(nil).DeleteEntity), ctx, entityType, entityID)
+	return &MockrelationshipOutboxDeleteEntityCall{Call: call}
+}
+
+// MockrelationshipOutboxDeleteEntityCall wrap *gomock.Call
+type MockrelationshipOutboxDeleteEntityCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrelationshipOutboxDeleteEntityCall) Return(arg0 uint, arg1 error) *MockrelationshipOutboxDeleteEntityCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrelationshipOutboxDeleteEntityCall) Do(f func(context.Context, string, string) (uint, error)) *MockrelationshipOutboxDeleteEntityCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrelationshipOutboxDeleteEntityCall) DoAndReturn(f func(context.Context, string, string) (uint, error)) *MockrelationshipOutboxDeleteEntityCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Flush mocks base method.
+func (m *MockrelationshipOutbox) Flush(ctx context.Context, ids []uint) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Flush", ctx, ids)
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockrelationshipOutboxMockRecorder) Flush(ctx, ids any) *MockrelationshipOutboxFlushCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockrelationshipOutbox)(nil).Flush), ctx, ids)
+	return &MockrelationshipOutboxFlushCall{Call: call}
+}
+
+// MockrelationshipOutboxFlushCall wrap *gomock.Call
+type MockrelationshipOutboxFlushCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrelationshipOutboxFlushCall) Return() *MockrelationshipOutboxFlushCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrelationshipOutboxFlushCall) Do(f func(context.Context, []uint)) *MockrelationshipOutboxFlushCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrelationshipOutboxFlushCall) DoAndReturn(f func(context.Context, []uint)) *MockrelationshipOutboxFlushCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Write mocks base method.
+func (m *MockrelationshipOutbox) Write(ctx context.Context, tuples ...permify.Tuple) ([]uint, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range tuples {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Write", varargs...)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockrelationshipOutboxMockRecorder) Write(ctx any, tuples ...any) *MockrelationshipOutboxWriteCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, tuples...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockrelationshipOutbox)(nil).Write), varargs...)
+	return &MockrelationshipOutboxWriteCall{Call: call}
+}
+
+// MockrelationshipOutboxWriteCall wrap *gomock.Call
+type MockrelationshipOutboxWriteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrelationshipOutboxWriteCall) Return(arg0 []uint, arg1 error) *MockrelationshipOutboxWriteCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrelationshipOutboxWriteCall) Do(f func(context.Context, ...permify.Tuple) ([]uint, error)) *MockrelationshipOutboxWriteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrelationshipOutboxWriteCall) DoAndReturn(f func(context.Context, ...permify.Tuple) ([]uint, error)) *MockrelationshipOutboxWriteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockpermissionChecker is a mock of permissionChecker interface.
+type MockpermissionChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockpermissionCheckerMockRecorder
+	isgomock struct{}
+}
+
+// MockpermissionCheckerMockRecorder is the mock recorder for MockpermissionChecker.
+type MockpermissionCheckerMockRecorder struct {
+	mock *MockpermissionChecker
+}
+
+// NewMockpermissionChecker creates a new mock instance.
+func NewMockpermissionChecker(ctrl *gomock.Controller) *MockpermissionChecker {
+	mock := &MockpermissionChecker{ctrl: ctrl}
+	mock.recorder = &MockpermissionCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpermissionChecker) EXPECT() *MockpermissionCheckerMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockpermissionChecker) Check(ctx context.Context, request permify.CheckRequest) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, request)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockpermissionCheckerMockRecorder) Check(ctx, request any) *MockpermissionCheckerCheckCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockpermissionChecker)(nil).Check), ctx, request)
+	return &MockpermissionCheckerCheckCall{Call: call}
+}
+
+// MockpermissionCheckerCheckCall wrap *gomock.Call
+type MockpermissionCheckerCheckCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpermissionCheckerCheckCall) Return(arg0 bool, arg1 error) *MockpermissionCheckerCheckCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpermissionCheckerCheckCall) Do(f func(context.Context, permify.CheckRequest) (bool, error)) *MockpermissionCheckerCheckCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpermissionCheckerCheckCall) DoAndReturn(f func(context.Context, permify.CheckRequest) (bool, error)) *MockpermissionCheckerCheckCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// LookupEntity mocks base method.
+func (m *MockpermissionChecker) LookupEntity(ctx context.Context, entityType, permission, userID, snapToken string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupEntity", ctx, entityType, permission, userID, snapToken)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupEntity indicates an expected call of LookupEntity.
+func (mr *MockpermissionCheckerMockRecorder) LookupEntity(ctx, entityType, permission, userID, snapToken any) *MockpermissionCheckerLookupEntityCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupEntity", reflect.TypeOf((*MockpermissionChecker)(nil).LookupEntity), ctx, entityType, permission, userID, snapToken)
+	return &MockpermissionCheckerLookupEntityCall{Call: call}
+}
+
+// MockpermissionCheckerLookupEntityCall wrap *gomock.Call
+type MockpermissionCheckerLookupEntityCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpermissionCheckerLookupEntityCall) Return(arg0 []string, arg1 error) *MockpermissionCheckerLookupEntityCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpermissionCheckerLookupEntityCall) Do(f func(context.Context, string, string, string, string) ([]string, error)) *MockpermissionCheckerLookupEntityCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpermissionCheckerLookupEntityCall) DoAndReturn(f func(context.Context, string, string, string, string) ([]string, error)) *MockpermissionCheckerLookupEntityCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -1,57 +1,182 @@
 package post_test
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"echo-app/internal/models"
+	"echo-app/internal/permify"
 	"echo-app/internal/requests"
 	"echo-app/internal/services/post"
+	"echo-app/internal/tenant"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
+type transactorStub struct{}
+
+func (transactorStub) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 func TestService_Create(t *testing.T) {
-	newPost := &models.Post{
-		Title:   "title",
-		Content: "conent",
-		UserID:  111,
+	domainID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	wantTuples := []permify.Tuple{
+		{EntityType: "post", EntityID: "5", Relation: "owner", SubjectType: "user", SubjectID: "111"},
+		{EntityType: "post", EntityID: "5", Relation: "parent", SubjectType: "domain", SubjectID: domainID.String()},
 	}
 
-	ctrl := gomock.NewController(t)
-	postRepository := NewMockpostRepository(ctrl)
-	postService := post.NewService(postRepository)
+	newPost := func() *models.Post {
+		return &models.Post{
+			Title:   "title",
+			Content: "conent",
+			UserID:  111,
+		}
+	}
 
-	postRepository.
-		EXPECT().
-		Create(gomock.Any(), newPost).
-		Return(nil)
+	t.Run("It should create the post and record its relationships", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		postRepository := NewMockpostRepository(ctrl)
+		relationships := NewMockrelationshipOutbox(ctrl)
+		postService := post.NewService(postRepository, NewMockdomainRepository(ctrl), transactorStub{}, relationships, NewMockpermissionChecker(ctrl))
 
-	err := postService.Create(t.Context(), newPost)
-	require.NoError(t, err)
+		postRepository.
+			EXPECT().
+			Create(gomock.Any(), newPost()).
+			DoAndReturn(func(_ context.Context, post *models.Post) error {
+				post.ID = 5
+				post.DomainID = domainID
+				return nil
+			})
+
+		relationships.
+			EXPECT().
+			Write(gomock.Any(), wantTuples[0], wantTuples[1]).
+			Return([]uint{1, 2}, nil)
+
+		relationships.
+			EXPECT().
+			Flush(gomock.Any(), []uint{1, 2})
+
+		err := postService.Create(t.Context(), newPost())
+		require.NoError(t, err)
+	})
+
+	t.Run("It should fail when the relationships can't be recorded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		postRepository := NewMockpostRepository(ctrl)
+		relationships := NewMockrelationshipOutbox(ctrl)
+		postService := post.NewService(postRepository, NewMockdomainRepository(ctrl), transactorStub{}, relationships, NewMockpermissionChecker(ctrl))
+
+		postRepository.
+			EXPECT().
+			Create(gomock.Any(), newPost()).
+			DoAndReturn(func(_ context.Context, post *models.Post) error {
+				post.ID = 5
+				post.DomainID = domainID
+				return nil
+			})
+
+		relationships.
+			EXPECT().
+			Write(gomock.Any(), wantTuples[0], wantTuples[1]).
+			Return(nil, errors.New("insert failed"))
+
+		err := postService.Create(t.Context(), newPost())
+		require.Error(t, err)
+	})
 }
 
 func TestService_GetPosts(t *testing.T) {
-	wantPosts := []models.Post{{
-		Title:   "title",
-		Content: "conent",
-		UserID:  111,
-	}}
+	domainID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
 
-	ctrl := gomock.NewController(t)
-	postRepository := NewMockpostRepository(ctrl)
-	postService := post.NewService(postRepository)
+	newService := func(t *testing.T) (post.Service, *MockpostRepository, *MockdomainRepository, *MockpermissionChecker) {
+		t.Helper()
 
-	postRepository.
-		EXPECT().
-		GetPosts(gomock.Any()).
-		Return(wantPosts, nil)
+		ctrl := gomock.NewController(t)
+		postRepository := NewMockpostRepository(ctrl)
+		domainRepository := NewMockdomainRepository(ctrl)
+		permissions := NewMockpermissionChecker(ctrl)
+		postService := post.NewService(postRepository, domainRepository, transactorStub{}, NewMockrelationshipOutbox(ctrl), permissions)
 
-	gotPosts, err := postService.GetPosts(t.Context())
-	require.NoError(t, err)
+		return postService, postRepository, domainRepository, permissions
+	}
+
+	t.Run("It should check every post of a small domain", func(t *testing.T) {
+		postService, postRepository, _, permissions := newService(t)
 
-	assert.Equal(t, wantPosts, gotPosts)
+		posts := []models.Post{
+			{Model: gorm.Model{ID: 1}, SnapToken: "snap-token"},
+			{Model: gorm.Model{ID: 2}},
+		}
+
+		postRepository.
+			EXPECT().
+			CountPosts(gomock.Any()).
+			Return(int64(2), nil)
+
+		postRepository.
+			EXPECT().
+			GetPosts(gomock.Any()).
+			Return(posts, nil)
+
+		permissions.
+			EXPECT().
+			Check(gomock.Any(), permify.CheckRequest{
+				EntityType: "post",
+				EntityID:   "1",
+				Permission: "view",
+				UserID:     "7",
+				SnapToken:  "snap-token",
+			}).
+			Return(true, nil)
+
+		permissions.
+			EXPECT().
+			Check(gomock.Any(), permify.CheckRequest{EntityType: "post", EntityID: "2", Permission: "view", UserID: "7"}).
+			Return(false, nil)
+
+		gotPosts, err := postService.GetPosts(t.Context(), 7)
+		require.NoError(t, err)
+
+		assert.Equal(t, posts[:1], gotPosts)
+	})
+
+	t.Run("It should look up the viewable posts of a large domain as fresh as the domain", func(t *testing.T) {
+		postService, postRepository, domainRepository, permissions := newService(t)
+
+		wantPosts := []models.Post{{Model: gorm.Model{ID: 5}}, {Model: gorm.Model{ID: 9}}}
+
+		postRepository.
+			EXPECT().
+			CountPosts(gomock.Any()).
+			Return(int64(1000), nil)
+
+		domainRepository.
+			EXPECT().
+			GetByID(gomock.Any(), domainID).
+			Return(models.Domain{ID: domainID, SnapToken: "domain-snap-token"}, nil)
+
+		permissions.
+			EXPECT().
+			LookupEntity(gomock.Any(), "post", "view", "7", "domain-snap-token").
+			Return([]string{"5", "9", "invalid"}, nil)
+
+		postRepository.
+			EXPECT().
+			GetPostsByIDs(gomock.Any(), []uint{5, 9}).
+			Return(wantPosts, nil)
+
+		gotPosts, err := postService.GetPosts(tenant.WithDomain(t.Context(), domainID), 7)
+		require.NoError(t, err)
+
+		assert.Equal(t, wantPosts, gotPosts)
+	})
 }
 
 func TestService_GetPost(t *testing.T) {
@@ -63,7 +188,7 @@ func TestService_GetPost(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	postRepository := NewMockpostRepository(ctrl)
-	postService := post.NewService(postRepository)
+	postService := post.NewService(postRepository, NewMockdomainRepository(ctrl), transactorStub{}, NewMockrelationshipOutbox(ctrl), NewMockpermissionChecker(ctrl))
 
 	postRepository.
 		EXPECT().
@@ -76,6 +201,38 @@ func TestService_GetPost(t *testing.T) {
 	assert.Equal(t, wantPost, gotPost)
 }
 
+func TestService_SnapToken(t *testing.T) {
+	t.Run("It should return the snap token of the post", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		postRepository := NewMockpostRepository(ctrl)
+		postService := post.NewService(postRepository, NewMockdomainRepository(ctrl), transactorStub{}, NewMockrelationshipOutbox(ctrl), NewMockpermissionChecker(ctrl))
+
+		postRepository.
+			EXPECT().
+			GetPost(gomock.Any(), uint(5)).
+			Return(models.Post{SnapToken: "snap-token"}, nil)
+
+		snapToken, err := postService.SnapToken(t.Context(), "5")
+		require.NoError(t, err)
+		assert.Equal(t, "snap-token", snapToken)
+	})
+
+	t.Run("It should return no snap token for an unknown post", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		postRepository := NewMockpostRepository(ctrl)
+		postService := post.NewService(postRepository, NewMockdomainRepository(ctrl), transactorStub{}, NewMockrelationshipOutbox(ctrl), NewMockpermissionChecker(ctrl))
+
+		postRepository.
+			EXPECT().
+			GetPost(gomock.Any(), uint(5)).
+			Return(models.Post{}, models.ErrPostNotFound)
+
+		snapToken, err := postService.SnapToken(t.Context(), "5")
+		require.NoError(t, err)
+		assert.Empty(t, snapToken)
+	})
+}
+
 func TestService_Update(t *testing.T) {
 	oldPost := &models.Post{
 		Title:   "title",
@@ -98,7 +255,7 @@ func TestService_Update(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	postRepository := NewMockpostRepository(ctrl)
-	postService := post.NewService(postRepository)
+	postService := post.NewService(postRepository, NewMockdomainRepository(ctrl), transactorStub{}, NewMockrelationshipOutbox(ctrl), NewMockpermissionChecker(ctrl))
 
 	postRepository.
 		EXPECT().
@@ -111,20 +268,33 @@ func TestService_Update(t *testing.T) {
 
 func TestService_Delete(t *testing.T) {
 	wantPost := &models.Post{
+		Model:   gorm.Model{ID: 5},
 		Title:   "new title",
 		Content: "new content",
 		UserID:  111,
 	}
 
-	ctrl := gomock.NewController(t)
-	postRepository := NewMockpostRepository(ctrl)
-	postService := post.NewService(postRepository)
+	t.Run("It should delete the post and its relationships", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		postRepository := NewMockpostRepository(ctrl)
+		relationships := NewMockrelationshipOutbox(ctrl)
+		postService := post.NewService(postRepository, NewMockdomainRepository(ctrl), transactorStub{}, relationships, NewMockpermissionChecker(ctrl))
 
-	postRepository.
-		EXPECT().
-		Delete(gomock.Any(), wantPost).
-		Return(nil)
+		postRepository.
+			EXPECT().
+			Delete(gomock.Any(), wantPost).
+			Return(nil)
 
-	err := postService.Delete(t.Context(), wantPost)
-	require.NoError(t, err)
+		relationships.
+			EXPECT().
+			DeleteEntity(gomock.Any(), "post", "5").
+			Return(uint(1), nil)
+
+		relationships.
+			EXPECT().
+			Flush(gomock.Any(), []uint{1})
+
+		err := postService.Delete(t.Context(), wantPost)
+		require.NoError(t, err)
+	})
 }
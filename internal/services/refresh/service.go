@@ -0,0 +1,211 @@
+package refresh
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"echo-app/internal/config"
+	"echo-app/internal/models"
+
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+const tokenLength = 32
+
+var (
+	ErrMissingSecret = errors.New("refresh token secret is not configured")
+	ErrTokenExpired  = errors.New("refresh token expired")
+	ErrTokenRevoked  = errors.New("refresh token revoked")
+	ErrTokenReused   = errors.New("refresh token reused")
+)
+
+type tokenRepository interface {
+	Create(ctx context.Context, token *models.RefreshToken) error
+	GetByHash(ctx context.Context, hash string) (models.RefreshToken, error)
+	MarkUsed(ctx context.Context, id uint, usedAt time.Time) (bool, error)
+	RevokeFamily(ctx context.Context, familyID uuid.UUID, revokedAt time.Time) error
+	RevokeUser(ctx context.Context, userID uint, revokedAt time.Time) error
+	RevokeOIDCSession(ctx context.Context, provider, sessionID string, revokedAt time.Time) error
+}
+
+type transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Token is a refresh token value handed out to the client.
+type Token struct {
+	Value     string
+	ExpiresAt time.Time
+}
+
+type Service struct {
+	tokenRepository tokenRepository
+	transactor      transactor
+	secret          []byte
+	ttl             time.Duration
+	now             func() time.Time
+}
+
+func NewService(tokenRepository tokenRepository, transactor transactor, cfg config.RefreshToken) (*Service, error) {
+	if cfg.Secret == "" {
+		return nil, ErrMissingSecret
+	}
+
+	return &Service{
+		tokenRepository: tokenRepository,
+		transactor:      transactor,
+		secret:          []byte(cfg.Secret),
+		ttl:             cfg.TTL,
+		now:             time.Now,
+	}, nil
+}
+
+// Issue starts the token family of the login session, the family has the ID of the session.
+// Logins with OIDC keep the identity provider and its session ID.
+func (s *Service) Issue(ctx context.Context, userID uint, familyID uuid.UUID, oidcProvider, oidcSessionID string) (Token, error) {
+	return s.create(ctx, userID, familyID, oidcProvider, oidcSessionID)
+}
+
+// Rotate exchanges the refresh token for a new one from the same family and returns the exchanged token with it.
+// Presenting a token that has already been used revokes the whole family.
+func (s *Service) Rotate(ctx context.Context, value string) (models.RefreshToken, Token, error) {
+	token, err := s.tokenRepository.GetByHash(ctx, s.hash(value))
+	if err != nil {
+		return models.RefreshToken{}, Token{}, fmt.Errorf("get refresh token: %w", err)
+	}
+
+	now := s.now()
+
+	if token.RevokedAt != nil {
+		return models.RefreshToken{}, Token{}, ErrTokenRevoked
+	}
+
+	if token.UsedAt != nil {
+		return models.RefreshToken{}, Token{}, s.revokeReusedFamily(ctx, token.FamilyID, now)
+	}
+
+	if !now.Before(token.ExpiresAt) {
+		return models.RefreshToken{}, Token{}, ErrTokenExpired
+	}
+
+	var (
+		marked   bool
+		newToken Token
+	)
+
+	// A token is only used up together with its replacement, or a retry after a failure would look like a reuse
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		marked, err = s.tokenRepository.MarkUsed(ctx, token.ID, now)
+		if err != nil {
+			return fmt.Errorf("mark refresh token as used: %w", err)
+		}
+
+		if !marked {
+			return nil
+		}
+
+		newToken, err = s.create(ctx, token.UserID, token.FamilyID, token.OIDCProvider, token.OIDCSessionID)
+
+		return err
+	})
+	if err != nil {
+		return models.RefreshToken{}, Token{}, fmt.Errorf("rotate refresh token: %w", err)
+	}
+
+	if !marked {
+		return models.RefreshToken{}, Token{}, s.revokeReusedFamily(ctx, token.FamilyID, now)
+	}
+
+	return token, newToken, nil
+}
+
+// Revoke revokes the family the refresh token belongs to and returns the ID of the family.
+func (s *Service) Revoke(ctx context.Context, value string) (uuid.UUID, error) {
+	token, err := s.tokenRepository.GetByHash(ctx, s.hash(value))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("get refresh token: %w", err)
+	}
+
+	if err := s.RevokeFamily(ctx, token.FamilyID); err != nil {
+		return uuid.Nil, err
+	}
+
+	return token.FamilyID, nil
+}
+
+// RevokeFamily revokes the token family.
+func (s *Service) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	if err := s.tokenRepository.RevokeFamily(ctx, familyID, s.now()); err != nil {
+		return fmt.Errorf("revoke refresh token family: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeUser revokes every token family of the user.
+func (s *Service) RevokeUser(ctx context.Context, userID uint) error {
+	if err := s.tokenRepository.RevokeUser(ctx, userID, s.now()); err != nil {
+		return fmt.Errorf("revoke user refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeOIDCSession revokes the token families started in the session of the identity provider.
+func (s *Service) RevokeOIDCSession(ctx context.Context, provider, sessionID string) error {
+	if err := s.tokenRepository.RevokeOIDCSession(ctx, provider, sessionID, s.now()); err != nil {
+		return fmt.Errorf("revoke OIDC session refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) create(ctx context.Context, userID uint, familyID uuid.UUID, oidcProvider, oidcSessionID string) (Token, error) {
+	raw := make([]byte, tokenLength)
+	if _, err := rand.Read(raw); err != nil {
+		return Token{}, fmt.Errorf("generate refresh token: %w", err)
+	}
+
+	value := base64.RawURLEncoding.EncodeToString(raw)
+
+	token := &models.RefreshToken{
+		UserID:        userID,
+		FamilyID:      familyID,
+		TokenHash:     s.hash(value),
+		OIDCProvider:  oidcProvider,
+		OIDCSessionID: oidcSessionID,
+		ExpiresAt:     s.now().Add(s.ttl),
+	}
+
+	if err := s.tokenRepository.Create(ctx, token); err != nil {
+		return Token{}, fmt.Errorf("create refresh token in repository: %w", err)
+	}
+
+	return Token{Value: value, ExpiresAt: token.ExpiresAt}, nil
+}
+
+func (s *Service) revokeReusedFamily(ctx context.Context, familyID uuid.UUID, now time.Time) error {
+	if err := s.tokenRepository.RevokeFamily(ctx, familyID, now); err != nil {
+		return errors.Join(ErrTokenReused, fmt.Errorf("revoke refresh token family: %w", err))
+	}
+
+	return ErrTokenReused
+}
+
+func (s *Service) hash(value string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(value))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,336 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=refresh_test -typed=true
+//
+
+// Package refresh_test is a generated GoMock package.
+package refresh_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "echo-app/internal/models"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MocktokenRepository is a mock of tokenRepository interface.
+type MocktokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MocktokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MocktokenRepositoryMockRecorder is the mock recorder for MocktokenRepository.
+type MocktokenRepositoryMockRecorder struct {
+	mock *MocktokenRepository
+}
+
+// NewMocktokenRepository creates a new mock instance.
+func NewMocktokenRepository(ctrl *gomock.Controller) *MocktokenRepository {
+	mock := &MocktokenRepository{ctrl: ctrl}
+	mock.recorder = &MocktokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktokenRepository) EXPECT() *MocktokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MocktokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MocktokenRepositoryMockRecorder) Create(ctx, token any) *MocktokenRepositoryCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MocktokenRepository)(nil).Create), ctx, token)
+	return &MocktokenRepositoryCreateCall{Call: call}
+}
+
+// MocktokenRepositoryCreateCall wrap *gomock.Call
+type MocktokenRepositoryCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenRepositoryCreateCall) Return(arg0 error) *MocktokenRepositoryCreateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenRepositoryCreateCall) Do(f func(context.Context, *models.RefreshToken) error) *MocktokenRepositoryCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenRepositoryCreateCall) DoAndReturn(f func(context.Context, *models.RefreshToken) error) *MocktokenRepositoryCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByHash mocks base method.
+func (m *MocktokenRepository) GetByHash(ctx context.Context, hash string) (models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, hash)
+	ret0, _ := ret[0].(models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MocktokenRepositoryMockRecorder) GetByHash(ctx, hash any) *MocktokenRepositoryGetByHashCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MocktokenRepository)(nil).GetByHash), ctx, hash)
+	return &MocktokenRepositoryGetByHashCall{Call: call}
+}
+
+// MocktokenRepositoryGetByHashCall wrap *gomock.Call
+type MocktokenRepositoryGetByHashCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenRepositoryGetByHashCall) Return(arg0 models.RefreshToken, arg1 error) *MocktokenRepositoryGetByHashCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenRepositoryGetByHashCall) Do(f func(context.Context, string) (models.RefreshToken, error)) *MocktokenRepositoryGetByHashCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenRepositoryGetByHashCall) DoAndReturn(f func(context.Context, string) (models.RefreshToken, error)) *MocktokenRepositoryGetByHashCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MarkUsed mocks base method.
+func (m *MocktokenRepository) MarkUsed(ctx context.Context, id uint, usedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id, usedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MocktokenRepositoryMockRecorder) MarkUsed(ctx, id, usedAt any) *MocktokenRepositoryMarkUsedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MocktokenRepository)(nil).MarkUsed), ctx, id, usedAt)
+	return &MocktokenRepositoryMarkUsedCall{Call: call}
+}
+
+// MocktokenRepositoryMarkUsedCall wrap *gomock.Call
+type MocktokenRepositoryMarkUsedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenRepositoryMarkUsedCall) Return(arg0 bool, arg1 error) *MocktokenRepositoryMarkUsedCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenRepositoryMarkUsedCall) Do(f func(context.Context, uint, time.Time) (bool, error)) *MocktokenRepositoryMarkUsedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenRepositoryMarkUsedCall) DoAndReturn(f func(context.Context, uint, time.Time) (bool, error)) *MocktokenRepositoryMarkUsedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeFamily mocks base method.
+func (m *MocktokenRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamily", ctx, familyID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFamily indicates an expected call of RevokeFamily.
+func (mr *MocktokenRepositoryMockRecorder) RevokeFamily(ctx, familyID, revokedAt any) *MocktokenRepositoryRevokeFamilyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamily", reflect.TypeOf((*MocktokenRepository)(nil).RevokeFamily), ctx, familyID, revokedAt)
+	return &MocktokenRepositoryRevokeFamilyCall{Call: call}
+}
+
+// MocktokenRepositoryRevokeFamilyCall wrap *gomock.Call
+type MocktokenRepositoryRevokeFamilyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenRepositoryRevokeFamilyCall) Return(arg0 error) *MocktokenRepositoryRevokeFamilyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenRepositoryRevokeFamilyCall) Do(f func(context.Context, uuid.UUID, time.Time) error) *MocktokenRepositoryRevokeFamilyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenRepositoryRevokeFamilyCall) DoAndReturn(f func(context.Context, uuid.UUID, time.Time) error) *MocktokenRepositoryRevokeFamilyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeOIDCSession mocks base method.
+func (m *MocktokenRepository) RevokeOIDCSession(ctx context.Context, provider, sessionID string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOIDCSession", ctx, provider, sessionID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOIDCSession indicates an expected call of RevokeOIDCSession.
+func (mr *MocktokenRepositoryMockRecorder) RevokeOIDCSession(ctx, provider, sessionID, revokedAt any) *MocktokenRepositoryRevokeOIDCSessionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOIDCSession", reflect.TypeOf((*MocktokenRepository)(nil).RevokeOIDCSession), ctx, provider, sessionID, revokedAt)
+	return &MocktokenRepositoryRevokeOIDCSessionCall{Call: call}
+}
+
+// MocktokenRepositoryRevokeOIDCSessionCall wrap *gomock.Call
+type MocktokenRepositoryRevokeOIDCSessionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenRepositoryRevokeOIDCSessionCall) Return(arg0 error) *MocktokenRepositoryRevokeOIDCSessionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenRepositoryRevokeOIDCSessionCall) Do(f func(context.Context, string, string, time.Time) error) *MocktokenRepositoryRevokeOIDCSessionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenRepositoryRevokeOIDCSessionCall) DoAndReturn(f func(context.Context, string, string, time.Time) error) *MocktokenRepositoryRevokeOIDCSessionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeUser mocks base method.
+func (m *MocktokenRepository) RevokeUser(ctx context.Context, userID uint, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUser", ctx, userID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUser indicates an expected call of RevokeUser.
+func (mr *MocktokenRepositoryMockRecorder) RevokeUser(ctx, userID, revokedAt any) *MocktokenRepositoryRevokeUserCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUser", reflect.TypeOf((*MocktokenRepository)(nil).RevokeUser), ctx, userID, revokedAt)
+	return &MocktokenRepositoryRevokeUserCall{Call: call}
+}
+
+// MocktokenRepositoryRevokeUserCall wrap *gomock.Call
+type MocktokenRepositoryRevokeUserCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenRepositoryRevokeUserCall) Return(arg0 error) *MocktokenRepositoryRevokeUserCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenRepositoryRevokeUserCall) Do(f func(context.Context, uint, time.Time) error) *MocktokenRepositoryRevokeUserCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenRepositoryRevokeUserCall) DoAndReturn(f func(context.Context, uint, time.Time) error) *MocktokenRepositoryRevokeUserCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Mocktransactor is a mock of transactor interface.
+type Mocktransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactorMockRecorder
+	isgomock struct{}
+}
+
+// MocktransactorMockRecorder is the mock recorder for Mocktransactor.
+type MocktransactorMockRecorder struct {
+	mock *Mocktransactor
+}
+
+// NewMocktransactor creates a new mock instance.
+func NewMocktransactor(ctrl *gomock.Controller) *Mocktransactor {
+	mock := &Mocktransactor{ctrl: ctrl}
+	mock.recorder = &MocktransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocktransactor) EXPECT() *MocktransactorMockRecorder {
+	return m.recorder
+}
+
+// Transaction mocks base method.
+func (m *Mocktransactor) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MocktransactorMockRecorder) Transaction(ctx, fn any) *MocktransactorTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*Mocktransactor)(nil).Transaction), ctx, fn)
+	return &MocktransactorTransactionCall{Call: call}
+}
+
+// MocktransactorTransactionCall wrap *gomock.Call
+type MocktransactorTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktransactorTransactionCall) Return(arg0 error) *MocktransactorTransactionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktransactorTransactionCall) Do(f func(context.Context, func(context.Context) error) error) *MocktransactorTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktransactorTransactionCall) DoAndReturn(f func(context.Context, func(context.Context) error) error) *MocktransactorTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,262 @@
+package refresh_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"echo-app/internal/config"
+	"echo-app/internal/models"
+	"echo-app/internal/services/refresh"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// inTransaction marks the context passed to the function of transactorStub.
+type inTransaction struct{}
+
+type transactorStub struct{}
+
+func (transactorStub) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, inTransaction{}, true))
+}
+
+func newService(t *testing.T) (*refresh.Service, *MocktokenRepository) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	tokenRepository := NewMocktokenRepository(ctrl)
+
+	refreshService, err := refresh.NewService(tokenRepository, transactorStub{}, config.RefreshToken{Secret: "secret", TTL: time.Hour})
+	require.NoError(t, err)
+
+	return refreshService, tokenRepository
+}
+
+func TestNewService(t *testing.T) {
+	_, err := refresh.NewService(nil, transactorStub{}, config.RefreshToken{TTL: time.Hour})
+	assert.ErrorIs(t, err, refresh.ErrMissingSecret)
+}
+
+// issue issues a token and returns its value together with the stored model.
+func issue(t *testing.T, refreshService *refresh.Service, tokenRepository *MocktokenRepository) (string, models.RefreshToken) {
+	t.Helper()
+
+	var stored models.RefreshToken
+
+	tokenRepository.
+		EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, token *models.RefreshToken) error {
+			token.ID = 1
+			stored = *token
+			return nil
+		})
+
+	token, err := refreshService.Issue(t.Context(), 7, uuid.MustParse("11111111-1111-1111-1111-111111111111"), "", "")
+	require.NoError(t, err)
+
+	return token.Value, stored
+}
+
+func TestService_Issue(t *testing.T) {
+	refreshService, tokenRepository := newService(t)
+
+	value, stored := issue(t, refreshService, tokenRepository)
+
+	assert.NotEmpty(t, value)
+	assert.NotEqual(t, value, stored.TokenHash)
+	assert.Equal(t, uint(7), stored.UserID)
+	assert.Equal(t, uuid.MustParse("11111111-1111-1111-1111-111111111111"), stored.FamilyID)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), stored.ExpiresAt, 5*time.Second)
+}
+
+func TestService_Rotate(t *testing.T) {
+	t.Run("It should rotate a token within its family", func(t *testing.T) {
+		refreshService, tokenRepository := newService(t)
+		value, stored := issue(t, refreshService, tokenRepository)
+		stored.OIDCProvider = "google"
+		stored.OIDCSessionID = "oidc-session"
+
+		tokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), stored.TokenHash).
+			Return(stored, nil)
+
+		tokenRepository.
+			EXPECT().
+			MarkUsed(gomock.Any(), stored.ID, gomock.Any()).
+			Return(true, nil)
+
+		tokenRepository.
+			EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, token *models.RefreshToken) error {
+				assert.Equal(t, stored.FamilyID, token.FamilyID)
+				assert.Equal(t, "google", token.OIDCProvider)
+				assert.Equal(t, "oidc-session", token.OIDCSessionID)
+				assert.NotEqual(t, stored.TokenHash, token.TokenHash)
+				return nil
+			})
+
+		rotated, newToken, err := refreshService.Rotate(t.Context(), value)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint(7), rotated.UserID)
+		assert.Equal(t, stored.FamilyID, rotated.FamilyID)
+		assert.NotEqual(t, value, newToken.Value)
+	})
+
+	t.Run("It should use up the token together with its replacement", func(t *testing.T) {
+		refreshService, tokenRepository := newService(t)
+		value, stored := issue(t, refreshService, tokenRepository)
+
+		tokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), stored.TokenHash).
+			Return(stored, nil)
+
+		tokenRepository.
+			EXPECT().
+			MarkUsed(gomock.Any(), stored.ID, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ uint, _ time.Time) (bool, error) {
+				assert.Equal(t, true, ctx.Value(inTransaction{}))
+				return true, nil
+			})
+
+		tokenRepository.
+			EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ *models.RefreshToken) error {
+				assert.Equal(t, true, ctx.Value(inTransaction{}))
+				return errors.New("insert failed")
+			})
+
+		_, _, err := refreshService.Rotate(t.Context(), value)
+		require.ErrorContains(t, err, "insert failed")
+		assert.NotErrorIs(t, err, refresh.ErrTokenReused)
+	})
+
+	t.Run("It should revoke the family when a used token is replayed", func(t *testing.T) {
+		refreshService, tokenRepository := newService(t)
+		value, stored := issue(t, refreshService, tokenRepository)
+
+		usedAt := time.Now()
+		stored.UsedAt = &usedAt
+
+		tokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), stored.TokenHash).
+			Return(stored, nil)
+
+		tokenRepository.
+			EXPECT().
+			RevokeFamily(gomock.Any(), stored.FamilyID, gomock.Any()).
+			Return(nil)
+
+		_, _, err := refreshService.Rotate(t.Context(), value)
+		assert.ErrorIs(t, err, refresh.ErrTokenReused)
+	})
+
+	t.Run("It should revoke the family when a concurrent rotation won", func(t *testing.T) {
+		refreshService, tokenRepository := newService(t)
+		value, stored := issue(t, refreshService, tokenRepository)
+
+		tokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), stored.TokenHash).
+			Return(stored, nil)
+
+		tokenRepository.
+			EXPECT().
+			MarkUsed(gomock.Any(), stored.ID, gomock.Any()).
+			Return(false, nil)
+
+		tokenRepository.
+			EXPECT().
+			RevokeFamily(gomock.Any(), stored.FamilyID, gomock.Any()).
+			Return(nil)
+
+		_, _, err := refreshService.Rotate(t.Context(), value)
+		assert.ErrorIs(t, err, refresh.ErrTokenReused)
+	})
+
+	t.Run("It should reject a revoked token", func(t *testing.T) {
+		refreshService, tokenRepository := newService(t)
+		value, stored := issue(t, refreshService, tokenRepository)
+
+		revokedAt := time.Now()
+		stored.RevokedAt = &revokedAt
+
+		tokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), stored.TokenHash).
+			Return(stored, nil)
+
+		_, _, err := refreshService.Rotate(t.Context(), value)
+		assert.ErrorIs(t, err, refresh.ErrTokenRevoked)
+	})
+
+	t.Run("It should reject an expired token", func(t *testing.T) {
+		refreshService, tokenRepository := newService(t)
+		value, stored := issue(t, refreshService, tokenRepository)
+
+		stored.ExpiresAt = time.Now().Add(-time.Minute)
+
+		tokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), stored.TokenHash).
+			Return(stored, nil)
+
+		_, _, err := refreshService.Rotate(t.Context(), value)
+		assert.ErrorIs(t, err, refresh.ErrTokenExpired)
+	})
+}
+
+func TestService_Revoke(t *testing.T) {
+	refreshService, tokenRepository := newService(t)
+	value, stored := issue(t, refreshService, tokenRepository)
+
+	tokenRepository.
+		EXPECT().
+		GetByHash(gomock.Any(), stored.TokenHash).
+		Return(stored, nil)
+
+	tokenRepository.
+		EXPECT().
+		RevokeFamily(gomock.Any(), stored.FamilyID, gomock.Any()).
+		Return(nil)
+
+	familyID, err := refreshService.Revoke(t.Context(), value)
+	require.NoError(t, err)
+
+	assert.Equal(t, stored.FamilyID, familyID)
+}
+
+func TestService_RevokeUser(t *testing.T) {
+	refreshService, tokenRepository := newService(t)
+
+	tokenRepository.
+		EXPECT().
+		RevokeUser(gomock.Any(), uint(7), gomock.Any()).
+		Return(nil)
+
+	err := refreshService.RevokeUser(t.Context(), 7)
+	require.NoError(t, err)
+}
+
+func TestService_RevokeOIDCSession(t *testing.T) {
+	refreshService, tokenRepository := newService(t)
+
+	tokenRepository.
+		EXPECT().
+		RevokeOIDCSession(gomock.Any(), "google", "oidc-session", gomock.Any()).
+		Return(nil)
+
+	err := refreshService.RevokeOIDCSession(t.Context(), "google", "oidc-session")
+	require.NoError(t, err)
+}
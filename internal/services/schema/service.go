@@ -0,0 +1,81 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"echo-app/internal/config"
+	"echo-app/internal/models"
+	"echo-app/internal/permify"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+var ErrSchemaDrift = errors.New("embedded permify schema differs from the active schema version")
+
+type schemaVersionRepository interface {
+	Create(ctx context.Context, version *models.SchemaVersion) error
+	Latest(ctx context.Context, tenantID string) (models.SchemaVersion, error)
+}
+
+type schemaWriter interface {
+	WriteSchema(ctx context.Context, schema string) (string, error)
+}
+
+// Service keeps the Permify schema of the tenant in line with the schema embedded in the binary.
+type Service struct {
+	schemaVersionRepository schemaVersionRepository
+	schemaWriter            schemaWriter
+	tenantID                string
+	allowDrift              bool
+}
+
+func NewService(schemaVersionRepository schemaVersionRepository, schemaWriter schemaWriter, cfg config.Permify) Service {
+	return Service{
+		schemaVersionRepository: schemaVersionRepository,
+		schemaWriter:            schemaWriter,
+		tenantID:                cfg.TenantID,
+		allowDrift:              cfg.AllowSchemaDrift,
+	}
+}
+
+// Sync returns the schema version of the embedded schema. The schema is only written to Permify when there is no
+// version for its content yet. Replacing the active version of another content fails with ErrSchemaDrift unless
+// drift is allowed, so a service can't silently change the schema other deployments of the tenant run with.
+func (s Service) Sync(ctx context.Context) (string, error) {
+	content, err := permify.Schema()
+	if err != nil {
+		return "", fmt.Errorf("load embedded schema: %w", err)
+	}
+
+	hash := permify.SchemaHash(content)
+
+	active, err := s.schemaVersionRepository.Latest(ctx, s.tenantID)
+	switch {
+	case errors.Is(err, models.ErrSchemaVersionNotFound):
+	case err != nil:
+		return "", fmt.Errorf("get active schema version from repository: %w", err)
+	case active.Hash == hash:
+		return active.Version, nil
+	case !s.allowDrift:
+		return "", fmt.Errorf("%w: active version %s has hash %s, embedded schema has hash %s",
+			ErrSchemaDrift, active.Version, active.Hash, hash)
+	}
+
+	version, err := s.schemaWriter.WriteSchema(ctx, content)
+	if err != nil {
+		return "", fmt.Errorf("write schema: %w", err)
+	}
+
+	err = s.schemaVersionRepository.Create(ctx, &models.SchemaVersion{
+		TenantID: s.tenantID,
+		Hash:     hash,
+		Version:  version,
+	})
+	if err != nil {
+		return "", fmt.Errorf("create schema version in repository: %w", err)
+	}
+
+	return version, nil
+}
@@ -0,0 +1,182 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=schema_test -typed=true
+//
+
+// Package schema_test is a generated GoMock package.
+package schema_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "echo-app/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockschemaVersionRepository is a mock of schemaVersionRepository interface.
+type MockschemaVersionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockschemaVersionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockschemaVersionRepositoryMockRecorder is the mock recorder for MockschemaVersionRepository.
+type MockschemaVersionRepositoryMockRecorder struct {
+	mock *MockschemaVersionRepository
+}
+
+// NewMockschemaVersionRepository creates a new mock instance.
+func NewMockschemaVersionRepository(ctrl *gomock.Controller) *MockschemaVersionRepository {
+	mock := &MockschemaVersionRepository{ctrl: ctrl}
+	mock.recorder = &MockschemaVersionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockschemaVersionRepository) EXPECT() *MockschemaVersionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockschemaVersionRepository) Create(ctx context.Context, version *models.SchemaVersion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockschemaVersionRepositoryMockRecorder) Create(ctx, version any) *MockschemaVersionRepositoryCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockschemaVersionRepository)(nil).Create), ctx, version)
+	return &MockschemaVersionRepositoryCreateCall{Call: call}
+}
+
+// MockschemaVersionRepositoryCreateCall wrap *gomock.Call
+type MockschemaVersionRepositoryCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockschemaVersionRepositoryCreateCall) Return(arg0 error) *MockschemaVersionRepositoryCreateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockschemaVersionRepositoryCreateCall) Do(f func(context.Context, *models.SchemaVersion) error) *MockschemaVersionRepositoryCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockschemaVersionRepositoryCreateCall) DoAndReturn(f func(context.Context, *models.SchemaVersion) error) *MockschemaVersionRepositoryCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Latest mocks base method.
+func (m *MockschemaVersionRepository) Latest(ctx context.Context, tenantID string) (models.SchemaVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Latest", ctx, tenantID)
+	ret0, _ := ret[0].(models.SchemaVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Latest indicates an expected call of Latest.
+func (mr *MockschemaVersionRepositoryMockRecorder) Latest(ctx, tenantID any) *MockschemaVersionRepositoryLatestCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Latest", reflect.TypeOf((*MockschemaVersionRepository)(nil).Latest), ctx, tenantID)
+	return &MockschemaVersionRepositoryLatestCall{Call: call}
+}
+
+// MockschemaVersionRepositoryLatestCall wrap *gomock.Call
+type MockschemaVersionRepositoryLatestCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockschemaVersionRepositoryLatestCall) Return(arg0 models.SchemaVersion, arg1 error) *MockschemaVersionRepositoryLatestCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockschemaVersionRepositoryLatestCall) Do(f func(context.Context, string) (models.SchemaVersion, error)) *MockschemaVersionRepositoryLatestCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockschemaVersionRepositoryLatestCall) DoAndReturn(f func(context.Context, string) (models.SchemaVersion, error)) *MockschemaVersionRepositoryLatestCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockschemaWriter is a mock of schemaWriter interface.
+type MockschemaWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockschemaWriterMockRecorder
+	isgomock struct{}
+}
+
+// MockschemaWriterMockRecorder is the mock recorder for MockschemaWriter.
+type MockschemaWriterMockRecorder struct {
+	mock *MockschemaWriter
+}
+
+// NewMockschemaWriter creates a new mock instance.
+func NewMockschemaWriter(ctrl *gomock.Controller) *MockschemaWriter {
+	mock := &MockschemaWriter{ctrl: ctrl}
+	mock.recorder = &MockschemaWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockschemaWriter) EXPECT() *MockschemaWriterMockRecorder {
+	return m.recorder
+}
+
+// WriteSchema mocks base method.
+func (m *MockschemaWriter) WriteSchema(ctx context.Context, schema string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteSchema", ctx, schema)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteSchema indicates an expected call of WriteSchema.
+func (mr *MockschemaWriterMockRecorder) WriteSchema(ctx, schema any) *MockschemaWriterWriteSchemaCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteSchema", reflect.TypeOf((*MockschemaWriter)(nil).WriteSchema), ctx, schema)
+	return &MockschemaWriterWriteSchemaCall{Call: call}
+}
+
+// MockschemaWriterWriteSchemaCall wrap *gomock.Call
+type MockschemaWriterWriteSchemaCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockschemaWriterWriteSchemaCall) Return(arg0 string, arg1 error) *MockschemaWriterWriteSchemaCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockschemaWriterWriteSchemaCall) Do(f func(context.Context, string) (string, error)) *MockschemaWriterWriteSchemaCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockschemaWriterWriteSchemaCall) DoAndReturn(f func(context.Context, string) (string, error)) *MockschemaWriterWriteSchemaCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
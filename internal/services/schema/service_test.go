@@ -0,0 +1,109 @@
+package schema_test
+
+import (
+	"testing"
+
+	"echo-app/internal/config"
+	"echo-app/internal/models"
+	"echo-app/internal/permify"
+	"echo-app/internal/services/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_Sync(t *testing.T) {
+	content, err := permify.Schema()
+	require.NoError(t, err)
+
+	hash := permify.SchemaHash(content)
+
+	newService := func(t *testing.T, allowDrift bool) (schema.Service, *MockschemaVersionRepository, *MockschemaWriter) {
+		t.Helper()
+
+		ctrl := gomock.NewController(t)
+		schemaVersionRepository := NewMockschemaVersionRepository(ctrl)
+		schemaWriter := NewMockschemaWriter(ctrl)
+		schemaService := schema.NewService(schemaVersionRepository, schemaWriter, config.Permify{
+			TenantID:         "t1",
+			AllowSchemaDrift: allowDrift,
+		})
+
+		return schemaService, schemaVersionRepository, schemaWriter
+	}
+
+	t.Run("It should reuse the active version of an unchanged schema", func(t *testing.T) {
+		schemaService, schemaVersionRepository, _ := newService(t, false)
+
+		schemaVersionRepository.
+			EXPECT().
+			Latest(gomock.Any(), "t1").
+			Return(models.SchemaVersion{TenantID: "t1", Hash: hash, Version: "v1"}, nil)
+
+		version, err := schemaService.Sync(t.Context())
+		require.NoError(t, err)
+
+		assert.Equal(t, "v1", version)
+	})
+
+	t.Run("It should write the schema of a new tenant", func(t *testing.T) {
+		schemaService, schemaVersionRepository, schemaWriter := newService(t, false)
+
+		schemaVersionRepository.
+			EXPECT().
+			Latest(gomock.Any(), "t1").
+			Return(models.SchemaVersion{}, models.ErrSchemaVersionNotFound)
+
+		schemaWriter.
+			EXPECT().
+			WriteSchema(gomock.Any(), content).
+			Return("v1", nil)
+
+		schemaVersionRepository.
+			EXPECT().
+			Create(gomock.Any(), &models.SchemaVersion{TenantID: "t1", Hash: hash, Version: "v1"}).
+			Return(nil)
+
+		version, err := schemaService.Sync(t.Context())
+		require.NoError(t, err)
+
+		assert.Equal(t, "v1", version)
+	})
+
+	t.Run("It should refuse a drifted schema", func(t *testing.T) {
+		schemaService, schemaVersionRepository, _ := newService(t, false)
+
+		schemaVersionRepository.
+			EXPECT().
+			Latest(gomock.Any(), "t1").
+			Return(models.SchemaVersion{TenantID: "t1", Hash: "other", Version: "v1"}, nil)
+
+		_, err := schemaService.Sync(t.Context())
+		assert.ErrorIs(t, err, schema.ErrSchemaDrift)
+	})
+
+	t.Run("It should write a drifted schema when allowed", func(t *testing.T) {
+		schemaService, schemaVersionRepository, schemaWriter := newService(t, true)
+
+		schemaVersionRepository.
+			EXPECT().
+			Latest(gomock.Any(), "t1").
+			Return(models.SchemaVersion{TenantID: "t1", Hash: "other", Version: "v1"}, nil)
+
+		schemaWriter.
+			EXPECT().
+			WriteSchema(gomock.Any(), content).
+			Return("v2", nil)
+
+		schemaVersionRepository.
+			EXPECT().
+			Create(gomock.Any(), &models.SchemaVersion{TenantID: "t1", Hash: hash, Version: "v2"}).
+			Return(nil)
+
+		version, err := schemaService.Sync(t.Context())
+		require.NoError(t, err)
+
+		assert.Equal(t, "v2", version)
+	})
+}
@@ -0,0 +1,56 @@
+package session
+
+import "strings"
+
+// The patterns are checked in order, the first match wins. More specific user agent tokens come first,
+// e.g. Chrome user agents contain "Safari" and Android ones "Linux".
+var (
+	browserPatterns = []struct{ token, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+	}
+	osPatterns = []struct{ token, name string }{
+		{"Android", "Android"},
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"CrOS", "ChromeOS"},
+		{"Linux", "Linux"},
+	}
+)
+
+// device describes the device of the user agent for the session list, e.g. "Firefox on Linux".
+func device(userAgent string) string {
+	browser := match(userAgent, browserPatterns)
+	system := match(userAgent, osPatterns)
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	}
+
+	// API clients like "curl/8.5.0" are named by their product
+	if product, _, _ := strings.Cut(userAgent, "/"); product != "" && len(product) <= 50 && !strings.Contains(product, " ") {
+		return product
+	}
+
+	return "Unknown device"
+}
+
+func match(userAgent string, patterns []struct{ token, name string }) string {
+	for _, pattern := range patterns {
+		if strings.Contains(userAgent, pattern.token) {
+			return pattern.name
+		}
+	}
+
+	return ""
+}
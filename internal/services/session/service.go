@@ -0,0 +1,210 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"echo-app/internal/config"
+	"echo-app/internal/models"
+	"echo-app/internal/services/refresh"
+
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+// touchInterval limits how often the activity of a session is written, a session used more often
+// keeps the last seen time of the first use in the interval.
+const touchInterval = time.Minute
+
+var ErrSessionRevoked = errors.New("session is revoked or expired")
+
+// Store persists the sessions, see repositories.SessionRepository and repositories.RedisSessionRepository.
+type Store interface {
+	Create(ctx context.Context, session *models.Session) error
+	Get(ctx context.Context, id uuid.UUID) (models.Session, error)
+	ListActive(ctx context.Context, userID uint, since time.Time) ([]models.Session, error)
+	Touch(ctx context.Context, id uuid.UUID, ip string, seenAt time.Time) error
+	Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+	RevokeUser(ctx context.Context, userID uint, revokedAt time.Time) error
+	RevokeOIDCSession(ctx context.Context, oidcProvider, oidcSessionID string, revokedAt time.Time) error
+}
+
+type refreshTokenService interface {
+	Issue(ctx context.Context, userID uint, familyID uuid.UUID, oidcProvider, oidcSessionID string) (refresh.Token, error)
+	Revoke(ctx context.Context, value string) (uuid.UUID, error)
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) error
+	RevokeUser(ctx context.Context, userID uint) error
+	RevokeOIDCSession(ctx context.Context, provider, sessionID string) error
+}
+
+// Client is the device a session is used from.
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+// Service keeps the login sessions. Each session has a refresh token family with its ID,
+// revoking the session revokes the family too.
+type Service struct {
+	store         Store
+	refreshTokens refreshTokenService
+	idleTTL       time.Duration
+	now           func() time.Time
+}
+
+func NewService(store Store, refreshTokens refreshTokenService, cfg config.Session) *Service {
+	return &Service{
+		store:         store,
+		refreshTokens: refreshTokens,
+		idleTTL:       cfg.IdleTTL,
+		now:           time.Now,
+	}
+}
+
+// Start starts a session of the user on the client and issues the refresh token of it.
+// Logins with OIDC keep the identity provider and its session ID, so back-channel logout can revoke the session.
+func (s *Service) Start(
+	ctx context.Context,
+	userID uint,
+	client Client,
+	oidcProvider, oidcSessionID string,
+) (models.Session, refresh.Token, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return models.Session{}, refresh.Token{}, fmt.Errorf("new session id: %w", err)
+	}
+
+	now := s.now()
+
+	session := &models.Session{
+		ID:            id,
+		UserID:        userID,
+		Device:        device(client.UserAgent),
+		IP:            client.IP,
+		UserAgent:     client.UserAgent,
+		OIDCProvider:  oidcProvider,
+		OIDCSessionID: oidcSessionID,
+		CreatedAt:     now,
+		LastSeenAt:    now,
+	}
+
+	if err := s.store.Create(ctx, session); err != nil {
+		return models.Session{}, refresh.Token{}, fmt.Errorf("create session in store: %w", err)
+	}
+
+	refreshToken, err := s.refreshTokens.Issue(ctx, userID, id, oidcProvider, oidcSessionID)
+	if err != nil {
+		err = fmt.Errorf("issue refresh token: %w", err)
+
+		// The session has no refresh token, don't leave it active
+		if revokeErr := s.store.Revoke(ctx, id, s.now()); revokeErr != nil {
+			err = errors.Join(err, fmt.Errorf("revoke session in store: %w", revokeErr))
+		}
+
+		return models.Session{}, refresh.Token{}, err
+	}
+
+	return *session, refreshToken, nil
+}
+
+// Validate checks the session of the user is still active and records it was used by the client.
+func (s *Service) Validate(ctx context.Context, id uuid.UUID, userID uint, client Client) (models.Session, error) {
+	session, err := s.store.Get(ctx, id)
+	if errors.Is(err, models.ErrSessionNotFound) {
+		return models.Session{}, ErrSessionRevoked
+	} else if err != nil {
+		return models.Session{}, fmt.Errorf("get session from store: %w", err)
+	}
+
+	now := s.now()
+
+	if session.UserID != userID || session.RevokedAt != nil || !now.Before(session.LastSeenAt.Add(s.idleTTL)) {
+		return models.Session{}, ErrSessionRevoked
+	}
+
+	if now.Sub(session.LastSeenAt) >= touchInterval || session.IP != client.IP {
+		if err := s.store.Touch(ctx, id, client.IP, now); err != nil {
+			return models.Session{}, fmt.Errorf("touch session in store: %w", err)
+		}
+
+		session.IP, session.LastSeenAt = client.IP, now
+	}
+
+	return session, nil
+}
+
+// List returns the active sessions of the user, the last used first.
+func (s *Service) List(ctx context.Context, userID uint) ([]models.Session, error) {
+	sessions, err := s.store.ListActive(ctx, userID, s.now().Add(-s.idleTTL))
+	if err != nil {
+		return nil, fmt.Errorf("list active sessions from store: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Revoke revokes the session of the user.
+func (s *Service) Revoke(ctx context.Context, userID uint, id uuid.UUID) error {
+	session, err := s.store.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get session from store: %w", err)
+	}
+
+	// Sessions of other users don't exist for the user
+	if session.UserID != userID {
+		return models.ErrSessionNotFound
+	}
+
+	if err := s.store.Revoke(ctx, id, s.now()); err != nil {
+		return fmt.Errorf("revoke session in store: %w", err)
+	}
+
+	if err := s.refreshTokens.RevokeFamily(ctx, id); err != nil {
+		return fmt.Errorf("revoke session refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+// Logout revokes the session of the refresh token.
+func (s *Service) Logout(ctx context.Context, refreshToken string) error {
+	id, err := s.refreshTokens.Revoke(ctx, refreshToken)
+	if err != nil {
+		return fmt.Errorf("revoke refresh token: %w", err)
+	}
+
+	if err := s.store.Revoke(ctx, id, s.now()); err != nil {
+		return fmt.Errorf("revoke session in store: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeUser revokes every session of the user.
+func (s *Service) RevokeUser(ctx context.Context, userID uint) error {
+	if err := s.store.RevokeUser(ctx, userID, s.now()); err != nil {
+		return fmt.Errorf("revoke user sessions in store: %w", err)
+	}
+
+	if err := s.refreshTokens.RevokeUser(ctx, userID); err != nil {
+		return fmt.Errorf("revoke user refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeOIDCSession revokes the sessions started in the session of the identity provider.
+func (s *Service) RevokeOIDCSession(ctx context.Context, oidcProvider, oidcSessionID string) error {
+	if err := s.store.RevokeOIDCSession(ctx, oidcProvider, oidcSessionID, s.now()); err != nil {
+		return fmt.Errorf("revoke OIDC session sessions in store: %w", err)
+	}
+
+	if err := s.refreshTokens.RevokeOIDCSession(ctx, oidcProvider, oidcSessionID); err != nil {
+		return fmt.Errorf("revoke OIDC session refresh tokens: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,529 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=session_test -typed=true
+//
+
+// Package session_test is a generated GoMock package.
+package session_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "echo-app/internal/models"
+	refresh "echo-app/internal/services/refresh"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockStore) Create(ctx context.Context, session *models.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStoreMockRecorder) Create(ctx, session any) *MockStoreCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStore)(nil).Create), ctx, session)
+	return &MockStoreCreateCall{Call: call}
+}
+
+// MockStoreCreateCall wrap *gomock.Call
+type MockStoreCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStoreCreateCall) Return(arg0 error) *MockStoreCreateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStoreCreateCall) Do(f func(context.Context, *models.Session) error) *MockStoreCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStoreCreateCall) DoAndReturn(f func(context.Context, *models.Session) error) *MockStoreCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Get mocks base method.
+func (m *MockStore) Get(ctx context.Context, id uuid.UUID) (models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStoreMockRecorder) Get(ctx, id any) *MockStoreGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStore)(nil).Get), ctx, id)
+	return &MockStoreGetCall{Call: call}
+}
+
+// MockStoreGetCall wrap *gomock.Call
+type MockStoreGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStoreGetCall) Return(arg0 models.Session, arg1 error) *MockStoreGetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStoreGetCall) Do(f func(context.Context, uuid.UUID) (models.Session, error)) *MockStoreGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStoreGetCall) DoAndReturn(f func(context.Context, uuid.UUID) (models.Session, error)) *MockStoreGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListActive mocks base method.
+func (m *MockStore) ListActive(ctx context.Context, userID uint, since time.Time) ([]models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActive", ctx, userID, since)
+	ret0, _ := ret[0].([]models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActive indicates an expected call of ListActive.
+func (mr *MockStoreMockRecorder) ListActive(ctx, userID, since any) *MockStoreListActiveCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActive", reflect.TypeOf((*MockStore)(nil).ListActive), ctx, userID, since)
+	return &MockStoreListActiveCall{Call: call}
+}
+
+// MockStoreListActiveCall wrap *gomock.Call
+type MockStoreListActiveCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStoreListActiveCall) Return(arg0 []models.Session, arg1 error) *MockStoreListActiveCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStoreListActiveCall) Do(f func(context.Context, uint, time.Time) ([]models.Session, error)) *MockStoreListActiveCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStoreListActiveCall) DoAndReturn(f func(context.Context, uint, time.Time) ([]models.Session, error)) *MockStoreListActiveCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MockStore) Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockStoreMockRecorder) Revoke(ctx, id, revokedAt any) *MockStoreRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockStore)(nil).Revoke), ctx, id, revokedAt)
+	return &MockStoreRevokeCall{Call: call}
+}
+
+// MockStoreRevokeCall wrap *gomock.Call
+type MockStoreRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStoreRevokeCall) Return(arg0 error) *MockStoreRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStoreRevokeCall) Do(f func(context.Context, uuid.UUID, time.Time) error) *MockStoreRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStoreRevokeCall) DoAndReturn(f func(context.Context, uuid.UUID, time.Time) error) *MockStoreRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeOIDCSession mocks base method.
+func (m *MockStore) RevokeOIDCSession(ctx context.Context, oidcProvider, oidcSessionID string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOIDCSession", ctx, oidcProvider, oidcSessionID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOIDCSession indicates an expected call of RevokeOIDCSession.
+func (mr *MockStoreMockRecorder) RevokeOIDCSession(ctx, oidcProvider, oidcSessionID, revokedAt any) *MockStoreRevokeOIDCSessionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOIDCSession", reflect.TypeOf((*MockStore)(nil).RevokeOIDCSession), ctx, oidcProvider, oidcSessionID, revokedAt)
+	return &MockStoreRevokeOIDCSessionCall{Call: call}
+}
+
+// MockStoreRevokeOIDCSessionCall wrap *gomock.Call
+type MockStoreRevokeOIDCSessionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStoreRevokeOIDCSessionCall) Return(arg0 error) *MockStoreRevokeOIDCSessionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStoreRevokeOIDCSessionCall) Do(f func(context.Context, string, string, time.Time) error) *MockStoreRevokeOIDCSessionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStoreRevokeOIDCSessionCall) DoAndReturn(f func(context.Context, string, string, time.Time) error) *MockStoreRevokeOIDCSessionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeUser mocks base method.
+func (m *MockStore) RevokeUser(ctx context.Context, userID uint, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUser", ctx, userID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUser indicates an expected call of RevokeUser.
+func (mr *MockStoreMockRecorder) RevokeUser(ctx, userID, revokedAt any) *MockStoreRevokeUserCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUser", reflect.TypeOf((*MockStore)(nil).RevokeUser), ctx, userID, revokedAt)
+	return &MockStoreRevokeUserCall{Call: call}
+}
+
+// MockStoreRevokeUserCall wrap *gomock.Call
+type MockStoreRevokeUserCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStoreRevokeUserCall) Return(arg0 error) *MockStoreRevokeUserCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStoreRevokeUserCall) Do(f func(context.Context, uint, time.Time) error) *MockStoreRevokeUserCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStoreRevokeUserCall) DoAndReturn(f func(context.Context, uint, time.Time) error) *MockStoreRevokeUserCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Touch mocks base method.
+func (m *MockStore) Touch(ctx context.Context, id uuid.UUID, ip string, seenAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, id, ip, seenAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockStoreMockRecorder) Touch(ctx, id, ip, seenAt any) *MockStoreTouchCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockStore)(nil).Touch), ctx, id, ip, seenAt)
+	return &MockStoreTouchCall{Call: call}
+}
+
+// MockStoreTouchCall wrap *gomock.Call
+type MockStoreTouchCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStoreTouchCall) Return(arg0 error) *MockStoreTouchCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStoreTouchCall) Do(f func(context.Context, uuid.UUID, string, time.Time) error) *MockStoreTouchCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStoreTouchCall) DoAndReturn(f func(context.Context, uuid.UUID, string, time.Time) error) *MockStoreTouchCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockrefreshTokenService is a mock of refreshTokenService interface.
+type MockrefreshTokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockrefreshTokenServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockrefreshTokenServiceMockRecorder is the mock recorder for MockrefreshTokenService.
+type MockrefreshTokenServiceMockRecorder struct {
+	mock *MockrefreshTokenService
+}
+
+// NewMockrefreshTokenService creates a new mock instance.
+func NewMockrefreshTokenService(ctrl *gomock.Controller) *MockrefreshTokenService {
+	mock := &MockrefreshTokenService{ctrl: ctrl}
+	mock.recorder = &MockrefreshTokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrefreshTokenService) EXPECT() *MockrefreshTokenServiceMockRecorder {
+	return m.recorder
+}
+
+// Issue mocks base method.
+func (m *MockrefreshTokenService) Issue(ctx context.Context, userID uint, familyID uuid.UUID, oidcProvider, oidcSessionID string) (refresh.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", ctx, userID, familyID, oidcProvider, oidcSessionID)
+	ret0, _ := ret[0].(refresh.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Issue indicates an expected call of Issue.
+func (mr *MockrefreshTokenServiceMockRecorder) Issue(ctx, userID, familyID, oidcProvider, oidcSessionID any) *MockrefreshTokenServiceIssueCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockrefreshTokenService)(nil).Issue), ctx, userID, familyID, oidcProvider, oidcSessionID)
+	return &MockrefreshTokenServiceIssueCall{Call: call}
+}
+
+// MockrefreshTokenServiceIssueCall wrap *gomock.Call
+type MockrefreshTokenServiceIssueCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrefreshTokenServiceIssueCall) Return(arg0 refresh.Token, arg1 error) *MockrefreshTokenServiceIssueCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrefreshTokenServiceIssueCall) Do(f func(context.Context, uint, uuid.UUID, string, string) (refresh.Token, error)) *MockrefreshTokenServiceIssueCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrefreshTokenServiceIssueCall) DoAndReturn(f func(context.Context, uint, uuid.UUID, string, string) (refresh.Token, error)) *MockrefreshTokenServiceIssueCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MockrefreshTokenService) Revoke(ctx context.Context, value string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, value)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockrefreshTokenServiceMockRecorder) Revoke(ctx, value any) *MockrefreshTokenServiceRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockrefreshTokenService)(nil).Revoke), ctx, value)
+	return &MockrefreshTokenServiceRevokeCall{Call: call}
+}
+
+// MockrefreshTokenServiceRevokeCall wrap *gomock.Call
+type MockrefreshTokenServiceRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrefreshTokenServiceRevokeCall) Return(arg0 uuid.UUID, arg1 error) *MockrefreshTokenServiceRevokeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrefreshTokenServiceRevokeCall) Do(f func(context.Context, string) (uuid.UUID, error)) *MockrefreshTokenServiceRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrefreshTokenServiceRevokeCall) DoAndReturn(f func(context.Context, string) (uuid.UUID, error)) *MockrefreshTokenServiceRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeFamily mocks base method.
+func (m *MockrefreshTokenService) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamily", ctx, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFamily indicates an expected call of RevokeFamily.
+func (mr *MockrefreshTokenServiceMockRecorder) RevokeFamily(ctx, familyID any) *MockrefreshTokenServiceRevokeFamilyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamily", reflect.TypeOf((*MockrefreshTokenService)(nil).RevokeFamily), ctx, familyID)
+	return &MockrefreshTokenServiceRevokeFamilyCall{Call: call}
+}
+
+// MockrefreshTokenServiceRevokeFamilyCall wrap *gomock.Call
+type MockrefreshTokenServiceRevokeFamilyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrefreshTokenServiceRevokeFamilyCall) Return(arg0 error) *MockrefreshTokenServiceRevokeFamilyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrefreshTokenServiceRevokeFamilyCall) Do(f func(context.Context, uuid.UUID) error) *MockrefreshTokenServiceRevokeFamilyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrefreshTokenServiceRevokeFamilyCall) DoAndReturn(f func(context.Context, uuid.UUID) error) *MockrefreshTokenServiceRevokeFamilyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeOIDCSession mocks base method.
+func (m *MockrefreshTokenService) RevokeOIDCSession(ctx context.Context, provider, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOIDCSession", ctx, provider, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOIDCSession indicates an expected call of RevokeOIDCSession.
+func (mr *MockrefreshTokenServiceMockRecorder) RevokeOIDCSession(ctx, provider, sessionID any) *MockrefreshTokenServiceRevokeOIDCSessionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOIDCSession", reflect.TypeOf((*MockrefreshTokenService)(nil).RevokeOIDCSession), ctx, provider, sessionID)
+	return &MockrefreshTokenServiceRevokeOIDCSessionCall{Call: call}
+}
+
+// MockrefreshTokenServiceRevokeOIDCSessionCall wrap *gomock.Call
+type MockrefreshTokenServiceRevokeOIDCSessionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrefreshTokenServiceRevokeOIDCSessionCall) Return(arg0 error) *MockrefreshTokenServiceRevokeOIDCSessionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrefreshTokenServiceRevokeOIDCSessionCall) Do(f func(context.Context, string, string) error) *MockrefreshTokenServiceRevokeOIDCSessionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrefreshTokenServiceRevokeOIDCSessionCall) DoAndReturn(f func(context.Context, string, string) error) *MockrefreshTokenServiceRevokeOIDCSessionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeUser mocks base method.
+func (m *MockrefreshTokenService) RevokeUser(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUser indicates an expected call of RevokeUser.
+func (mr *MockrefreshTokenServiceMockRecorder) RevokeUser(ctx, userID any) *MockrefreshTokenServiceRevokeUserCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUser", reflect.TypeOf((*MockrefreshTokenService)(nil).RevokeUser), ctx, userID)
+	return &MockrefreshTokenServiceRevokeUserCall{Call: call}
+}
+
+// MockrefreshTokenServiceRevokeUserCall wrap *gomock.Call
+type MockrefreshTokenServiceRevokeUserCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrefreshTokenServiceRevokeUserCall) Return(arg0 error) *MockrefreshTokenServiceRevokeUserCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrefreshTokenServiceRevokeUserCall) Do(f func(context.Context, uint) error) *MockrefreshTokenServiceRevokeUserCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrefreshTokenServiceRevokeUserCall) DoAndReturn(f func(context.Context, uint) error) *MockrefreshTokenServiceRevokeUserCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
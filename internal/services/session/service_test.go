@@ -0,0 +1,267 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"echo-app/internal/config"
+	"echo-app/internal/models"
+	"echo-app/internal/services/refresh"
+	"echo-app/internal/services/session"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var sessionID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+
+func newService(t *testing.T) (*session.Service, *MockStore, *MockrefreshTokenService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	store := NewMockStore(ctrl)
+	refreshTokens := NewMockrefreshTokenService(ctrl)
+
+	return session.NewService(store, refreshTokens, config.Session{IdleTTL: time.Hour}), store, refreshTokens
+}
+
+func TestService_Start(t *testing.T) {
+	userAgents := map[string]string{
+		"Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0":                                                                  "Firefox on Linux",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36 Edg/129.0.0.0":           "Edge on Windows",
+		"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Mobile Safari/537.36":                   "Chrome on Android",
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 18_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/18.0 Mobile/15E148 Safari/604.1": "Safari on iOS",
+		"curl/8.5.0": "curl",
+		"":           "Unknown device",
+	}
+
+	for userAgent, wantDevice := range userAgents {
+		t.Run("It should start a session on "+wantDevice, func(t *testing.T) {
+			sessionService, store, refreshTokens := newService(t)
+
+			var created models.Session
+
+			store.
+				EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, got *models.Session) error {
+					created = *got
+					return nil
+				})
+
+			refreshTokens.
+				EXPECT().
+				Issue(gomock.Any(), uint(7), gomock.Any(), "google", "oidc-session").
+				DoAndReturn(func(_ context.Context, _ uint, familyID uuid.UUID, _, _ string) (refresh.Token, error) {
+					assert.Equal(t, created.ID, familyID)
+					return refresh.Token{Value: "refresh-token"}, nil
+				})
+
+			client := session.Client{IP: "203.0.113.7", UserAgent: userAgent}
+
+			gotSession, refreshToken, err := sessionService.Start(t.Context(), 7, client, "google", "oidc-session")
+			require.NoError(t, err)
+
+			assert.Equal(t, created, gotSession)
+			assert.Equal(t, wantDevice, gotSession.Device)
+			assert.Equal(t, "203.0.113.7", gotSession.IP)
+			assert.Equal(t, "google", gotSession.OIDCProvider)
+			assert.Equal(t, "oidc-session", gotSession.OIDCSessionID)
+			assert.Equal(t, "refresh-token", refreshToken.Value)
+		})
+	}
+
+	t.Run("It should revoke the session if the refresh token can't be issued", func(t *testing.T) {
+		sessionService, store, refreshTokens := newService(t)
+
+		var created models.Session
+
+		store.
+			EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, got *models.Session) error {
+				created = *got
+				return nil
+			})
+
+		refreshTokens.
+			EXPECT().
+			Issue(gomock.Any(), uint(7), gomock.Any(), "", "").
+			Return(refresh.Token{}, errors.New("insert failed"))
+
+		store.
+			EXPECT().
+			Revoke(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, id uuid.UUID, _ time.Time) error {
+				assert.Equal(t, created.ID, id)
+				return nil
+			})
+
+		_, _, err := sessionService.Start(t.Context(), 7, session.Client{IP: "203.0.113.7"}, "", "")
+		require.ErrorContains(t, err, "insert failed")
+	})
+}
+
+func TestService_Validate(t *testing.T) {
+	client := session.Client{IP: "203.0.113.7"}
+
+	t.Run("It should accept an active session without touching it again", func(t *testing.T) {
+		sessionService, store, _ := newService(t)
+
+		stored := models.Session{ID: sessionID, UserID: 7, IP: "203.0.113.7", LastSeenAt: time.Now().Add(-time.Second)}
+
+		store.
+			EXPECT().
+			Get(gomock.Any(), sessionID).
+			Return(stored, nil)
+
+		gotSession, err := sessionService.Validate(t.Context(), sessionID, 7, client)
+		require.NoError(t, err)
+
+		assert.Equal(t, stored, gotSession)
+	})
+
+	t.Run("It should record the activity of a session", func(t *testing.T) {
+		sessionService, store, _ := newService(t)
+
+		store.
+			EXPECT().
+			Get(gomock.Any(), sessionID).
+			Return(models.Session{ID: sessionID, UserID: 7, IP: "198.51.100.1", LastSeenAt: time.Now().Add(-time.Second)}, nil)
+
+		store.
+			EXPECT().
+			Touch(gomock.Any(), sessionID, "203.0.113.7", gomock.Any()).
+			Return(nil)
+
+		gotSession, err := sessionService.Validate(t.Context(), sessionID, 7, client)
+		require.NoError(t, err)
+
+		assert.Equal(t, "203.0.113.7", gotSession.IP)
+	})
+
+	revokedAt := time.Now()
+
+	rejected := map[string]models.Session{
+		"revoked":         {ID: sessionID, UserID: 7, LastSeenAt: time.Now(), RevokedAt: &revokedAt},
+		"idle":            {ID: sessionID, UserID: 7, LastSeenAt: time.Now().Add(-2 * time.Hour)},
+		"of another user": {ID: sessionID, UserID: 8, LastSeenAt: time.Now()},
+	}
+
+	for name, stored := range rejected {
+		t.Run("It should reject a session "+name, func(t *testing.T) {
+			sessionService, store, _ := newService(t)
+
+			store.
+				EXPECT().
+				Get(gomock.Any(), sessionID).
+				Return(stored, nil)
+
+			_, err := sessionService.Validate(t.Context(), sessionID, 7, client)
+			assert.ErrorIs(t, err, session.ErrSessionRevoked)
+		})
+	}
+
+	t.Run("It should reject an unknown session", func(t *testing.T) {
+		sessionService, store, _ := newService(t)
+
+		store.
+			EXPECT().
+			Get(gomock.Any(), sessionID).
+			Return(models.Session{}, models.ErrSessionNotFound)
+
+		_, err := sessionService.Validate(t.Context(), sessionID, 7, client)
+		assert.ErrorIs(t, err, session.ErrSessionRevoked)
+	})
+}
+
+func TestService_Revoke(t *testing.T) {
+	t.Run("It should revoke the session with its refresh tokens", func(t *testing.T) {
+		sessionService, store, refreshTokens := newService(t)
+
+		store.
+			EXPECT().
+			Get(gomock.Any(), sessionID).
+			Return(models.Session{ID: sessionID, UserID: 7}, nil)
+
+		store.
+			EXPECT().
+			Revoke(gomock.Any(), sessionID, gomock.Any()).
+			Return(nil)
+
+		refreshTokens.
+			EXPECT().
+			RevokeFamily(gomock.Any(), sessionID).
+			Return(nil)
+
+		err := sessionService.Revoke(t.Context(), 7, sessionID)
+		require.NoError(t, err)
+	})
+
+	t.Run("It should not revoke the session of another user", func(t *testing.T) {
+		sessionService, store, _ := newService(t)
+
+		store.
+			EXPECT().
+			Get(gomock.Any(), sessionID).
+			Return(models.Session{ID: sessionID, UserID: 8}, nil)
+
+		err := sessionService.Revoke(t.Context(), 7, sessionID)
+		assert.ErrorIs(t, err, models.ErrSessionNotFound)
+	})
+}
+
+func TestService_Logout(t *testing.T) {
+	sessionService, store, refreshTokens := newService(t)
+
+	refreshTokens.
+		EXPECT().
+		Revoke(gomock.Any(), "refresh-token").
+		Return(sessionID, nil)
+
+	store.
+		EXPECT().
+		Revoke(gomock.Any(), sessionID, gomock.Any()).
+		Return(nil)
+
+	err := sessionService.Logout(t.Context(), "refresh-token")
+	require.NoError(t, err)
+}
+
+func TestService_RevokeUser(t *testing.T) {
+	sessionService, store, refreshTokens := newService(t)
+
+	store.
+		EXPECT().
+		RevokeUser(gomock.Any(), uint(7), gomock.Any()).
+		Return(nil)
+
+	refreshTokens.
+		EXPECT().
+		RevokeUser(gomock.Any(), uint(7)).
+		Return(nil)
+
+	err := sessionService.RevokeUser(t.Context(), 7)
+	require.NoError(t, err)
+}
+
+func TestService_RevokeOIDCSession(t *testing.T) {
+	sessionService, store, refreshTokens := newService(t)
+
+	store.
+		EXPECT().
+		RevokeOIDCSession(gomock.Any(), "google", "oidc-session", gomock.Any()).
+		Return(nil)
+
+	refreshTokens.
+		EXPECT().
+		RevokeOIDCSession(gomock.Any(), "google", "oidc-session").
+		Return(nil)
+
+	err := sessionService.RevokeOIDCSession(t.Context(), "google", "oidc-session")
+	require.NoError(t, err)
+}
@@ -0,0 +1,297 @@
+package token
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"echo-app/internal/config"
+	"echo-app/internal/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// Token types put into the "typ" header, so a token issued for one purpose
+// can't be used for another.
+const (
+	accessTokenType            = "at+jwt"
+	emailVerificationTokenType = "email-verification+jwt"
+	mfaTokenType               = "mfa+jwt"
+)
+
+var (
+	ErrUnsupportedSigningMethod = errors.New("unsupported signing method")
+	ErrUnknownKeyID             = errors.New("unknown key id")
+	ErrUnexpectedTokenType      = errors.New("unexpected token type")
+)
+
+// Claims are the claims carried by access tokens issued by the service.
+// SessionID is the login session the token was issued in, the token is rejected once the session is revoked.
+type Claims struct {
+	UserID    uint   `json:"uid"`
+	Email     string `json:"email"`
+	DomainID  string `json:"domain_id,omitempty"`
+	SessionID string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// VerificationClaims are the claims of one-time email verification tokens.
+// The token ID must match the one stored on the user.
+type VerificationClaims struct {
+	UserID uint   `json:"uid"`
+	Email  string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// MFAClaims are the claims of the challenge tokens of password logins waiting for the second factor.
+type MFAClaims struct {
+	UserID uint `json:"uid"`
+	jwt.RegisteredClaims
+}
+
+type Service struct {
+	method          jwt.SigningMethod
+	keyID           string
+	signingKey      any
+	verifyingKeys   map[string]any
+	issuer          string
+	accessTokenTTL  time.Duration
+	verificationTTL time.Duration
+	mfaTTL          time.Duration
+	now             func() time.Time
+}
+
+func NewService(cfg config.JWT) (*Service, error) {
+	method := jwt.GetSigningMethod(cfg.SigningMethod)
+
+	var (
+		signingKey   any
+		verifyingKey any
+	)
+
+	switch method {
+	case jwt.SigningMethodHS256:
+		if cfg.Secret == "" {
+			return nil, errors.New("secret is required for HS256 signing method")
+		}
+
+		signingKey = []byte(cfg.Secret)
+		verifyingKey = signingKey
+	case jwt.SigningMethodRS256, jwt.SigningMethodEdDSA:
+		privateKey, err := loadPrivateKey(method, cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load private key: %w", err)
+		}
+
+		signingKey = privateKey
+		verifyingKey = privateKey.Public()
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedSigningMethod, cfg.SigningMethod)
+	}
+
+	return &Service{
+		method:          method,
+		keyID:           cfg.KeyID,
+		signingKey:      signingKey,
+		verifyingKeys:   map[string]any{cfg.KeyID: verifyingKey},
+		issuer:          cfg.Issuer,
+		accessTokenTTL:  cfg.AccessTokenTTL,
+		verificationTTL: cfg.EmailVerificationTTL,
+		mfaTTL:          cfg.MFATokenTTL,
+		now:             time.Now,
+	}, nil
+}
+
+// CreateAccessToken issues a signed access token for the user in the session and returns it together with its expiration time.
+func (s *Service) CreateAccessToken(user *models.User, sessionID uuid.UUID) (string, time.Time, error) {
+	now := s.now()
+	expiresAt := now.Add(s.accessTokenTTL)
+
+	claims := &Claims{
+		UserID:    user.ID,
+		Email:     user.Email,
+		SessionID: sessionID.String(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer,
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	if user.DomainID != nil {
+		claims.DomainID = user.DomainID.String()
+	}
+
+	signed, err := s.sign(accessTokenType, claims)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("sign access token: %w", err)
+	}
+
+	return signed, expiresAt, nil
+}
+
+// ParseAccessToken verifies the signature, issuer and lifetime of the raw token and returns its claims.
+func (s *Service) ParseAccessToken(raw string) (*Claims, error) {
+	claims := new(Claims)
+	if err := s.parse(raw, accessTokenType, claims); err != nil {
+		return nil, fmt.Errorf("parse access token: %w", err)
+	}
+
+	return claims, nil
+}
+
+// CreateEmailVerificationToken issues a verification token bound to the user's current verification token ID.
+func (s *Service) CreateEmailVerificationToken(user *models.User) (string, error) {
+	now := s.now()
+
+	claims := &VerificationClaims{
+		UserID: user.ID,
+		Email:  user.Email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        user.VerificationTokenID,
+			Issuer:    s.issuer,
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.verificationTTL)),
+		},
+	}
+
+	signed, err := s.sign(emailVerificationTokenType, claims)
+	if err != nil {
+		return "", fmt.Errorf("sign email verification token: %w", err)
+	}
+
+	return signed, nil
+}
+
+func (s *Service) ParseEmailVerificationToken(raw string) (*VerificationClaims, error) {
+	claims := new(VerificationClaims)
+	if err := s.parse(raw, emailVerificationTokenType, claims); err != nil {
+		return nil, fmt.Errorf("parse email verification token: %w", err)
+	}
+
+	return claims, nil
+}
+
+// CreateMFAToken issues the challenge token of a password login of the user, which still needs the second factor.
+// It can't be used as an access token.
+func (s *Service) CreateMFAToken(user *models.User) (string, time.Time, error) {
+	now := s.now()
+	expiresAt := now.Add(s.mfaTTL)
+
+	claims := &MFAClaims{
+		UserID: user.ID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer,
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	signed, err := s.sign(mfaTokenType, claims)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("sign MFA token: %w", err)
+	}
+
+	return signed, expiresAt, nil
+}
+
+func (s *Service) ParseMFAToken(raw string) (*MFAClaims, error) {
+	claims := new(MFAClaims)
+	if err := s.parse(raw, mfaTokenType, claims); err != nil {
+		return nil, fmt.Errorf("parse MFA token: %w", err)
+	}
+
+	return claims, nil
+}
+
+// KeyFunc selects the verification key by the token "kid" header.
+func (s *Service) KeyFunc(token *jwt.Token) (any, error) {
+	if token.Method.Alg() != s.method.Alg() {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedSigningMethod, token.Method.Alg())
+	}
+
+	keyID, _ := token.Header["kid"].(string)
+
+	key, ok := s.verifyingKeys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, keyID)
+	}
+
+	return key, nil
+}
+
+func (s *Service) sign(tokenType string, claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.method, claims)
+	token.Header["typ"] = tokenType
+	if s.keyID != "" {
+		token.Header["kid"] = s.keyID
+	}
+
+	signed, err := token.SignedString(s.signingKey)
+	if err != nil {
+		return "", fmt.Errorf("sign token: %w", err)
+	}
+
+	return signed, nil
+}
+
+func (s *Service) parse(raw, tokenType string, claims jwt.Claims) error {
+	token, err := jwt.ParseWithClaims(
+		raw,
+		claims,
+		s.KeyFunc,
+		jwt.WithValidMethods([]string{s.method.Alg()}),
+		jwt.WithIssuer(s.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(s.now),
+	)
+	if err != nil {
+		return fmt.Errorf("parse token: %w", err)
+	}
+
+	if typ, _ := token.Header["typ"].(string); typ != tokenType {
+		return fmt.Errorf("%w: %q", ErrUnexpectedTokenType, typ)
+	}
+
+	return nil
+}
+
+func loadPrivateKey(method jwt.SigningMethod, path string) (crypto.Signer, error) {
+	if path == "" {
+		return nil, fmt.Errorf("private key file is required for %s signing method", method.Alg())
+	}
+
+	pemData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read private key file: %w", err)
+	}
+
+	if method == jwt.SigningMethodRS256 {
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(pemData)
+		if err != nil {
+			return nil, fmt.Errorf("parse RSA private key: %w", err)
+		}
+
+		return key, nil
+	}
+
+	key, err := jwt.ParseEdPrivateKeyFromPEM(pemData)
+	if err != nil {
+		return nil, fmt.Errorf("parse Ed25519 private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("Ed25519 private key is not a signer")
+	}
+
+	return signer, nil
+}
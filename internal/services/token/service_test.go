@@ -0,0 +1,238 @@
+package token_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"echo-app/internal/config"
+	"echo-app/internal/models"
+	"echo-app/internal/services/token"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func writePrivateKey(t *testing.T, key any) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+	err = os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+	require.NoError(t, err)
+
+	return path
+}
+
+func TestService_CreateAccessToken(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	domainID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	sessionID := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	user := &models.User{
+		Model:    gorm.Model{ID: 42},
+		Email:    "example@email.com",
+		DomainID: &domainID,
+	}
+
+	configs := map[string]config.JWT{
+		"HS256": {SigningMethod: "HS256", Secret: "secret", KeyID: "hs-1"},
+		"RS256": {SigningMethod: "RS256", PrivateKeyFile: writePrivateKey(t, rsaKey), KeyID: "rs-1"},
+		"EdDSA": {SigningMethod: "EdDSA", PrivateKeyFile: writePrivateKey(t, edKey)},
+	}
+
+	for name, cfg := range configs {
+		t.Run("It should issue a verifiable token signed with "+name, func(t *testing.T) {
+			cfg.Issuer = "echo-app"
+			cfg.AccessTokenTTL = time.Minute
+
+			tokenService, err := token.NewService(cfg)
+			require.NoError(t, err)
+
+			rawToken, expiresAt, err := tokenService.CreateAccessToken(user, sessionID)
+			require.NoError(t, err)
+			assert.WithinDuration(t, time.Now().Add(time.Minute), expiresAt, 5*time.Second)
+
+			claims, err := tokenService.ParseAccessToken(rawToken)
+			require.NoError(t, err)
+
+			assert.Equal(t, uint(42), claims.UserID)
+			assert.Equal(t, "example@email.com", claims.Email)
+			assert.Equal(t, domainID.String(), claims.DomainID)
+			assert.Equal(t, "42", claims.Subject)
+			assert.Equal(t, sessionID.String(), claims.SessionID)
+		})
+	}
+}
+
+func TestService_ParseAccessToken(t *testing.T) {
+	cfg := config.JWT{
+		SigningMethod:  "HS256",
+		Secret:         "secret",
+		KeyID:          "hs-1",
+		Issuer:         "echo-app",
+		AccessTokenTTL: time.Minute,
+	}
+
+	tokenService, err := token.NewService(cfg)
+	require.NoError(t, err)
+
+	t.Run("It should reject a token signed with another key", func(t *testing.T) {
+		otherConfig := cfg
+		otherConfig.Secret = "another-secret"
+
+		otherService, err := token.NewService(otherConfig)
+		require.NoError(t, err)
+
+		rawToken, _, err := otherService.CreateAccessToken(&models.User{}, uuid.New())
+		require.NoError(t, err)
+
+		_, err = tokenService.ParseAccessToken(rawToken)
+		assert.ErrorIs(t, err, jwt.ErrSignatureInvalid)
+	})
+
+	t.Run("It should reject a token with an unknown key id", func(t *testing.T) {
+		otherConfig := cfg
+		otherConfig.KeyID = "hs-2"
+
+		otherService, err := token.NewService(otherConfig)
+		require.NoError(t, err)
+
+		rawToken, _, err := otherService.CreateAccessToken(&models.User{}, uuid.New())
+		require.NoError(t, err)
+
+		_, err = tokenService.ParseAccessToken(rawToken)
+		assert.ErrorIs(t, err, token.ErrUnknownKeyID)
+	})
+
+	t.Run("It should reject an expired token", func(t *testing.T) {
+		otherConfig := cfg
+		otherConfig.AccessTokenTTL = -time.Minute
+
+		otherService, err := token.NewService(otherConfig)
+		require.NoError(t, err)
+
+		rawToken, _, err := otherService.CreateAccessToken(&models.User{}, uuid.New())
+		require.NoError(t, err)
+
+		_, err = tokenService.ParseAccessToken(rawToken)
+		assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+	})
+
+	t.Run("It should reject the none algorithm", func(t *testing.T) {
+		rawToken, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{"iss": "echo-app"}).
+			SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		_, err = tokenService.ParseAccessToken(rawToken)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewService(t *testing.T) {
+	t.Run("It should fail on unsupported signing method", func(t *testing.T) {
+		_, err := token.NewService(config.JWT{SigningMethod: "HS512", Secret: "secret"})
+		assert.ErrorIs(t, err, token.ErrUnsupportedSigningMethod)
+	})
+
+	t.Run("It should require a private key file for RS256", func(t *testing.T) {
+		_, err := token.NewService(config.JWT{SigningMethod: "RS256"})
+		assert.Error(t, err)
+	})
+}
+
+func TestService_CreateEmailVerificationToken(t *testing.T) {
+	tokenService, err := token.NewService(config.JWT{
+		SigningMethod:        "HS256",
+		Secret:               "secret",
+		Issuer:               "echo-app",
+		AccessTokenTTL:       time.Minute,
+		EmailVerificationTTL: time.Hour,
+	})
+	require.NoError(t, err)
+
+	user := &models.User{
+		Model:               gorm.Model{ID: 42},
+		Email:               "example@email.com",
+		VerificationTokenID: "token-id",
+	}
+
+	t.Run("It should issue a verification token bound to the token id", func(t *testing.T) {
+		rawToken, err := tokenService.CreateEmailVerificationToken(user)
+		require.NoError(t, err)
+
+		claims, err := tokenService.ParseEmailVerificationToken(rawToken)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint(42), claims.UserID)
+		assert.Equal(t, "token-id", claims.ID)
+	})
+
+	t.Run("It should not accept tokens of another type", func(t *testing.T) {
+		verificationToken, err := tokenService.CreateEmailVerificationToken(user)
+		require.NoError(t, err)
+
+		_, err = tokenService.ParseAccessToken(verificationToken)
+		require.ErrorIs(t, err, token.ErrUnexpectedTokenType)
+
+		accessToken, _, err := tokenService.CreateAccessToken(user, uuid.New())
+		require.NoError(t, err)
+
+		_, err = tokenService.ParseEmailVerificationToken(accessToken)
+		assert.ErrorIs(t, err, token.ErrUnexpectedTokenType)
+	})
+}
+
+func TestService_CreateMFAToken(t *testing.T) {
+	tokenService, err := token.NewService(config.JWT{
+		SigningMethod:  "HS256",
+		Secret:         "secret",
+		Issuer:         "echo-app",
+		AccessTokenTTL: time.Minute,
+		MFATokenTTL:    5 * time.Minute,
+	})
+	require.NoError(t, err)
+
+	user := &models.User{Model: gorm.Model{ID: 42}, Email: "example@email.com"}
+
+	t.Run("It should issue a short-lived MFA token of the user", func(t *testing.T) {
+		rawToken, expiresAt, err := tokenService.CreateMFAToken(user)
+		require.NoError(t, err)
+
+		assert.WithinDuration(t, time.Now().Add(5*time.Minute), expiresAt, time.Second)
+
+		claims, err := tokenService.ParseMFAToken(rawToken)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint(42), claims.UserID)
+	})
+
+	t.Run("It should not accept an MFA token as an access token", func(t *testing.T) {
+		mfaToken, _, err := tokenService.CreateMFAToken(user)
+		require.NoError(t, err)
+
+		_, err = tokenService.ParseAccessToken(mfaToken)
+		require.ErrorIs(t, err, token.ErrUnexpectedTokenType)
+
+		accessToken, _, err := tokenService.CreateAccessToken(user, uuid.New())
+		require.NoError(t, err)
+
+		_, err = tokenService.ParseMFAToken(accessToken)
+		assert.ErrorIs(t, err, token.ErrUnexpectedTokenType)
+	})
+}
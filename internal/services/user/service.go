@@ -2,21 +2,87 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
-	"echo-app/internal/repositories"
+	"echo-app/internal/models"
+	"echo-app/internal/permify"
 	"echo-app/internal/requests"
 	"echo-app/internal/server/builders"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrEmailNotVerified   = errors.New("email is not verified")
+	// ErrOIDCEmailNotVerified is returned when an identity provider didn't verify the email of an existing user,
+	// linking the identity could hand the account over to whoever registered the email at the provider.
+	ErrOIDCEmailNotVerified = errors.New("identity provider didn't verify the email")
+)
+
+// dummyPasswordHash is compared against when the user doesn't exist,
+// so the response time doesn't reveal which emails are registered.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+type userRepository interface {
+	Create(ctx context.Context, user *models.User) error
+	GetByID(ctx context.Context, id uint) (models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (models.User, error)
+	GetUserByIdentity(ctx context.Context, provider, subject string) (models.User, error)
+	Update(ctx context.Context, user *models.User) error
+}
+
+type identityRepository interface {
+	Create(ctx context.Context, identity *models.UserIdentity) error
+}
+
+type verificationSender interface {
+	Send(ctx context.Context, user *models.User) error
+}
+
+type transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type relationshipOutbox interface {
+	Write(ctx context.Context, tuples ...permify.Tuple) ([]uint, error)
+	Flush(ctx context.Context, ids []uint)
+}
+
+type groupSyncer interface {
+	SyncGroups(ctx context.Context, provider string, userID uint, groups []string) error
+}
+
 type Service struct {
-	userRepository *repositories.UserRepository
+	userRepository     userRepository
+	identityRepository identityRepository
+	verificationSender verificationSender
+	transactor         transactor
+	relationships      relationshipOutbox
+	groups             groupSyncer
 }
 
-func NewService(userRepository *repositories.UserRepository) *Service {
-	return &Service{userRepository: userRepository}
+func NewService(
+	userRepository userRepository,
+	identityRepository identityRepository,
+	verificationSender verificationSender,
+	transactor transactor,
+	relationships relationshipOutbox,
+	groups groupSyncer,
+) *Service {
+	return &Service{
+		userRepository:     userRepository,
+		identityRepository: identityRepository,
+		verificationSender: verificationSender,
+		transactor:         transactor,
+		relationships:      relationships,
+		groups:             groups,
+	}
 }
 
 // Register handles both traditional registration and OIDC user creation
@@ -35,11 +101,173 @@ func (s *Service) Register(ctx context.Context, request *requests.RegisterReques
 		SetPassword(string(encryptedPassword)).
 		Build()
 
-	if err := s.userRepository.Create(ctx, user); err != nil {
-		return fmt.Errorf("create user in repository: %w", err)
+	if err := s.create(ctx, user); err != nil {
+		return err
+	}
+
+	if err := s.verificationSender.Send(ctx, user); err != nil {
+		return fmt.Errorf("send verification email: %w", err)
 	}
 
 	return nil
 }
 
-// GetOrCreateUserFromOIDC handles OIDC user authentication
+func (s *Service) GetByID(ctx context.Context, id uint) (models.User, error) {
+	user, err := s.userRepository.GetByID(ctx, id)
+	if err != nil {
+		return models.User{}, fmt.Errorf("get user by id from repository: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *Service) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
+	user, err := s.userRepository.GetUserByEmail(ctx, email)
+	if err != nil {
+		return models.User{}, fmt.Errorf("get user by email from repository: %w", err)
+	}
+
+	return user, nil
+}
+
+// Authenticate checks the email and password against the stored bcrypt hash.
+// Users created through OIDC have no password and can't authenticate this way,
+// users who haven't verified their email yet are rejected with ErrEmailNotVerified.
+func (s *Service) Authenticate(ctx context.Context, email, password string) (models.User, error) {
+	user, err := s.userRepository.GetUserByEmail(ctx, email)
+	if errors.Is(err, models.ErrUserNotFound) {
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+		return models.User{}, ErrInvalidCredentials
+	} else if err != nil {
+		return models.User{}, fmt.Errorf("get user by email from repository: %w", err)
+	}
+
+	if user.Password == "" {
+		return models.User{}, ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return models.User{}, ErrInvalidCredentials
+	}
+
+	if user.EmailVerifiedAt == nil {
+		return models.User{}, ErrEmailNotVerified
+	}
+
+	return user, nil
+}
+
+// GetOrCreateUserFromOIDC handles OIDC user authentication at the identity provider.
+// A new identity is linked to the user with the same email, if the identity provider verified it.
+// The domain memberships of the user are synced with their identity provider groups on every login.
+func (s *Service) GetOrCreateUserFromOIDC(ctx context.Context, provider string, claims *models.OIDCClaims) (models.User, error) {
+	user, err := s.getOrCreateUserFromOIDC(ctx, provider, claims)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	if err := s.groups.SyncGroups(ctx, provider, user.ID, claims.Groups); err != nil {
+		return models.User{}, fmt.Errorf("sync OIDC groups: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *Service) getOrCreateUserFromOIDC(ctx context.Context, provider string, claims *models.OIDCClaims) (models.User, error) {
+	// First try to find by the identity
+	user, err := s.userRepository.GetUserByIdentity(ctx, provider, claims.Sub)
+	if err == nil {
+		return user, nil
+	} else if !errors.Is(err, models.ErrUserNotFound) {
+		return models.User{}, fmt.Errorf("get user by identity from repository: %w", err)
+	}
+
+	identity := &models.UserIdentity{Provider: provider, Subject: claims.Sub}
+
+	// If not found by the identity, try by email
+	user, err = s.userRepository.GetUserByEmail(ctx, claims.Email)
+	if err == nil {
+		if !claims.EmailVerified {
+			return models.User{}, ErrOIDCEmailNotVerified
+		}
+
+		identity.UserID = user.ID
+		if err := s.identityRepository.Create(ctx, identity); err != nil {
+			return models.User{}, fmt.Errorf("link user identity: %w", err)
+		}
+
+		return user, nil
+	} else if !errors.Is(err, models.ErrUserNotFound) {
+		return models.User{}, fmt.Errorf("get user by email from repository: %w", err)
+	}
+
+	// Create new user if not found
+	userBuilder := builders.NewUserBuilder().
+		SetEmail(claims.Email).
+		SetName(claims.Name)
+	if claims.EmailVerified {
+		userBuilder.SetEmailVerifiedAt(time.Now())
+	}
+
+	newUser := userBuilder.Build()
+
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.Create(ctx, newUser); err != nil {
+			return fmt.Errorf("create user in repository: %w", err)
+		}
+
+		identity.UserID = newUser.ID
+		if err := s.identityRepository.Create(ctx, identity); err != nil {
+			return fmt.Errorf("create user identity in repository: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.User{}, fmt.Errorf("create OIDC user: %w", err)
+	}
+
+	return *newUser, nil
+}
+
+// create stores the user and makes them a member of their domain in Permify.
+// The membership is recorded in the outbox in the same transaction as the user.
+func (s *Service) create(ctx context.Context, user *models.User) error {
+	if user.DomainID == nil {
+		if err := s.userRepository.Create(ctx, user); err != nil {
+			return fmt.Errorf("create user in repository: %w", err)
+		}
+
+		return nil
+	}
+
+	var entryIDs []uint
+
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.Create(ctx, user); err != nil {
+			return fmt.Errorf("create user in repository: %w", err)
+		}
+
+		var err error
+
+		entryIDs, err = s.relationships.Write(ctx, permify.Tuple{
+			EntityType:  "domain",
+			EntityID:    user.DomainID.String(),
+			Relation:    "member",
+			SubjectType: "user",
+			SubjectID:   strconv.FormatUint(uint64(user.ID), 10),
+		})
+		if err != nil {
+			return fmt.Errorf("write domain membership: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.relationships.Flush(ctx, entryIDs)
+
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	models "echo-app/internal/models"
+	permify "echo-app/internal/permify"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -156,3 +157,432 @@ func (c *MockuserRepositoryGetUserByEmailCall) DoAndReturn(f func(context.Contex
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// GetUserByIdentity mocks base method.
+func (m *MockuserRepository) GetUserByIdentity(ctx context.Context, provider, subject string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByIdentity", ctx, provider, subject)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByIdentity indicates an expected call of GetUserByIdentity.
+func (mr *MockuserRepositoryMockRecorder) GetUserByIdentity(ctx, provider, subject any) *MockuserRepositoryGetUserByIdentityCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByIdentity", reflect.TypeOf((*MockuserRepository)(nil).GetUserByIdentity), ctx, provider, subject)
+	return &MockuserRepositoryGetUserByIdentityCall{Call: call}
+}
+
+// MockuserRepositoryGetUserByIdentityCall wrap *gomock.Call
+type MockuserRepositoryGetUserByIdentityCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRepositoryGetUserByIdentityCall) Return(arg0 models.User, arg1 error) *MockuserRepositoryGetUserByIdentityCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRepositoryGetUserByIdentityCall) Do(f func(context.Context, string, string) (models.User, error)) *MockuserRepositoryGetUserByIdentityCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRepositoryGetUserByIdentityCall) DoAndReturn(f func(context.Context, string, string) (models.User, error)) *MockuserRepositoryGetUserByIdentityCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockuserRepository) Update(ctx context.Context, user *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockuserRepositoryMockRecorder) Update(ctx, user any) *MockuserRepositoryUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockuserRepository)(nil).Update), ctx, user)
+	return &MockuserRepositoryUpdateCall{Call: call}
+}
+
+// MockuserRepositoryUpdateCall wrap *gomock.Call
+type MockuserRepositoryUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRepositoryUpdateCall) Return(arg0 error) *MockuserRepositoryUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRepositoryUpdateCall) Do(f func(context.Context, *models.User) error) *MockuserRepositoryUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRepositoryUpdateCall) DoAndReturn(f func(context.Context, *models.User) error) *MockuserRepositoryUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockidentityRepository is a mock of identityRepository interface.
+type MockidentityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockidentityRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockidentityRepositoryMockRecorder is the mock recorder for MockidentityRepository.
+type MockidentityRepositoryMockRecorder struct {
+	mock *MockidentityRepository
+}
+
+// NewMockidentityRepository creates a new mock instance.
+func NewMockidentityRepository(ctrl *gomock.Controller) *MockidentityRepository {
+	mock := &MockidentityRepository{ctrl: ctrl}
+	mock.recorder = &MockidentityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockidentityRepository) EXPECT() *MockidentityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockidentityRepository) Create(ctx context.Context, identity *models.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockidentityRepositoryMockRecorder) Create(ctx, identity any) *MockidentityRepositoryCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockidentityRepository)(nil).Create), ctx, identity)
+	return &MockidentityRepositoryCreateCall{Call: call}
+}
+
+// MockidentityRepositoryCreateCall wrap *gomock.Call
+type MockidentityRepositoryCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockidentityRepositoryCreateCall) Return(arg0 error) *MockidentityRepositoryCreateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockidentityRepositoryCreateCall) Do(f func(context.Context, *models.UserIdentity) error) *MockidentityRepositoryCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockidentityRepositoryCreateCall) DoAndReturn(f func(context.Context, *models.UserIdentity) error) *MockidentityRepositoryCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockverificationSender is a mock of verificationSender interface.
+type MockverificationSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockverificationSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockverificationSenderMockRecorder is the mock recorder for MockverificationSender.
+type MockverificationSenderMockRecorder struct {
+	mock *MockverificationSender
+}
+
+// NewMockverificationSender creates a new mock instance.
+func NewMockverificationSender(ctrl *gomock.Controller) *MockverificationSender {
+	mock := &MockverificationSender{ctrl: ctrl}
+	mock.recorder = &MockverificationSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockverificationSender) EXPECT() *MockverificationSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockverificationSender) Send(ctx context.Context, user *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockverificationSenderMockRecorder) Send(ctx, user any) *MockverificationSenderSendCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockverificationSender)(nil).Send), ctx, user)
+	return &MockverificationSenderSendCall{Call: call}
+}
+
+// MockverificationSenderSendCall wrap *gomock.Call
+type MockverificationSenderSendCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockverificationSenderSendCall) Return(arg0 error) *MockverificationSenderSendCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockverificationSenderSendCall) Do(f func(context.Context, *models.User) error) *MockverificationSenderSendCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockverificationSenderSendCall) DoAndReturn(f func(context.Context, *models.User) error) *MockverificationSenderSendCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Mocktransactor is a mock of transactor interface.
+type Mocktransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactorMockRecorder
+	isgomock struct{}
+}
+
+// MocktransactorMockRecorder is the mock recorder for Mocktransactor.
+type MocktransactorMockRecorder struct {
+	mock *Mocktransactor
+}
+
+// NewMocktransactor creates a new mock instance.
+func NewMocktransactor(ctrl *gomock.Controller) *Mocktransactor {
+	mock := &Mocktransactor{ctrl: ctrl}
+	mock.recorder = &MocktransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocktransactor) EXPECT() *MocktransactorMockRecorder {
+	return m.recorder
+}
+
+// Transaction mocks base method.
+func (m *Mocktransactor) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MocktransactorMockRecorder) Transaction(ctx, fn any) *MocktransactorTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*Mocktransactor)(nil).Transaction), ctx, fn)
+	return &MocktransactorTransactionCall{Call: call}
+}
+
+// MocktransactorTransactionCall wrap *gomock.Call
+type MocktransactorTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktransactorTransactionCall) Return(arg0 error) *MocktransactorTransactionCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktransactorTransactionCall) Do(f func(context.Context, func(context.Context) error) error) *MocktransactorTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktransactorTransactionCall) DoAndReturn(f func(context.Context, func(context.Context) error) error) *MocktransactorTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockrelationshipOutbox is a mock of relationshipOutbox interface.
+type MockrelationshipOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockrelationshipOutboxMockRecorder
+	isgomock struct{}
+}
+
+// MockrelationshipOutboxMockRecorder is the mock recorder for MockrelationshipOutbox.
+type MockrelationshipOutboxMockRecorder struct {
+	mock *MockrelationshipOutbox
+}
+
+// NewMockrelationshipOutbox creates a new mock instance.
+func NewMockrelationshipOutbox(ctrl *gomock.Controller) *MockrelationshipOutbox {
+	mock := &MockrelationshipOutbox{ctrl: ctrl}
+	mock.recorder = &MockrelationshipOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrelationshipOutbox) EXPECT() *MockrelationshipOutboxMockRecorder {
+	return m.recorder
+}
+
+// Flush mocks base method.
+func (m *MockrelationshipOutbox) Flush(ctx context.Context, ids []uint) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Flush", ctx, ids)
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockrelationshipOutboxMockRecorder) Flush(ctx, ids any) *MockrelationshipOutboxFlushCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockrelationshipOutbox)(nil).Flush), ctx, ids)
+	return &MockrelationshipOutboxFlushCall{Call: call}
+}
+
+// MockrelationshipOutboxFlushCall wrap *gomock.Call
+type MockrelationshipOutboxFlushCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrelationshipOutboxFlushCall) Return() *MockrelationshipOutboxFlushCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrelationshipOutboxFlushCall) Do(f func(context.Context, []uint)) *MockrelationshipOutboxFlushCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrelationshipOutboxFlushCall) DoAndReturn(f func(context.Context, []uint)) *MockrelationshipOutboxFlushCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Write mocks base method.
+func (m *MockrelationshipOutbox) Write(ctx context.Context, tuples ...permify.Tuple) ([]uint, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range tuples {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Write", varargs...)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockrelationshipOutboxMockRecorder) Write(ctx any, tuples ...any) *MockrelationshipOutboxWriteCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, tuples...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockrelationshipOutbox)(nil).Write), varargs...)
+	return &MockrelationshipOutboxWriteCall{Call: call}
+}
+
+// MockrelationshipOutboxWriteCall wrap *gomock.Call
+type MockrelationshipOutboxWriteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrelationshipOutboxWriteCall) Return(arg0 []uint, arg1 error) *MockrelationshipOutboxWriteCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrelationshipOutboxWriteCall) Do(f func(context.Context, ...permify.Tuple) ([]uint, error)) *MockrelationshipOutboxWriteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrelationshipOutboxWriteCall) DoAndReturn(f func(context.Context, ...permify.Tuple) ([]uint, error)) *MockrelationshipOutboxWriteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockgroupSyncer is a mock of groupSyncer interface.
+type MockgroupSyncer struct {
+	ctrl     *gomock.Controller
+	recorder *MockgroupSyncerMockRecorder
+	isgomock struct{}
+}
+
+// MockgroupSyncerMockRecorder is the mock recorder for MockgroupSyncer.
+type MockgroupSyncerMockRecorder struct {
+	mock *MockgroupSyncer
+}
+
+// NewMockgroupSyncer creates a new mock instance.
+func NewMockgroupSyncer(ctrl *gomock.Controller) *MockgroupSyncer {
+	mock := &MockgroupSyncer{ctrl: ctrl}
+	mock.recorder = &MockgroupSyncerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgroupSyncer) EXPECT() *MockgroupSyncerMockRecorder {
+	return m.recorder
+}
+
+// SyncGroups mocks base method.
+func (m *MockgroupSyncer) SyncGroups(ctx context.Context, provider string, userID uint, groups []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncGroups", ctx, provider, userID, groups)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncGroups indicates an expected call of SyncGroups.
+func (mr *MockgroupSyncerMockRecorder) SyncGroups(ctx, provider, userID, groups any) *MockgroupSyncerSyncGroupsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncGroups", reflect.TypeOf((*MockgroupSyncer)(nil).SyncGroups), ctx, provider, userID, groups)
+	return &MockgroupSyncerSyncGroupsCall{Call: call}
+}
+
+// MockgroupSyncerSyncGroupsCall wrap *gomock.Call
+type MockgroupSyncerSyncGroupsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockgroupSyncerSyncGroupsCall) Return(arg0 error) *MockgroupSyncerSyncGroupsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockgroupSyncerSyncGroupsCall) Do(f func(context.Context, string, uint, []string) error) *MockgroupSyncerSyncGroupsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockgroupSyncerSyncGroupsCall) DoAndReturn(f func(context.Context, string, uint, []string) error) *MockgroupSyncerSyncGroupsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
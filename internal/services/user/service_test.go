@@ -2,7 +2,9 @@ package user_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"echo-app/internal/models"
 	"echo-app/internal/requests"
@@ -12,12 +14,14 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 func TestService_Register(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	userRepository := NewMockuserRepository(ctrl)
-	userService := user.NewService(userRepository)
+	verificationSender := NewMockverificationSender(ctrl)
+	userService := user.NewService(userRepository, nil, verificationSender, nil, nil, nil)
 
 	request := &requests.RegisterRequest{
 		BasicAuth: requests.BasicAuth{
@@ -46,6 +50,11 @@ func TestService_Register(t *testing.T) {
 			return nil
 		})
 
+	verificationSender.
+		EXPECT().
+		Send(gomock.Any(), wantUser).
+		Return(nil)
+
 	err := userService.Register(t.Context(), request)
 	require.NoError(t, err)
 }
@@ -53,7 +62,7 @@ func TestService_Register(t *testing.T) {
 func TestService_GetByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	userRepository := NewMockuserRepository(ctrl)
-	userService := user.NewService(userRepository)
+	userService := user.NewService(userRepository, nil, nil, nil, nil, nil)
 
 	wantUser := models.User{
 		Email:    "example@email.com",
@@ -75,7 +84,7 @@ func TestService_GetByID(t *testing.T) {
 func TestService_GetUserByEmail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	userRepository := NewMockuserRepository(ctrl)
-	userService := user.NewService(userRepository)
+	userService := user.NewService(userRepository, nil, nil, nil, nil, nil)
 
 	wantUser := models.User{
 		Email:    "example@gmail.com",
@@ -93,3 +102,250 @@ func TestService_GetUserByEmail(t *testing.T) {
 
 	assert.Equal(t, wantUser, gotUser)
 }
+
+func TestService_Authenticate(t *testing.T) {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte("some-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	verifiedAt := time.Now()
+
+	storedUser := models.User{
+		Email:           "example@email.com",
+		Name:            "name",
+		Password:        string(passwordHash),
+		EmailVerifiedAt: &verifiedAt,
+	}
+
+	t.Run("It should authenticate a user with a valid password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userRepository := NewMockuserRepository(ctrl)
+		userService := user.NewService(userRepository, nil, nil, nil, nil, nil)
+
+		userRepository.
+			EXPECT().
+			GetUserByEmail(gomock.Any(), "example@email.com").
+			Return(storedUser, nil)
+
+		gotUser, err := userService.Authenticate(t.Context(), "example@email.com", "some-password")
+		require.NoError(t, err)
+
+		assert.Equal(t, storedUser, gotUser)
+	})
+
+	t.Run("It should reject an invalid password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userRepository := NewMockuserRepository(ctrl)
+		userService := user.NewService(userRepository, nil, nil, nil, nil, nil)
+
+		userRepository.
+			EXPECT().
+			GetUserByEmail(gomock.Any(), "example@email.com").
+			Return(storedUser, nil)
+
+		_, err := userService.Authenticate(t.Context(), "example@email.com", "wrong-password")
+		assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+	})
+
+	t.Run("It should reject an unknown email", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userRepository := NewMockuserRepository(ctrl)
+		userService := user.NewService(userRepository, nil, nil, nil, nil, nil)
+
+		userRepository.
+			EXPECT().
+			GetUserByEmail(gomock.Any(), "unknown@email.com").
+			Return(models.User{}, models.ErrUserNotFound)
+
+		_, err := userService.Authenticate(t.Context(), "unknown@email.com", "some-password")
+		assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+	})
+
+	t.Run("It should reject a user without a password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userRepository := NewMockuserRepository(ctrl)
+		userService := user.NewService(userRepository, nil, nil, nil, nil, nil)
+
+		userRepository.
+			EXPECT().
+			GetUserByEmail(gomock.Any(), "oidc@email.com").
+			Return(models.User{Email: "oidc@email.com"}, nil)
+
+		_, err := userService.Authenticate(t.Context(), "oidc@email.com", "")
+		assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+	})
+
+	t.Run("It should reject a user with an unverified email", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userRepository := NewMockuserRepository(ctrl)
+		userService := user.NewService(userRepository, nil, nil, nil, nil, nil)
+
+		unverifiedUser := storedUser
+		unverifiedUser.EmailVerifiedAt = nil
+
+		userRepository.
+			EXPECT().
+			GetUserByEmail(gomock.Any(), "example@email.com").
+			Return(unverifiedUser, nil)
+
+		_, err := userService.Authenticate(t.Context(), "example@email.com", "some-password")
+		assert.ErrorIs(t, err, user.ErrEmailNotVerified)
+	})
+}
+
+type transactorStub struct{}
+
+func (transactorStub) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestService_GetOrCreateUserFromOIDC(t *testing.T) {
+	claims := &models.OIDCClaims{
+		Sub:           "subject",
+		Email:         "example@email.com",
+		EmailVerified: true,
+		Name:          "name",
+		Groups:        []string{"acme"},
+	}
+
+	t.Run("It should return the user linked to the identity", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userRepository := NewMockuserRepository(ctrl)
+		groupSyncer := NewMockgroupSyncer(ctrl)
+		userService := user.NewService(userRepository, nil, nil, nil, nil, groupSyncer)
+
+		wantUser := models.User{Model: gorm.Model{ID: 3}, Email: "example@email.com"}
+
+		userRepository.
+			EXPECT().
+			GetUserByIdentity(gomock.Any(), "google", "subject").
+			Return(wantUser, nil)
+
+		groupSyncer.
+			EXPECT().
+			SyncGroups(gomock.Any(), "google", uint(3), []string{"acme"}).
+			Return(nil)
+
+		gotUser, err := userService.GetOrCreateUserFromOIDC(t.Context(), "google", claims)
+		require.NoError(t, err)
+
+		assert.Equal(t, wantUser, gotUser)
+	})
+
+	t.Run("It should link the identity to the user with the verified email", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userRepository := NewMockuserRepository(ctrl)
+		identityRepository := NewMockidentityRepository(ctrl)
+		groupSyncer := NewMockgroupSyncer(ctrl)
+		userService := user.NewService(userRepository, identityRepository, nil, nil, nil, groupSyncer)
+
+		userRepository.
+			EXPECT().
+			GetUserByIdentity(gomock.Any(), "google", "subject").
+			Return(models.User{}, models.ErrUserNotFound)
+
+		userRepository.
+			EXPECT().
+			GetUserByEmail(gomock.Any(), "example@email.com").
+			Return(models.User{Model: gorm.Model{ID: 3}}, nil)
+
+		identityRepository.
+			EXPECT().
+			Create(gomock.Any(), &models.UserIdentity{UserID: 3, Provider: "google", Subject: "subject"}).
+			Return(nil)
+
+		groupSyncer.
+			EXPECT().
+			SyncGroups(gomock.Any(), "google", uint(3), []string{"acme"}).
+			Return(nil)
+
+		gotUser, err := userService.GetOrCreateUserFromOIDC(t.Context(), "google", claims)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint(3), gotUser.ID)
+	})
+
+	t.Run("It should not link the identity when the email is not verified", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userRepository := NewMockuserRepository(ctrl)
+		userService := user.NewService(userRepository, NewMockidentityRepository(ctrl), nil, nil, nil, nil)
+
+		unverified := *claims
+		unverified.EmailVerified = false
+
+		userRepository.
+			EXPECT().
+			GetUserByIdentity(gomock.Any(), "google", "subject").
+			Return(models.User{}, models.ErrUserNotFound)
+
+		userRepository.
+			EXPECT().
+			GetUserByEmail(gomock.Any(), "example@email.com").
+			Return(models.User{Model: gorm.Model{ID: 3}}, nil)
+
+		_, err := userService.GetOrCreateUserFromOIDC(t.Context(), "google", &unverified)
+		assert.ErrorIs(t, err, user.ErrOIDCEmailNotVerified)
+	})
+
+	t.Run("It should create a verified user with the identity", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userRepository := NewMockuserRepository(ctrl)
+		identityRepository := NewMockidentityRepository(ctrl)
+		groupSyncer := NewMockgroupSyncer(ctrl)
+		userService := user.NewService(userRepository, identityRepository, nil, transactorStub{}, nil, groupSyncer)
+
+		userRepository.
+			EXPECT().
+			GetUserByIdentity(gomock.Any(), "google", "subject").
+			Return(models.User{}, models.ErrUserNotFound)
+
+		userRepository.
+			EXPECT().
+			GetUserByEmail(gomock.Any(), "example@email.com").
+			Return(models.User{}, models.ErrUserNotFound)
+
+		userRepository.
+			EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, got *models.User) error {
+				got.ID = 5
+
+				return nil
+			})
+
+		identityRepository.
+			EXPECT().
+			Create(gomock.Any(), &models.UserIdentity{UserID: 5, Provider: "google", Subject: "subject"}).
+			Return(nil)
+
+		groupSyncer.
+			EXPECT().
+			SyncGroups(gomock.Any(), "google", uint(5), []string{"acme"}).
+			Return(nil)
+
+		gotUser, err := userService.GetOrCreateUserFromOIDC(t.Context(), "google", claims)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint(5), gotUser.ID)
+		assert.NotNil(t, gotUser.EmailVerifiedAt)
+	})
+
+	t.Run("It should fail the login when the groups can't be synced", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userRepository := NewMockuserRepository(ctrl)
+		groupSyncer := NewMockgroupSyncer(ctrl)
+		userService := user.NewService(userRepository, nil, nil, nil, nil, groupSyncer)
+
+		userRepository.
+			EXPECT().
+			GetUserByIdentity(gomock.Any(), "google", "subject").
+			Return(models.User{Model: gorm.Model{ID: 3}}, nil)
+
+		groupSyncer.
+			EXPECT().
+			SyncGroups(gomock.Any(), "google", uint(3), []string{"acme"}).
+			Return(errors.New("permify unavailable"))
+
+		_, err := userService.GetOrCreateUserFromOIDC(t.Context(), "google", claims)
+		assert.Error(t, err)
+	})
+}
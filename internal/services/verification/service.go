@@ -0,0 +1,128 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"echo-app/internal/config"
+	"echo-app/internal/mailer"
+	"echo-app/internal/models"
+	"echo-app/internal/services/token"
+
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+var ErrInvalidToken = errors.New("invalid verification token")
+
+type userRepository interface {
+	GetUserByEmail(ctx context.Context, email string) (models.User, error)
+	Update(ctx context.Context, user *models.User) error
+	VerifyEmail(ctx context.Context, id uint, tokenID, email string, verifiedAt time.Time) (bool, error)
+}
+
+type tokenService interface {
+	CreateEmailVerificationToken(user *models.User) (string, error)
+	ParseEmailVerificationToken(raw string) (*token.VerificationClaims, error)
+}
+
+type Service struct {
+	userRepository  userRepository
+	tokens          tokenService
+	mailer          mailer.Mailer
+	from            string
+	verificationURL string
+	now             func() time.Time
+}
+
+func NewService(userRepository userRepository, tokens tokenService, mailer mailer.Mailer, cfg config.Mail) *Service {
+	return &Service{
+		userRepository:  userRepository,
+		tokens:          tokens,
+		mailer:          mailer,
+		from:            cfg.From,
+		verificationURL: cfg.VerificationURL,
+		now:             time.Now,
+	}
+}
+
+// Send issues a new verification token for the user and mails it.
+// Tokens sent before are invalidated.
+func (s *Service) Send(ctx context.Context, user *models.User) error {
+	tokenID, err := uuid.NewRandom()
+	if err != nil {
+		return fmt.Errorf("new verification token id: %w", err)
+	}
+
+	user.VerificationTokenID = tokenID.String()
+	if err := s.userRepository.Update(ctx, user); err != nil {
+		return fmt.Errorf("store verification token id: %w", err)
+	}
+
+	verificationToken, err := s.tokens.CreateEmailVerificationToken(user)
+	if err != nil {
+		return fmt.Errorf("create verification token: %w", err)
+	}
+
+	message := mailer.Message{
+		From:    s.from,
+		To:      user.Email,
+		Subject: "Confirm your email address",
+		Body:    "Open the link to confirm your email address:\n\n" + s.link(verificationToken) + "\n",
+	}
+
+	if err := s.mailer.Send(ctx, message); err != nil {
+		return fmt.Errorf("send verification email: %w", err)
+	}
+
+	return nil
+}
+
+// Resend mails a new verification token to the user with the email.
+// Unknown and already verified emails are ignored, so the caller can't tell which accounts exist.
+func (s *Service) Resend(ctx context.Context, email string) error {
+	user, err := s.userRepository.GetUserByEmail(ctx, email)
+	if errors.Is(err, models.ErrUserNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("get user by email from repository: %w", err)
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	return s.Send(ctx, &user)
+}
+
+// Verify marks the user's email as verified. Each token can be used only once.
+func (s *Service) Verify(ctx context.Context, rawToken string) error {
+	claims, err := s.tokens.ParseEmailVerificationToken(rawToken)
+	if err != nil {
+		return errors.Join(ErrInvalidToken, err)
+	}
+
+	if claims.ID == "" {
+		return ErrInvalidToken
+	}
+
+	// The token is consumed in the same statement that checks it, concurrent requests can't both use it
+	verified, err := s.userRepository.VerifyEmail(ctx, claims.UserID, claims.ID, claims.Email, s.now())
+	if err != nil {
+		return fmt.Errorf("verify email in repository: %w", err)
+	}
+
+	if !verified {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
+func (s *Service) link(verificationToken string) string {
+	return s.verificationURL + "?" + url.Values{"token": {verificationToken}}.Encode()
+}
@@ -0,0 +1,262 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=verification_test -typed=true
+//
+
+// Package verification_test is a generated GoMock package.
+package verification_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "echo-app/internal/models"
+	token "echo-app/internal/services/token"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockuserRepository is a mock of userRepository interface.
+type MockuserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockuserRepositoryMockRecorder is the mock recorder for MockuserRepository.
+type MockuserRepositoryMockRecorder struct {
+	mock *MockuserRepository
+}
+
+// NewMockuserRepository creates a new mock instance.
+func NewMockuserRepository(ctrl *gomock.Controller) *MockuserRepository {
+	mock := &MockuserRepository{ctrl: ctrl}
+	mock.recorder = &MockuserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserRepository) EXPECT() *MockuserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockuserRepository) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockuserRepositoryMockRecorder) GetUserByEmail(ctx, email any) *MockuserRepositoryGetUserByEmailCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockuserRepository)(nil).GetUserByEmail), ctx, email)
+	return &MockuserRepositoryGetUserByEmailCall{Call: call}
+}
+
+// MockuserRepositoryGetUserByEmailCall wrap *gomock.Call
+type MockuserRepositoryGetUserByEmailCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRepositoryGetUserByEmailCall) Return(arg0 models.User, arg1 error) *MockuserRepositoryGetUserByEmailCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRepositoryGetUserByEmailCall) Do(f func(context.Context, string) (models.User, error)) *MockuserRepositoryGetUserByEmailCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRepositoryGetUserByEmailCall) DoAndReturn(f func(context.Context, string) (models.User, error)) *MockuserRepositoryGetUserByEmailCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockuserRepository) Update(ctx context.Context, user *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockuserRepositoryMockRecorder) Update(ctx, user any) *MockuserRepositoryUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockuserRepository)(nil).Update), ctx, user)
+	return &MockuserRepositoryUpdateCall{Call: call}
+}
+
+// MockuserRepositoryUpdateCall wrap *gomock.Call
+type MockuserRepositoryUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRepositoryUpdateCall) Return(arg0 error) *MockuserRepositoryUpdateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRepositoryUpdateCall) Do(f func(context.Context, *models.User) error) *MockuserRepositoryUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRepositoryUpdateCall) DoAndReturn(f func(context.Context, *models.User) error) *MockuserRepositoryUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// VerifyEmail mocks base method.
+func (m *MockuserRepository) VerifyEmail(ctx context.Context, id uint, tokenID, email string, verifiedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, id, tokenID, email, verifiedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockuserRepositoryMockRecorder) VerifyEmail(ctx, id, tokenID, email, verifiedAt any) *MockuserRepositoryVerifyEmailCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockuserRepository)(nil).VerifyEmail), ctx, id, tokenID, email, verifiedAt)
+	return &MockuserRepositoryVerifyEmailCall{Call: call}
+}
+
+// MockuserRepositoryVerifyEmailCall wrap *gomock.Call
+type MockuserRepositoryVerifyEmailCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRepositoryVerifyEmailCall) Return(arg0 bool, arg1 error) *MockuserRepositoryVerifyEmailCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRepositoryVerifyEmailCall) Do(f func(context.Context, uint, string, string, time.Time) (bool, error)) *MockuserRepositoryVerifyEmailCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRepositoryVerifyEmailCall) DoAndReturn(f func(context.Context, uint, string, string, time.Time) (bool, error)) *MockuserRepositoryVerifyEmailCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MocktokenService is a mock of tokenService interface.
+type MocktokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MocktokenServiceMockRecorder
+	isgomock struct{}
+}
+
+// MocktokenServiceMockRecorder is the mock recorder for MocktokenService.
+type MocktokenServiceMockRecorder struct {
+	mock *MocktokenService
+}
+
+// NewMocktokenService creates a new mock instance.
+func NewMocktokenService(ctrl *gomock.Controller) *MocktokenService {
+	mock := &MocktokenService{ctrl: ctrl}
+	mock.recorder = &MocktokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktokenService) EXPECT() *MocktokenServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateEmailVerificationToken mocks base method.
+func (m *MocktokenService) CreateEmailVerificationToken(user *models.User) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailVerificationToken", user)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmailVerificationToken indicates an expected call of CreateEmailVerificationToken.
+func (mr *MocktokenServiceMockRecorder) CreateEmailVerificationToken(user any) *MocktokenServiceCreateEmailVerificationTokenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailVerificationToken", reflect.TypeOf((*MocktokenService)(nil).CreateEmailVerificationToken), user)
+	return &MocktokenServiceCreateEmailVerificationTokenCall{Call: call}
+}
+
+// MocktokenServiceCreateEmailVerificationTokenCall wrap *gomock.Call
+type MocktokenServiceCreateEmailVerificationTokenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenServiceCreateEmailVerificationTokenCall) Return(arg0 string, arg1 error) *MocktokenServiceCreateEmailVerificationTokenCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenServiceCreateEmailVerificationTokenCall) Do(f func(*models.User) (string, error)) *MocktokenServiceCreateEmailVerificationTokenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenServiceCreateEmailVerificationTokenCall) DoAndReturn(f func(*models.User) (string, error)) *MocktokenServiceCreateEmailVerificationTokenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ParseEmailVerificationToken mocks base method.
+func (m *MocktokenService) ParseEmailVerificationToken(raw string) (*token.VerificationClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseEmailVerificationToken", raw)
+	ret0, _ := ret[0].(*token.VerificationClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseEmailVerificationToken indicates an expected call of ParseEmailVerificationToken.
+func (mr *MocktokenServiceMockRecorder) ParseEmailVerificationToken(raw any) *MocktokenServiceParseEmailVerificationTokenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseEmailVerificationToken", reflect.TypeOf((*MocktokenService)(nil).ParseEmailVerificationToken), raw)
+	return &MocktokenServiceParseEmailVerificationTokenCall{Call: call}
+}
+
+// MocktokenServiceParseEmailVerificationTokenCall wrap *gomock.Call
+type MocktokenServiceParseEmailVerificationTokenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenServiceParseEmailVerificationTokenCall) Return(arg0 *token.VerificationClaims, arg1 error) *MocktokenServiceParseEmailVerificationTokenCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenServiceParseEmailVerificationTokenCall) Do(f func(string) (*token.VerificationClaims, error)) *MocktokenServiceParseEmailVerificationTokenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenServiceParseEmailVerificationTokenCall) DoAndReturn(f func(string) (*token.VerificationClaims, error)) *MocktokenServiceParseEmailVerificationTokenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,148 @@
+package verification_test
+
+import (
+	"context"
+	"testing"
+
+	"echo-app/internal/config"
+	"echo-app/internal/mailer"
+	"echo-app/internal/models"
+	"echo-app/internal/services/token"
+	"echo-app/internal/services/verification"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type mailerStub struct {
+	messages []mailer.Message
+}
+
+func (m *mailerStub) Send(_ context.Context, message mailer.Message) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+type verificationMocks struct {
+	userRepository *MockuserRepository
+	tokens         *MocktokenService
+	mailer         *mailerStub
+}
+
+func newService(t *testing.T) (*verification.Service, verificationMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mocks := verificationMocks{
+		userRepository: NewMockuserRepository(ctrl),
+		tokens:         NewMocktokenService(ctrl),
+		mailer:         &mailerStub{},
+	}
+
+	verificationService := verification.NewService(
+		mocks.userRepository,
+		mocks.tokens,
+		mocks.mailer,
+		config.Mail{From: "no-reply@localhost", VerificationURL: "http://localhost/verify-email"},
+	)
+
+	return verificationService, mocks
+}
+
+func TestService_Send(t *testing.T) {
+	verificationService, mocks := newService(t)
+
+	user := &models.User{Model: gorm.Model{ID: 3}, Email: "example@email.com"}
+
+	mocks.userRepository.
+		EXPECT().
+		Update(gomock.Any(), user).
+		DoAndReturn(func(_ context.Context, got *models.User) error {
+			assert.NotEmpty(t, got.VerificationTokenID)
+			return nil
+		})
+
+	mocks.tokens.
+		EXPECT().
+		CreateEmailVerificationToken(user).
+		Return("verification-token", nil)
+
+	err := verificationService.Send(t.Context(), user)
+	require.NoError(t, err)
+
+	require.Len(t, mocks.mailer.messages, 1)
+	assert.Equal(t, "example@email.com", mocks.mailer.messages[0].To)
+	assert.Contains(t, mocks.mailer.messages[0].Body, "http://localhost/verify-email?token=verification-token")
+}
+
+func TestService_Verify(t *testing.T) {
+	claims := &token.VerificationClaims{
+		UserID:           3,
+		Email:            "example@email.com",
+		RegisteredClaims: jwt.RegisteredClaims{ID: "token-id"},
+	}
+
+	t.Run("It should verify the email", func(t *testing.T) {
+		verificationService, mocks := newService(t)
+
+		mocks.tokens.
+			EXPECT().
+			ParseEmailVerificationToken("verification-token").
+			Return(claims, nil)
+
+		mocks.userRepository.
+			EXPECT().
+			VerifyEmail(gomock.Any(), uint(3), "token-id", "example@email.com", gomock.Any()).
+			Return(true, nil)
+
+		err := verificationService.Verify(t.Context(), "verification-token")
+		require.NoError(t, err)
+	})
+
+	t.Run("It should reject a used or superseded token", func(t *testing.T) {
+		verificationService, mocks := newService(t)
+
+		mocks.tokens.
+			EXPECT().
+			ParseEmailVerificationToken("verification-token").
+			Return(claims, nil)
+
+		mocks.userRepository.
+			EXPECT().
+			VerifyEmail(gomock.Any(), uint(3), "token-id", "example@email.com", gomock.Any()).
+			Return(false, nil)
+
+		err := verificationService.Verify(t.Context(), "verification-token")
+		assert.ErrorIs(t, err, verification.ErrInvalidToken)
+	})
+
+	t.Run("It should reject a token without an ID", func(t *testing.T) {
+		verificationService, mocks := newService(t)
+
+		mocks.tokens.
+			EXPECT().
+			ParseEmailVerificationToken("verification-token").
+			Return(&token.VerificationClaims{UserID: 3, Email: "example@email.com"}, nil)
+
+		err := verificationService.Verify(t.Context(), "verification-token")
+		assert.ErrorIs(t, err, verification.ErrInvalidToken)
+	})
+}
+
+func TestService_Resend(t *testing.T) {
+	t.Run("It should ignore unknown emails", func(t *testing.T) {
+		verificationService, mocks := newService(t)
+
+		mocks.userRepository.
+			EXPECT().
+			GetUserByEmail(gomock.Any(), "unknown@email.com").
+			Return(models.User{}, models.ErrUserNotFound)
+
+		err := verificationService.Resend(t.Context(), "unknown@email.com")
+		require.NoError(t, err)
+		assert.Empty(t, mocks.mailer.messages)
+	})
+}
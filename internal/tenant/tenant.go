@@ -0,0 +1,29 @@
+// Package tenant carries the domain a request works in, so data access can be scoped to it.
+package tenant
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrNoDomain = errors.New("no domain in context")
+
+type domainKey struct{}
+
+// WithDomain returns a context scoped to the domain.
+func WithDomain(ctx context.Context, domainID uuid.UUID) context.Context {
+	return context.WithValue(ctx, domainKey{}, domainID)
+}
+
+// DomainID returns the domain the context is scoped to, it fails with ErrNoDomain for unscoped contexts,
+// so callers never fall back to unscoped data access.
+func DomainID(ctx context.Context) (uuid.UUID, error) {
+	domainID, ok := ctx.Value(domainKey{}).(uuid.UUID)
+	if !ok || domainID == uuid.Nil {
+		return uuid.Nil, ErrNoDomain
+	}
+
+	return domainID, nil
+}
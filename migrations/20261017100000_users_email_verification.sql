@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+ADD COLUMN email_verified_at TIMESTAMP,
+ADD COLUMN verification_token_id VARCHAR(64);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Accounts created before verification existed are treated as verified.
+UPDATE users SET email_verified_at = created_at;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+DROP COLUMN verification_token_id,
+DROP COLUMN email_verified_at;
+-- +goose StatementEnd
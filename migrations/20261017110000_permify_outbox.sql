@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE permify_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    operation VARCHAR(16) NOT NULL,
+    entity_type VARCHAR(64) NOT NULL,
+    entity_id VARCHAR(255) NOT NULL,
+    relation VARCHAR(64) NOT NULL DEFAULT '',
+    subject_type VARCHAR(64) NOT NULL DEFAULT '',
+    subject_id VARCHAR(255) NOT NULL DEFAULT '',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    snap_token TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    processed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX permify_outbox_pending_idx ON permify_outbox (entity_type, entity_id, id) WHERE processed_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE permify_outbox;
+-- +goose StatementEnd
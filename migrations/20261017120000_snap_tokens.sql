@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts ADD COLUMN snap_token TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE domains ADD COLUMN snap_token TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE domains DROP COLUMN snap_token;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE posts DROP COLUMN snap_token;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts ADD COLUMN domain_id UUID REFERENCES domains(id) ON DELETE CASCADE;
+-- +goose StatementEnd
+
+-- Posts of authors without a domain can't be assigned to one, they stay invisible to domain scoped queries
+-- +goose StatementBegin
+UPDATE posts SET domain_id = users.domain_id FROM users WHERE users.id = posts.user_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX posts_domain_id_idx ON posts (domain_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE posts DROP COLUMN domain_id;
+-- +goose StatementEnd
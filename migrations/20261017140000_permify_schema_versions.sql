@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE permify_schema_versions (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(128) NOT NULL,
+    hash CHAR(64) NOT NULL,
+    version VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX permify_schema_versions_tenant_idx ON permify_schema_versions (tenant_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE permify_schema_versions;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- The authors of the posts were admins of the posts in the previous Permify schema, they are the owners now.
+-- The stale admin tuples are left in Permify, the schema doesn't refer to them anymore.
+-- +goose StatementBegin
+INSERT INTO permify_outbox (operation, entity_type, entity_id, relation, subject_type, subject_id)
+SELECT 'write', 'post', posts.id::TEXT, 'owner', 'user', posts.user_id::TEXT
+FROM posts
+WHERE posts.deleted_at IS NULL
+ORDER BY posts.id;
+-- +goose StatementEnd
+
+-- Admins of the domain inherit edit on the posts through the parent relation, posts backfilled with a domain miss it
+-- +goose StatementBegin
+INSERT INTO permify_outbox (operation, entity_type, entity_id, relation, subject_type, subject_id)
+SELECT 'write', 'post', posts.id::TEXT, 'parent', 'domain', posts.domain_id::TEXT
+FROM posts
+WHERE posts.deleted_at IS NULL AND posts.domain_id IS NOT NULL
+ORDER BY posts.id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- The relationships stay in Permify, the previous schema ignores the owner relation.
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE refresh_tokens ADD COLUMN oidc_session_id VARCHAR(255) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX refresh_tokens_oidc_session_id_idx ON refresh_tokens (oidc_session_id) WHERE oidc_session_id <> '';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX refresh_tokens_user_id_idx ON refresh_tokens (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX refresh_tokens_user_id_idx;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE refresh_tokens DROP COLUMN oidc_session_id;
+-- +goose StatementEnd
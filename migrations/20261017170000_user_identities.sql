@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_identities (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    provider VARCHAR(64) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, subject),
+    UNIQUE (user_id, provider),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- The users migrations never created the oidc_subject column the user model used to have,
+-- only databases migrated by GORM have it. Its subjects belong to the provider of the OIDC_ISSUER variables.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'users' AND column_name = 'oidc_subject'
+    ) THEN
+        INSERT INTO user_identities (user_id, provider, subject)
+        SELECT id, 'default', oidc_subject FROM users WHERE oidc_subject IS NOT NULL AND oidc_subject <> '';
+
+        ALTER TABLE users DROP COLUMN oidc_subject;
+    END IF;
+END $$;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE user_identities;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Posts of authors without a domain couldn't be assigned to one when the domains were introduced and nobody can see them.
+-- They are deleted together with their Permify relationships, so every post belongs to a domain.
+-- +goose StatementBegin
+INSERT INTO permify_outbox (operation, entity_type, entity_id)
+SELECT 'delete', 'post', posts.id::TEXT
+FROM posts
+WHERE posts.domain_id IS NULL
+ORDER BY posts.id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DELETE FROM posts WHERE domain_id IS NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE posts ALTER COLUMN domain_id SET NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE posts ALTER COLUMN domain_id DROP NOT NULL;
+-- +goose StatementEnd
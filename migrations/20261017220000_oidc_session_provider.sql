@@ -0,0 +1,52 @@
+-- +goose Up
+-- Session IDs are only unique per identity provider. Rows from before keep an empty provider: back-channel logout
+-- with a session ID doesn't reach them anymore, logout tokens with the subject still revoke them.
+-- +goose StatementBegin
+ALTER TABLE sessions ADD COLUMN oidc_provider VARCHAR(64) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX sessions_oidc_session_id_idx;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX sessions_oidc_session_idx ON sessions (oidc_provider, oidc_session_id) WHERE oidc_session_id <> '';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE refresh_tokens ADD COLUMN oidc_provider VARCHAR(64) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX refresh_tokens_oidc_session_id_idx;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX refresh_tokens_oidc_session_idx ON refresh_tokens (oidc_provider, oidc_session_id) WHERE oidc_session_id <> '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- Restores the session ID indexes of before, back-channel logout matches session IDs of every provider again.
+-- +goose StatementBegin
+DROP INDEX refresh_tokens_oidc_session_idx;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE refresh_tokens DROP COLUMN oidc_provider;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX refresh_tokens_oidc_session_id_idx ON refresh_tokens (oidc_session_id) WHERE oidc_session_id <> '';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX sessions_oidc_session_idx;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE sessions DROP COLUMN oidc_provider;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX sessions_oidc_session_id_idx ON sessions (oidc_session_id) WHERE oidc_session_id <> '';
+-- +goose StatementEnd
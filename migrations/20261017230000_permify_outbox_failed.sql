@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE permify_outbox ADD COLUMN failed_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX permify_outbox_pending_idx;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX permify_outbox_pending_idx ON permify_outbox (entity_type, entity_id, id)
+    WHERE processed_at IS NULL AND failed_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX permify_outbox_pending_idx;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE permify_outbox DROP COLUMN failed_at;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX permify_outbox_pending_idx ON permify_outbox (entity_type, entity_id, id) WHERE processed_at IS NULL;
+-- +goose StatementEnd
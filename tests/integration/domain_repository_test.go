@@ -0,0 +1,95 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"echo-app/internal/models"
+	"echo-app/internal/repositories"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainRepository(t *testing.T) {
+	domainRepository := repositories.NewDomainRepository(gormDB)
+
+	newDomain := &models.Domain{Name: "domain_repository"}
+
+	t.Run("It should create a domain", func(t *testing.T) {
+		err := domainRepository.Create(t.Context(), newDomain)
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, newDomain.ID)
+	})
+
+	t.Run("It should reject a taken name", func(t *testing.T) {
+		err := domainRepository.Create(t.Context(), &models.Domain{Name: "domain_repository"})
+		assert.ErrorIs(t, err, models.ErrDomainNameTaken)
+	})
+
+	t.Run("It should fetch domains by ids", func(t *testing.T) {
+		gotDomains, err := domainRepository.GetByIDs(t.Context(), []uuid.UUID{newDomain.ID, uuid.New()})
+		require.NoError(t, err)
+
+		require.Len(t, gotDomains, 1)
+		assert.Equal(t, "domain_repository", gotDomains[0].Name)
+	})
+
+	t.Run("It should rename the domain", func(t *testing.T) {
+		newDomain.Name = "domain_repository_renamed"
+
+		err := domainRepository.Update(t.Context(), newDomain)
+		require.NoError(t, err)
+
+		gotDomain, err := domainRepository.GetByID(t.Context(), newDomain.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "domain_repository_renamed", gotDomain.Name)
+	})
+
+	t.Run("It should lock the domain until the end of the transaction", func(t *testing.T) {
+		err := repositories.NewTransactor(gormDB).Transaction(t.Context(), func(ctx context.Context) error {
+			gotDomain, err := domainRepository.GetForUpdate(ctx, newDomain.ID)
+			require.NoError(t, err)
+			assert.Equal(t, newDomain.ID, gotDomain.ID)
+
+			var id uuid.UUID
+			err = gormDB.Raw("SELECT id FROM domains WHERE id = ? FOR UPDATE NOWAIT", newDomain.ID).Scan(&id).Error
+			assert.Error(t, err)
+
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("It should delete the domain with its posts and detach its users", func(t *testing.T) {
+		user := &models.User{
+			Email:    "domain_repository@email.com",
+			Name:     "domain_repository",
+			Password: "domain_repository",
+			DomainID: &newDomain.ID,
+		}
+		require.NoError(t, gormDB.Create(user).Error)
+
+		post := &models.Post{Title: "domain_repository", UserID: user.ID, DomainID: newDomain.ID}
+		require.NoError(t, gormDB.Create(post).Error)
+
+		postIDs, err := domainRepository.GetPostIDs(t.Context(), newDomain.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{post.ID}, postIDs)
+
+		err = domainRepository.Delete(t.Context(), newDomain)
+		require.NoError(t, err)
+
+		_, err = domainRepository.GetByID(t.Context(), newDomain.ID)
+		require.ErrorIs(t, err, models.ErrDomainNotFound)
+
+		var gotUser models.User
+		require.NoError(t, gormDB.Take(&gotUser, user.ID).Error)
+		assert.Nil(t, gotUser.DomainID)
+
+		var remaining int64
+		require.NoError(t, gormDB.Unscoped().Model(&models.Post{}).Where("id = ?", post.ID).Count(&remaining).Error)
+		assert.Zero(t, remaining)
+	})
+}
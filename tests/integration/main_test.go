@@ -13,10 +13,14 @@ import (
 	"echo-app/internal/db"
 	"echo-app/tests/setup"
 
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
-var gormDB *gorm.DB
+var (
+	gormDB      *gorm.DB
+	redisClient *redis.Client
+)
 
 func TestMain(m *testing.M) {
 	ctx := context.Background()
@@ -70,7 +74,7 @@ func setupMain(ctx context.Context) (_ func(context.Context) error, err error) {
 
 	shutdownCallbacks = append(shutdownCallbacks, postgresShutdown)
 
-	gormDB, err = db.NewGormDB(config.DBConfig{
+	gormDB, err = db.NewGormDB(config.DB{
 		User:     postgresConfig.User,
 		Password: postgresConfig.Password,
 		Name:     postgresConfig.Name,
@@ -98,5 +102,25 @@ func setupMain(ctx context.Context) (_ func(context.Context) error, err error) {
 		return nil
 	})
 
+	redisAddr, redisShutdown, err := setup.SetupRedis(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("setup redis: %w", err)
+	}
+
+	shutdownCallbacks = append(shutdownCallbacks, redisShutdown)
+
+	redisClient, err = db.NewRedisClient(ctx, config.Redis{Addr: redisAddr})
+	if err != nil {
+		return nil, fmt.Errorf("new redis client: %w", err)
+	}
+
+	shutdownCallbacks = append(shutdownCallbacks, func(context.Context) error {
+		if err := redisClient.Close(); err != nil {
+			return fmt.Errorf("close redis client: %w", err)
+		}
+
+		return nil
+	})
+
 	return shutdown, nil
 }
@@ -0,0 +1,78 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"echo-app/internal/models"
+	"echo-app/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMFARecoveryCodeRepository(t *testing.T) {
+	recoveryCodeRepository := repositories.NewMFARecoveryCodeRepository(gormDB)
+
+	user := &models.User{
+		Email:    "mfa_recovery_code_repository@email.com",
+		Name:     "mfa_recovery_code_repository",
+		Password: "mfa_recovery_code_repository",
+	}
+
+	err := gormDB.Create(user).Error
+	require.NoError(t, err)
+
+	t.Run("It should store the recovery codes of the user", func(t *testing.T) {
+		err := recoveryCodeRepository.Replace(t.Context(), user.ID, []models.MFARecoveryCode{
+			{UserID: user.ID, CodeHash: "first-recovery-code-hash"},
+			{UserID: user.ID, CodeHash: "second-recovery-code-hash"},
+		})
+		require.NoError(t, err)
+
+		codes, err := recoveryCodeRepository.ListUnused(t.Context(), user.ID)
+		require.NoError(t, err)
+
+		require.Len(t, codes, 2)
+		assert.Equal(t, "first-recovery-code-hash", codes[0].CodeHash)
+		assert.Equal(t, "second-recovery-code-hash", codes[1].CodeHash)
+	})
+
+	t.Run("It should mark a code as used only once", func(t *testing.T) {
+		codes, err := recoveryCodeRepository.ListUnused(t.Context(), user.ID)
+		require.NoError(t, err)
+		require.NotEmpty(t, codes)
+
+		marked, err := recoveryCodeRepository.MarkUsed(t.Context(), codes[0].ID, time.Now())
+		require.NoError(t, err)
+		assert.True(t, marked)
+
+		marked, err = recoveryCodeRepository.MarkUsed(t.Context(), codes[0].ID, time.Now())
+		require.NoError(t, err)
+		assert.False(t, marked)
+
+		unused, err := recoveryCodeRepository.ListUnused(t.Context(), user.ID)
+		require.NoError(t, err)
+
+		require.Len(t, unused, 1)
+		assert.Equal(t, codes[1].ID, unused[0].ID)
+	})
+
+	t.Run("It should replace the previous codes", func(t *testing.T) {
+		err := recoveryCodeRepository.Replace(t.Context(), user.ID, []models.MFARecoveryCode{
+			{UserID: user.ID, CodeHash: "new-recovery-code-hash"},
+		})
+		require.NoError(t, err)
+
+		codes, err := recoveryCodeRepository.ListUnused(t.Context(), user.ID)
+		require.NoError(t, err)
+
+		require.Len(t, codes, 1)
+		assert.Equal(t, "new-recovery-code-hash", codes[0].CodeHash)
+
+		var total int64
+		err = gormDB.Model(&models.MFARecoveryCode{}).Where("user_id = ?", user.ID).Count(&total).Error
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+	})
+}
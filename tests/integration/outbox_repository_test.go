@@ -0,0 +1,165 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"echo-app/internal/models"
+	"echo-app/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRepository(t *testing.T) {
+	outboxRepository := repositories.NewOutboxRepository(gormDB)
+	transactor := repositories.NewTransactor(gormDB)
+
+	now := time.Now().UTC()
+
+	t.Run("It should not enqueue entries of a rolled back transaction", func(t *testing.T) {
+		errRollback := errors.New("rollback")
+
+		err := transactor.Transaction(t.Context(), func(ctx context.Context) error {
+			_, err := outboxRepository.Enqueue(ctx, models.OutboxEntry{
+				Operation:     models.OutboxWrite,
+				EntityType:    "post",
+				EntityID:      "rolled-back",
+				NextAttemptAt: now,
+			})
+			require.NoError(t, err)
+
+			return errRollback
+		})
+		require.ErrorIs(t, err, errRollback)
+
+		entries, err := outboxRepository.LockPending(t.Context(), now, 100, nil)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("It should lock only the first pending entry of an entity", func(t *testing.T) {
+		ids, err := outboxRepository.Enqueue(t.Context(),
+			models.OutboxEntry{Operation: models.OutboxWrite, EntityType: "post", EntityID: "1", NextAttemptAt: now},
+			models.OutboxEntry{Operation: models.OutboxDelete, EntityType: "post", EntityID: "1", NextAttemptAt: now},
+			models.OutboxEntry{Operation: models.OutboxWrite, EntityType: "post", EntityID: "2", NextAttemptAt: now.Add(time.Hour)},
+		)
+		require.NoError(t, err)
+		require.Len(t, ids, 3)
+
+		entries, err := outboxRepository.LockPending(t.Context(), now, 100, nil)
+		require.NoError(t, err)
+
+		require.Len(t, entries, 1)
+		assert.Equal(t, ids[0], entries[0].ID)
+		assert.Equal(t, models.OutboxWrite, entries[0].Operation)
+		assert.Equal(t, "1", entries[0].EntityID)
+
+		processedAt := now
+		entries[0].ProcessedAt = &processedAt
+		err = outboxRepository.Update(t.Context(), &entries[0])
+		require.NoError(t, err)
+
+		entries, err = outboxRepository.LockPending(t.Context(), now, 100, nil)
+		require.NoError(t, err)
+
+		require.Len(t, entries, 1)
+		assert.Equal(t, models.OutboxDelete, entries[0].Operation)
+	})
+
+	t.Run("It should list the pending entries of an entity including the ones not due yet", func(t *testing.T) {
+		entries, err := outboxRepository.ListPending(t.Context(), "post", "1")
+		require.NoError(t, err)
+
+		require.Len(t, entries, 1)
+		assert.Equal(t, models.OutboxDelete, entries[0].Operation)
+
+		entries, err = outboxRepository.ListPending(t.Context(), "post", "2")
+		require.NoError(t, err)
+
+		require.Len(t, entries, 1)
+		assert.Equal(t, models.OutboxWrite, entries[0].Operation)
+	})
+
+	t.Run("It should lock only the entries with the IDs", func(t *testing.T) {
+		ids, err := outboxRepository.Enqueue(t.Context(),
+			models.OutboxEntry{Operation: models.OutboxWrite, EntityType: "post", EntityID: "3", NextAttemptAt: now},
+			models.OutboxEntry{Operation: models.OutboxWrite, EntityType: "post", EntityID: "4", NextAttemptAt: now},
+		)
+		require.NoError(t, err)
+
+		entries, err := outboxRepository.LockPending(t.Context(), now, 100, ids[1:])
+		require.NoError(t, err)
+
+		require.Len(t, entries, 1)
+		assert.Equal(t, "4", entries[0].EntityID)
+	})
+
+	t.Run("It should skip leased entries until the lease ends", func(t *testing.T) {
+		ids, err := outboxRepository.Enqueue(t.Context(),
+			models.OutboxEntry{Operation: models.OutboxWrite, EntityType: "post", EntityID: "5", NextAttemptAt: now},
+		)
+		require.NoError(t, err)
+
+		err = outboxRepository.Lease(t.Context(), ids, now.Add(time.Minute))
+		require.NoError(t, err)
+
+		entries, err := outboxRepository.LockPending(t.Context(), now, 100, ids)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+
+		entries, err = outboxRepository.LockPending(t.Context(), now.Add(time.Minute), 100, ids)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("It should not hold back the entity on a given up entry", func(t *testing.T) {
+		ids, err := outboxRepository.Enqueue(t.Context(),
+			models.OutboxEntry{Operation: models.OutboxWrite, EntityType: "post", EntityID: "6", NextAttemptAt: now},
+			models.OutboxEntry{Operation: models.OutboxDelete, EntityType: "post", EntityID: "6", NextAttemptAt: now},
+		)
+		require.NoError(t, err)
+
+		entries, err := outboxRepository.LockPending(t.Context(), now, 100, ids)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+
+		failedAt := now
+		entries[0].FailedAt = &failedAt
+		err = outboxRepository.Update(t.Context(), &entries[0])
+		require.NoError(t, err)
+
+		entries, err = outboxRepository.LockPending(t.Context(), now, 100, ids)
+		require.NoError(t, err)
+
+		require.Len(t, entries, 1)
+		assert.Equal(t, ids[1], entries[0].ID)
+
+		entries, err = outboxRepository.ListPending(t.Context(), "post", "6")
+		require.NoError(t, err)
+
+		require.Len(t, entries, 1)
+		assert.Equal(t, ids[1], entries[0].ID)
+	})
+
+	t.Run("It should store the snap token on the post", func(t *testing.T) {
+		user := &models.User{Email: "outbox_repository@email.com", Name: "outbox_repository", Password: "outbox_repository"}
+		require.NoError(t, gormDB.Create(user).Error)
+
+		domain := &models.Domain{Name: "outbox_repository"}
+		require.NoError(t, gormDB.Create(domain).Error)
+
+		post := &models.Post{Title: "title", Content: "content", UserID: user.ID, DomainID: domain.ID}
+		require.NoError(t, gormDB.Create(post).Error)
+
+		err := outboxRepository.SetSnapToken(t.Context(), "post", strconv.FormatUint(uint64(post.ID), 10), "snap-token")
+		require.NoError(t, err)
+
+		var gotPost models.Post
+		require.NoError(t, gormDB.Take(&gotPost, post.ID).Error)
+		assert.Equal(t, "snap-token", gotPost.SnapToken)
+	})
+}
@@ -0,0 +1,106 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"echo-app/internal/models"
+	"echo-app/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersonalAccessTokenRepository(t *testing.T) {
+	tokenRepository := repositories.NewPersonalAccessTokenRepository(gormDB)
+
+	user := &models.User{
+		Email:    "personal_access_token_repository@email.com",
+		Name:     "personal_access_token_repository",
+		Password: "personal_access_token_repository",
+	}
+
+	err := gormDB.Create(user).Error
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	older := &models.PersonalAccessToken{
+		UserID:    user.ID,
+		Name:      "deploy",
+		TokenHash: "older-personal-access-token-hash",
+		Scopes:    []string{models.ScopeDomainsRead},
+		ExpiresAt: now.Add(-time.Hour),
+		CreatedAt: now.Add(-time.Minute),
+	}
+
+	newer := &models.PersonalAccessToken{
+		UserID:    user.ID,
+		Name:      "ci",
+		TokenHash: "newer-personal-access-token-hash",
+		Scopes:    []string{models.ScopePostsRead, models.ScopePostsWrite},
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now,
+	}
+
+	t.Run("It should create personal access tokens", func(t *testing.T) {
+		for _, token := range []*models.PersonalAccessToken{older, newer} {
+			err := tokenRepository.Create(t.Context(), token)
+			require.NoError(t, err)
+			assert.NotZero(t, token.ID)
+		}
+	})
+
+	t.Run("It should fetch the token by hash with its scopes", func(t *testing.T) {
+		gotToken, err := tokenRepository.GetByHash(t.Context(), "newer-personal-access-token-hash")
+		require.NoError(t, err)
+
+		assert.Equal(t, newer.ID, gotToken.ID)
+		assert.Equal(t, []string{models.ScopePostsRead, models.ScopePostsWrite}, gotToken.Scopes)
+		assert.Nil(t, gotToken.LastUsedAt)
+	})
+
+	t.Run("It should return an error if the token is not found", func(t *testing.T) {
+		_, err := tokenRepository.GetByHash(t.Context(), "unknown-hash")
+		assert.ErrorIs(t, err, models.ErrPersonalAccessTokenNotFound)
+	})
+
+	t.Run("It should list the unrevoked tokens including expired ones, the newest first", func(t *testing.T) {
+		tokens, err := tokenRepository.ListUnrevoked(t.Context(), user.ID)
+		require.NoError(t, err)
+
+		require.Len(t, tokens, 2)
+		assert.Equal(t, newer.ID, tokens[0].ID)
+		assert.Equal(t, older.ID, tokens[1].ID)
+	})
+
+	t.Run("It should record the last use of the token", func(t *testing.T) {
+		err := tokenRepository.Touch(t.Context(), newer.ID, now)
+		require.NoError(t, err)
+
+		gotToken, err := tokenRepository.GetByHash(t.Context(), "newer-personal-access-token-hash")
+		require.NoError(t, err)
+
+		require.NotNil(t, gotToken.LastUsedAt)
+		assert.True(t, now.Equal(*gotToken.LastUsedAt))
+	})
+
+	t.Run("It should not revoke the token of another user", func(t *testing.T) {
+		err := tokenRepository.Revoke(t.Context(), user.ID+1, newer.ID, now)
+		assert.ErrorIs(t, err, models.ErrPersonalAccessTokenNotFound)
+	})
+
+	t.Run("It should revoke the token only once", func(t *testing.T) {
+		err := tokenRepository.Revoke(t.Context(), user.ID, newer.ID, now)
+		require.NoError(t, err)
+
+		err = tokenRepository.Revoke(t.Context(), user.ID, newer.ID, now)
+		assert.ErrorIs(t, err, models.ErrPersonalAccessTokenNotFound)
+
+		tokens, err := tokenRepository.ListUnrevoked(t.Context(), user.ID)
+		require.NoError(t, err)
+
+		require.Len(t, tokens, 1)
+		assert.Equal(t, older.ID, tokens[0].ID)
+	})
+}
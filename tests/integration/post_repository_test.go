@@ -5,6 +5,7 @@ import (
 
 	"echo-app/internal/models"
 	"echo-app/internal/repositories"
+	"echo-app/internal/tenant"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,6 +27,17 @@ func TestPostRepository(t *testing.T) {
 	user, err := userRepository.GetUserByEmail(t.Context(), "example_user_email@email.com")
 	require.NotNil(t, user)
 
+	domain := &models.Domain{Name: "post_repository_domain"}
+	err = gormDB.Create(domain).Error
+	require.NoError(t, err)
+
+	otherDomain := &models.Domain{Name: "post_repository_other_domain"}
+	err = gormDB.Create(otherDomain).Error
+	require.NoError(t, err)
+
+	ctx := tenant.WithDomain(t.Context(), domain.ID)
+	otherCtx := tenant.WithDomain(t.Context(), otherDomain.ID)
+
 	newPost := &models.Post{
 		Title:   "Post title",
 		Content: "Post content",
@@ -33,13 +45,14 @@ func TestPostRepository(t *testing.T) {
 	}
 
 	t.Run("It should create a post", func(t *testing.T) {
-		err := postRepository.Create(t.Context(), newPost)
+		err := postRepository.Create(ctx, newPost)
 		require.NoError(t, err)
 		assert.NotZero(t, newPost.ID)
+		assert.Equal(t, domain.ID, newPost.DomainID)
 	})
 
 	t.Run("It should fetch created post", func(t *testing.T) {
-		gotPost, err := postRepository.GetPost(t.Context(), newPost.ID)
+		gotPost, err := postRepository.GetPost(ctx, newPost.ID)
 		require.NoError(t, err)
 
 		newPost.CreatedAt = gotPost.CreatedAt
@@ -49,24 +62,45 @@ func TestPostRepository(t *testing.T) {
 	})
 
 	t.Run("It should return an error if post not found", func(t *testing.T) {
-		_, err := postRepository.GetPost(t.Context(), 999)
+		_, err := postRepository.GetPost(ctx, 999)
 		assert.ErrorIs(t, err, models.ErrPostNotFound)
 	})
 
 	t.Run("It should fetch all posts", func(t *testing.T) {
-		posts, err := postRepository.GetPosts(t.Context())
+		posts, err := postRepository.GetPosts(ctx)
 		require.NoError(t, err)
 		require.Len(t, posts, 1)
 		assert.Equal(t, *newPost, posts[0])
 	})
 
+	t.Run("It should fetch posts by IDs", func(t *testing.T) {
+		posts, err := postRepository.GetPostsByIDs(ctx, []uint{newPost.ID, 999})
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, *newPost, posts[0])
+
+		posts, err = postRepository.GetPostsByIDs(otherCtx, []uint{newPost.ID})
+		require.NoError(t, err)
+		assert.Empty(t, posts)
+	})
+
+	t.Run("It should count the posts of the domain", func(t *testing.T) {
+		count, err := postRepository.CountPosts(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		count, err = postRepository.CountPosts(otherCtx)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
 	t.Run("It should update post", func(t *testing.T) {
 		newPost.Title = "New post title"
 		newPost.Content = "New post content"
-		err := postRepository.Update(t.Context(), newPost)
+		err := postRepository.Update(ctx, newPost)
 		require.NoError(t, err)
 
-		gotPost, err := postRepository.GetPost(t.Context(), newPost.ID)
+		gotPost, err := postRepository.GetPost(ctx, newPost.ID)
 		require.NoError(t, err)
 
 		newPost.UpdatedAt = gotPost.UpdatedAt
@@ -74,13 +108,36 @@ func TestPostRepository(t *testing.T) {
 		assert.Equal(t, *newPost, gotPost)
 	})
 
+	t.Run("It should not expose the post to other domains", func(t *testing.T) {
+		_, err := postRepository.GetPost(otherCtx, newPost.ID)
+		require.ErrorIs(t, err, models.ErrPostNotFound)
+
+		posts, err := postRepository.GetPosts(otherCtx)
+		require.NoError(t, err)
+		assert.Empty(t, posts)
+
+		err = postRepository.Update(otherCtx, &models.Post{Model: newPost.Model, Title: "Foreign title"})
+		require.ErrorIs(t, err, models.ErrPostNotFound)
+
+		err = postRepository.Delete(otherCtx, &models.Post{Model: newPost.Model})
+		require.ErrorIs(t, err, models.ErrPostNotFound)
+	})
+
+	t.Run("It should refuse queries without a domain", func(t *testing.T) {
+		_, err := postRepository.GetPosts(t.Context())
+		require.ErrorIs(t, err, tenant.ErrNoDomain)
+
+		err = postRepository.Create(t.Context(), &models.Post{Title: "title", Content: "content", UserID: user.ID})
+		require.ErrorIs(t, err, tenant.ErrNoDomain)
+	})
+
 	t.Run("It should delete post", func(t *testing.T) {
 		id := newPost.ID
 
-		err := postRepository.Delete(t.Context(), newPost)
+		err := postRepository.Delete(ctx, newPost)
 		require.NoError(t, err)
 
-		_, err = postRepository.GetPost(t.Context(), id)
+		_, err = postRepository.GetPost(ctx, id)
 		assert.ErrorIs(t, err, models.ErrPostNotFound)
 	})
 }
@@ -0,0 +1,112 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"echo-app/internal/models"
+	"echo-app/internal/repositories"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshTokenRepository(t *testing.T) {
+	refreshTokenRepository := repositories.NewRefreshTokenRepository(gormDB)
+
+	user := &models.User{
+		Email:    "refresh_token_repository@email.com",
+		Name:     "refresh_token_repository",
+		Password: "refresh_token_repository",
+	}
+
+	err := gormDB.Create(user).Error
+	require.NoError(t, err)
+
+	newToken := &models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  uuid.New(),
+		TokenHash: "refresh-token-hash",
+		ExpiresAt: time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond),
+	}
+
+	t.Run("It should create a refresh token", func(t *testing.T) {
+		err := refreshTokenRepository.Create(t.Context(), newToken)
+		require.NoError(t, err)
+		assert.NotZero(t, newToken.ID)
+	})
+
+	t.Run("It should fetch the token by hash", func(t *testing.T) {
+		gotToken, err := refreshTokenRepository.GetByHash(t.Context(), "refresh-token-hash")
+		require.NoError(t, err)
+
+		assert.Equal(t, newToken.ID, gotToken.ID)
+		assert.Equal(t, newToken.FamilyID, gotToken.FamilyID)
+	})
+
+	t.Run("It should return an error if token not found", func(t *testing.T) {
+		_, err := refreshTokenRepository.GetByHash(t.Context(), "unknown-hash")
+		assert.ErrorIs(t, err, models.ErrRefreshTokenNotFound)
+	})
+
+	t.Run("It should mark the token as used only once", func(t *testing.T) {
+		marked, err := refreshTokenRepository.MarkUsed(t.Context(), newToken.ID, time.Now())
+		require.NoError(t, err)
+		assert.True(t, marked)
+
+		marked, err = refreshTokenRepository.MarkUsed(t.Context(), newToken.ID, time.Now())
+		require.NoError(t, err)
+		assert.False(t, marked)
+	})
+
+	t.Run("It should revoke the token family", func(t *testing.T) {
+		err := refreshTokenRepository.RevokeFamily(t.Context(), newToken.FamilyID, time.Now())
+		require.NoError(t, err)
+
+		gotToken, err := refreshTokenRepository.GetByHash(t.Context(), "refresh-token-hash")
+		require.NoError(t, err)
+		assert.NotNil(t, gotToken.RevokedAt)
+	})
+
+	t.Run("It should revoke the token families of the OIDC session of the provider", func(t *testing.T) {
+		sessionToken := &models.RefreshToken{
+			UserID:        user.ID,
+			FamilyID:      uuid.New(),
+			TokenHash:     "oidc-session-token-hash",
+			OIDCProvider:  "google",
+			OIDCSessionID: "oidc-session",
+			ExpiresAt:     time.Now().Add(time.Hour),
+		}
+		otherToken := &models.RefreshToken{
+			UserID:        user.ID,
+			FamilyID:      uuid.New(),
+			TokenHash:     "other-session-token-hash",
+			OIDCProvider:  "github",
+			OIDCSessionID: "oidc-session",
+			ExpiresAt:     time.Now().Add(time.Hour),
+		}
+		require.NoError(t, refreshTokenRepository.Create(t.Context(), sessionToken))
+		require.NoError(t, refreshTokenRepository.Create(t.Context(), otherToken))
+
+		err := refreshTokenRepository.RevokeOIDCSession(t.Context(), "google", "oidc-session", time.Now())
+		require.NoError(t, err)
+
+		gotToken, err := refreshTokenRepository.GetByHash(t.Context(), "oidc-session-token-hash")
+		require.NoError(t, err)
+		assert.NotNil(t, gotToken.RevokedAt)
+
+		gotToken, err = refreshTokenRepository.GetByHash(t.Context(), "other-session-token-hash")
+		require.NoError(t, err)
+		assert.Nil(t, gotToken.RevokedAt)
+	})
+
+	t.Run("It should revoke every token family of the user", func(t *testing.T) {
+		err := refreshTokenRepository.RevokeUser(t.Context(), user.ID, time.Now())
+		require.NoError(t, err)
+
+		gotToken, err := refreshTokenRepository.GetByHash(t.Context(), "other-session-token-hash")
+		require.NoError(t, err)
+		assert.NotNil(t, gotToken.RevokedAt)
+	})
+}
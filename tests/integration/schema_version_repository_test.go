@@ -0,0 +1,44 @@
+package integration
+
+import (
+	"testing"
+
+	"echo-app/internal/models"
+	"echo-app/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaVersionRepository(t *testing.T) {
+	schemaVersionRepository := repositories.NewSchemaVersionRepository(gormDB)
+
+	t.Run("It should return an error for a tenant without versions", func(t *testing.T) {
+		_, err := schemaVersionRepository.Latest(t.Context(), "schema_version_repository")
+		assert.ErrorIs(t, err, models.ErrSchemaVersionNotFound)
+	})
+
+	t.Run("It should return the latest version of the tenant", func(t *testing.T) {
+		for _, version := range []string{"v1", "v2"} {
+			err := schemaVersionRepository.Create(t.Context(), &models.SchemaVersion{
+				TenantID: "schema_version_repository",
+				Hash:     "hash-" + version,
+				Version:  version,
+			})
+			require.NoError(t, err)
+		}
+
+		err := schemaVersionRepository.Create(t.Context(), &models.SchemaVersion{
+			TenantID: "schema_version_repository_other",
+			Hash:     "hash-v3",
+			Version:  "v3",
+		})
+		require.NoError(t, err)
+
+		latest, err := schemaVersionRepository.Latest(t.Context(), "schema_version_repository")
+		require.NoError(t, err)
+
+		assert.Equal(t, "v2", latest.Version)
+		assert.Equal(t, "hash-v2", latest.Hash)
+	})
+}
@@ -0,0 +1,155 @@
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"echo-app/internal/models"
+	"echo-app/internal/repositories"
+	"echo-app/internal/services/session"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionRepository(t *testing.T) {
+	testSessionStore(t, repositories.NewSessionRepository(gormDB), "session_repository@email.com")
+}
+
+func TestRedisSessionRepository(t *testing.T) {
+	testSessionStore(t, repositories.NewRedisSessionRepository(redisClient, time.Hour), "redis_session_repository@email.com")
+}
+
+// testSessionStore runs the behavior every session store shares. Stores may delete revoked sessions.
+func testSessionStore(t *testing.T, store session.Store, email string) {
+	t.Helper()
+
+	user := &models.User{Email: email, Name: "session_repository", Password: "session_repository"}
+
+	err := gormDB.Create(user).Error
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	newSession := func(lastSeenAt time.Time, oidcProvider, oidcSessionID string) *models.Session {
+		return &models.Session{
+			ID:            uuid.New(),
+			UserID:        user.ID,
+			Device:        "Firefox on Linux",
+			IP:            "203.0.113.7",
+			UserAgent:     "Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0",
+			OIDCProvider:  oidcProvider,
+			OIDCSessionID: oidcSessionID,
+			CreatedAt:     lastSeenAt,
+			LastSeenAt:    lastSeenAt,
+		}
+	}
+
+	older := newSession(now.Add(-time.Minute), "", "")
+	newer := newSession(now, "google", "oidc-session")
+	idle := newSession(now.Add(-2*time.Hour), "", "")
+
+	assertRevoked := func(t *testing.T, id uuid.UUID) {
+		t.Helper()
+
+		gotSession, err := store.Get(t.Context(), id)
+		if errors.Is(err, models.ErrSessionNotFound) {
+			return
+		}
+
+		require.NoError(t, err)
+		assert.NotNil(t, gotSession.RevokedAt)
+	}
+
+	t.Run("It should create sessions", func(t *testing.T) {
+		for _, userSession := range []*models.Session{older, newer, idle} {
+			err := store.Create(t.Context(), userSession)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("It should get the session", func(t *testing.T) {
+		gotSession, err := store.Get(t.Context(), newer.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, newer.UserID, gotSession.UserID)
+		assert.Equal(t, newer.Device, gotSession.Device)
+		assert.Equal(t, "google", gotSession.OIDCProvider)
+		assert.Equal(t, "oidc-session", gotSession.OIDCSessionID)
+		assert.Nil(t, gotSession.RevokedAt)
+	})
+
+	t.Run("It should return an error if the session is not found", func(t *testing.T) {
+		_, err := store.Get(t.Context(), uuid.New())
+		assert.ErrorIs(t, err, models.ErrSessionNotFound)
+	})
+
+	t.Run("It should list the active sessions, the last used first", func(t *testing.T) {
+		sessions, err := store.ListActive(t.Context(), user.ID, now.Add(-time.Hour))
+		require.NoError(t, err)
+
+		require.Len(t, sessions, 2)
+		assert.Equal(t, newer.ID, sessions[0].ID)
+		assert.Equal(t, older.ID, sessions[1].ID)
+	})
+
+	t.Run("It should record the activity of the session", func(t *testing.T) {
+		seenAt := now.Add(time.Minute)
+
+		err := store.Touch(t.Context(), older.ID, "198.51.100.1", seenAt)
+		require.NoError(t, err)
+
+		gotSession, err := store.Get(t.Context(), older.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, "198.51.100.1", gotSession.IP)
+		assert.True(t, seenAt.Equal(gotSession.LastSeenAt))
+	})
+
+	t.Run("It should revoke the session", func(t *testing.T) {
+		err := store.Revoke(t.Context(), older.ID, now)
+		require.NoError(t, err)
+
+		assertRevoked(t, older.ID)
+
+		sessions, err := store.ListActive(t.Context(), user.ID, now.Add(-time.Hour))
+		require.NoError(t, err)
+
+		require.Len(t, sessions, 1)
+		assert.Equal(t, newer.ID, sessions[0].ID)
+	})
+
+	t.Run("It should revoke the sessions of the OIDC session of the provider", func(t *testing.T) {
+		otherProvider := newSession(now, "github", "oidc-session")
+
+		err := store.Create(t.Context(), otherProvider)
+		require.NoError(t, err)
+
+		err = store.RevokeOIDCSession(t.Context(), "google", "oidc-session", now)
+		require.NoError(t, err)
+
+		assertRevoked(t, newer.ID)
+
+		gotSession, err := store.Get(t.Context(), otherProvider.ID)
+		require.NoError(t, err)
+		assert.Nil(t, gotSession.RevokedAt)
+	})
+
+	t.Run("It should revoke every session of the user", func(t *testing.T) {
+		active := newSession(now, "", "")
+
+		err := store.Create(t.Context(), active)
+		require.NoError(t, err)
+
+		err = store.RevokeUser(t.Context(), user.ID, now)
+		require.NoError(t, err)
+
+		assertRevoked(t, active.ID)
+
+		sessions, err := store.ListActive(t.Context(), user.ID, now.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+}
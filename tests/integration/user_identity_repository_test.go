@@ -0,0 +1,55 @@
+package integration
+
+import (
+	"testing"
+
+	"echo-app/internal/models"
+	"echo-app/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserIdentityRepository(t *testing.T) {
+	userRepository := repositories.NewUserRepository(gormDB)
+	userIdentityRepository := repositories.NewUserIdentityRepository(gormDB)
+
+	user := &models.User{
+		Email:    "user_identity_repository@email.com",
+		Name:     "user_identity_repository",
+		Password: "user_identity_repository",
+	}
+
+	err := userRepository.Create(t.Context(), user)
+	require.NoError(t, err)
+
+	t.Run("It should link identities of several providers to the user", func(t *testing.T) {
+		for _, provider := range []string{"authentik", "google"} {
+			identity := &models.UserIdentity{UserID: user.ID, Provider: provider, Subject: "subject"}
+
+			err := userIdentityRepository.Create(t.Context(), identity)
+			require.NoError(t, err)
+			assert.NotZero(t, identity.ID)
+		}
+	})
+
+	t.Run("It should reject a subject linked to another user", func(t *testing.T) {
+		otherUser := &models.User{Email: "other_user_identity_repository@email.com", Name: "other", Password: "other"}
+		require.NoError(t, userRepository.Create(t.Context(), otherUser))
+
+		err := userIdentityRepository.Create(t.Context(), &models.UserIdentity{UserID: otherUser.ID, Provider: "google", Subject: "subject"})
+		assert.Error(t, err)
+	})
+
+	t.Run("It should fetch the user by identity", func(t *testing.T) {
+		gotUser, err := userRepository.GetUserByIdentity(t.Context(), "google", "subject")
+		require.NoError(t, err)
+
+		assert.Equal(t, user.ID, gotUser.ID)
+	})
+
+	t.Run("It should return an error if the identity is not linked", func(t *testing.T) {
+		_, err := userRepository.GetUserByIdentity(t.Context(), "github", "subject")
+		assert.ErrorIs(t, err, models.ErrUserNotFound)
+	})
+}
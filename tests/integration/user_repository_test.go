@@ -1,7 +1,9 @@
 package integration
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"echo-app/internal/models"
 	"echo-app/internal/repositories"
@@ -54,4 +56,142 @@ func TestUserRepository(t *testing.T) {
 		_, err := userRepository.GetUserByEmail(t.Context(), "unknown_email@gmail.com")
 		assert.ErrorIs(t, err, models.ErrUserNotFound)
 	})
+
+	t.Run("It should fetch users by IDs", func(t *testing.T) {
+		users, err := userRepository.GetByIDs(t.Context(), []uint{newUser.ID, 999})
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+
+		assert.Equal(t, newUser.ID, users[0].ID)
+	})
+}
+
+func TestUserRepository_VerifyEmail(t *testing.T) {
+	userRepository := repositories.NewUserRepository(gormDB)
+
+	user := &models.User{
+		Email:               "verify_email_user_repository@email.com",
+		Name:                "verify_email_user_repository",
+		Password:            "verify_email_user_repository",
+		VerificationTokenID: "verification-token-id",
+	}
+
+	err := gormDB.Create(user).Error
+	require.NoError(t, err)
+
+	t.Run("It should reject a token of another email", func(t *testing.T) {
+		verified, err := userRepository.VerifyEmail(t.Context(), user.ID, "verification-token-id", "other@email.com", time.Now())
+		require.NoError(t, err)
+		assert.False(t, verified)
+	})
+
+	t.Run("It should verify the email only once", func(t *testing.T) {
+		verified, err := userRepository.VerifyEmail(t.Context(), user.ID, "verification-token-id", user.Email, time.Now())
+		require.NoError(t, err)
+		assert.True(t, verified)
+
+		verified, err = userRepository.VerifyEmail(t.Context(), user.ID, "verification-token-id", user.Email, time.Now())
+		require.NoError(t, err)
+		assert.False(t, verified)
+
+		gotUser, err := userRepository.GetByID(t.Context(), user.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, gotUser.EmailVerifiedAt)
+		assert.Empty(t, gotUser.VerificationTokenID)
+	})
+}
+
+func TestUserRepository_MFA(t *testing.T) {
+	userRepository := repositories.NewUserRepository(gormDB)
+
+	user := &models.User{
+		Email:    "mfa_user_repository@email.com",
+		Name:     "mfa_user_repository",
+		Password: "mfa_user_repository",
+	}
+
+	err := gormDB.Create(user).Error
+	require.NoError(t, err)
+
+	t.Run("It should use a TOTP step only once", func(t *testing.T) {
+		used, err := userRepository.UseTOTPStep(t.Context(), user.ID, 100)
+		require.NoError(t, err)
+		assert.True(t, used)
+
+		used, err = userRepository.UseTOTPStep(t.Context(), user.ID, 100)
+		require.NoError(t, err)
+		assert.False(t, used)
+
+		used, err = userRepository.UseTOTPStep(t.Context(), user.ID, 99)
+		require.NoError(t, err)
+		assert.False(t, used)
+
+		gotUser, err := userRepository.GetByID(t.Context(), user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), gotUser.TOTPLastStep)
+	})
+
+	t.Run("It should count concurrent failed attempts and lock at the limit", func(t *testing.T) {
+		lockedUntil := time.Now().Add(15 * time.Minute).UTC().Truncate(time.Microsecond)
+
+		var (
+			wg     sync.WaitGroup
+			mu     sync.Mutex
+			locked int
+		)
+
+		for range 4 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				gotLocked, err := userRepository.RecordMFAFailure(t.Context(), user.ID, 5, lockedUntil)
+				assert.NoError(t, err)
+
+				if gotLocked {
+					mu.Lock()
+					locked++
+					mu.Unlock()
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		assert.Zero(t, locked)
+
+		gotUser, err := userRepository.GetByID(t.Context(), user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 4, gotUser.MFAFailedAttempts)
+		assert.Nil(t, gotUser.MFALockedUntil)
+
+		gotLocked, err := userRepository.RecordMFAFailure(t.Context(), user.ID, 5, lockedUntil)
+		require.NoError(t, err)
+		assert.True(t, gotLocked)
+
+		gotUser, err = userRepository.GetByID(t.Context(), user.ID)
+		require.NoError(t, err)
+		assert.Zero(t, gotUser.MFAFailedAttempts)
+		require.NotNil(t, gotUser.MFALockedUntil)
+		assert.True(t, lockedUntil.Equal(*gotUser.MFALockedUntil))
+	})
+
+	t.Run("It should reset the failed attempts and the lock", func(t *testing.T) {
+		_, err := userRepository.RecordMFAFailure(t.Context(), user.ID, 5, time.Now())
+		require.NoError(t, err)
+
+		err = userRepository.ResetMFAFailures(t.Context(), user.ID)
+		require.NoError(t, err)
+
+		gotUser, err := userRepository.GetByID(t.Context(), user.ID)
+		require.NoError(t, err)
+		assert.Zero(t, gotUser.MFAFailedAttempts)
+		assert.Nil(t, gotUser.MFALockedUntil)
+	})
+
+	t.Run("It should return an error if the user is not found", func(t *testing.T) {
+		_, err := userRepository.RecordMFAFailure(t.Context(), 999, 5, time.Now())
+		assert.ErrorIs(t, err, models.ErrUserNotFound)
+	})
 }
@@ -0,0 +1,59 @@
+package setup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	redisImage = "redis:7-alpine"
+	redisPort  = "6379"
+)
+
+// SetupRedis starts a Redis container and returns its address.
+func SetupRedis(ctx context.Context) (_ string, _ func(ctx context.Context) error, err error) {
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        redisImage,
+			ExposedPorts: []string{redisPort + "/tcp"},
+			WaitingFor:   wait.ForLog("Ready to accept connections").WithStartupTimeout(time.Minute),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("run redis container: %w", err)
+	}
+
+	shutdown := func(ctx context.Context) error {
+		if err := container.Terminate(ctx); err != nil {
+			return fmt.Errorf("terminate redis container: %w", err)
+		}
+		return nil
+	}
+
+	defer func() {
+		if err == nil {
+			return
+		}
+		if errShutdown := shutdown(ctx); errShutdown != nil {
+			err = errors.Join(err, errShutdown)
+		}
+	}()
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("get redis host: %w", err)
+	}
+
+	port, err := container.MappedPort(ctx, redisPort+"/tcp")
+	if err != nil {
+		return "", nil, fmt.Errorf("get redis exposed port: %w", err)
+	}
+
+	return host + ":" + port.Port(), shutdown, nil
+}